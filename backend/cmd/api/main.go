@@ -1,23 +1,187 @@
 package main
 
 import (
-	"log"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"tokentide/internal/app"
+	"tokentide/internal/domain"
+	"tokentide/internal/repository"
+	"tokentide/internal/seed"
+	"tokentide/internal/service"
+	"tokentide/migrations"
 	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+
+	"gorm.io/gorm"
 )
 
+// usage lists the commands; with none, the API is served
+const usage = `usage: api [flags] [command]
+
+commands:
+  serve                        serve the API (the default)
+  worker                       work background jobs instead of serving the API
+  migrate up|down|status       apply, roll back or show the schema migrations
+  seed                         fill a development database with demo data
+  create-admin <email> <name>  create an admin account, reading its password from stdin
+  grant-admin <email>          promote an existing account to admin
+  config print                 print every setting and where its value came from
+`
+
+// arity is the number of arguments each command takes
+var arity = map[string]int{
+	"serve":        0,
+	"worker":       0,
+	"migrate":      1,
+	"seed":         0,
+	"create-admin": 2,
+	"grant-admin":  1,
+	"config":       1,
+}
+
 func main() {
-	config.LoadConfig()
+	cfg, args, err := config.LoadConfig(os.Args[1:])
+	if err != nil {
+		fatal(slog.Default(), "Could not load configuration", err)
+	}
+
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	if n, ok := arity[command]; !ok || len(args) != n || (command == "config" && args[0] != "print") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if command == "config" {
+		if err := config.Print(os.Stdout); err != nil {
+			fatal(slog.Default(), "Could not print configuration", err)
+		}
+		return
+	}
 
-	db, err := config.SetupDatabase()
+	logger := logging.New(os.Stdout, cfg.LogLevel)
+	slog.SetDefault(logger)
+	config.Log(logger)
+
+	db, err := config.SetupDatabase(cfg.Database)
 	if err != nil {
-		log.Fatalf("Could not connect to the database: %v", err)
+		fatal(logger, "Could not connect to the database", err)
+	}
+	// Run and RunWorker close the database themselves
+	if command != "serve" && command != "worker" {
+		defer config.CloseDatabase(db)
 	}
 
-	// Automatically migrate the database (optional, depending on your entities)
-	db.AutoMigrate( /* Add your models here */ )
+	// Serve until SIGINT/SIGTERM, then shut down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = logging.WithLogger(ctx, logger)
 
-	// Setup and run Fiber router
-	router := app.SetupRouter()
-	log.Fatal(router.Listen(":3000"))
+	switch command {
+	case "migrate":
+		if err := runMigrate(db, args[0]); err != nil {
+			fatal(logger, "Could not migrate the database", err)
+		}
+	case "seed":
+		if err := seed.Run(ctx, db); err != nil {
+			fatal(logger, "Could not seed the database", err)
+		}
+	case "create-admin":
+		if err := createAdmin(ctx, db, args[0], args[1]); err != nil {
+			fatal(logger, "Could not create the admin", err)
+		}
+	case "grant-admin":
+		if err := grantAdmin(db, args[0]); err != nil {
+			fatal(logger, "Could not grant the admin role", err)
+		}
+		logger.Info("Admin role granted", "email", args[0])
+	case "worker":
+		if err := app.RunWorker(ctx, cfg, db); err != nil {
+			fatal(logger, "Worker failed", err)
+		}
+	default:
+		if err := app.Run(ctx, cfg, db); err != nil {
+			fatal(logger, "Server failed", err)
+		}
+	}
+}
+
+// fatal logs err and exits with a failure status
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// createAdmin creates an admin account with the password on the first line of
+// stdin, so it stays out of the shell history. Audited like a signup.
+func createAdmin(ctx context.Context, db *gorm.DB, email, name string) error {
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	// Creating an admin starts no session, so the service needs no session service
+	artists := service.NewAuditedArtistService(service.NewArtistService(repository.NewArtistRepository(db), nil, repository.NewTxManager(db)), repository.NewAuditRepository(db))
+	_, err = artists.CreateAdmin(ctx, name, email, strings.TrimRight(password, "\r\n"))
+	return err
+}
+
+// grantAdmin promotes the account registered with email to admin. It is how
+// the first admin is appointed; later ones can be promoted through the API.
+func grantAdmin(db *gorm.DB, email string) error {
+	ctx := context.Background()
+	artists := repository.NewArtistRepository(db)
+	artist, err := artists.GetArtistByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return err
+	}
+	return artists.UpdateRole(ctx, artist.ID, domain.RoleAdmin)
+}
+
+// runMigrate applies the "migrate up|down|status" subcommand. Schema changes
+// are never applied by the server itself, run "migrate up" before deploying.
+func runMigrate(db *gorm.DB, command string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	switch command {
+	case "up":
+		return migrations.Up(sqlDB)
+	case "down":
+		return migrations.Down(sqlDB)
+	case "status":
+		status, err := migrations.GetStatus(sqlDB)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version %d", status.Version)
+		if status.Dirty {
+			fmt.Print(" (dirty)")
+		}
+		fmt.Println()
+		for _, m := range status.Migrations {
+			state := "pending"
+			if m.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d %s %s\n", m.Version, m.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", command)
+	}
 }
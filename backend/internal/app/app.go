@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+
+	"gorm.io/gorm"
+)
+
+// drainTimeout bounds how long in-flight requests may take to finish on shutdown
+const drainTimeout = 30 * time.Second
+
+// flushTimeout bounds how long buffered spans may take to export on shutdown
+const flushTimeout = 5 * time.Second
+
+// Run serves the API until ctx is cancelled. It then stops accepting
+// connections, drains in-flight requests, waits for background workers to
+// finish and closes the database pools, which Run takes ownership of.
+func Run(ctx context.Context, cfg *config.Config, db *gorm.DB) error {
+	defer config.CloseDatabase(db)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer func() {
+		// Flush spans still buffered for export
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logging.FromContext(ctx).Error("Could not flush traces", "error", err)
+		}
+	}()
+
+	var workers sync.WaitGroup
+	defer workers.Wait()
+	// Workers keep the logger carried in ctx but outlive its cancellation
+	workerCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopWorkers()
+
+	router, err := SetupRouter(workerCtx, &workers, cfg, db)
+	if err != nil {
+		return fmt.Errorf("set up router: %w", err)
+	}
+
+	ln, err := Listen(":"+cfg.Port, cfg.ReusePort)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- router.Listener(ln)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Stop accepting connections and drain in-flight requests, letting a
+	// replacement process bound to the same port take over
+	logger := logging.FromContext(ctx)
+	logger.Info("Shutting down, draining connections", "timeout", drainTimeout.String())
+	if err := router.ShutdownWithTimeout(drainTimeout); err != nil {
+		logger.Error("Could not drain connections", "error", err)
+	}
+	if err := <-served; err != nil && !errors.Is(err, context.Canceled) {
+		logger.Error("Server stopped with error", "error", err)
+	}
+
+	stopWorkers()
+	workers.Wait()
+	logger.Info("Background workers stopped, closing the database pool")
+	return nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"tokentide/internal/domain"
+	"tokentide/internal/email"
+	"tokentide/internal/exchange"
+	"tokentide/internal/jobs"
+	"tokentide/internal/payment"
+	"tokentide/internal/realtime"
+	"tokentide/internal/repository"
+	"tokentide/internal/service"
+	"tokentide/internal/webhook"
+	"tokentide/pkg/config"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"gorm.io/gorm"
+)
+
+// newJobClient builds the background job queue client with a worker for
+// every job kind. Only a worker client works jobs; others just enqueue them.
+func newJobClient(ctx context.Context, cfg *config.Config, db *gorm.DB, pool *pgxpool.Pool, worker bool) (*jobs.Client, error) {
+	workers := river.NewWorkers()
+	river.AddWorker(workers, webhook.NewDeliveryWorker(repository.NewWebhookRepository(db), cfg.WebhookAllowPrivateNetworks))
+	river.AddWorker(workers, email.NewSendWorker(email.NewMailer(cfg.Email), repository.NewArtistRepository(db), repository.NewNotificationRepository(db)))
+
+	// Nobody listens to this hub, so scheduled gifts are not pushed to live notifications
+	gifts := repository.NewGiftRepository(db)
+	currencies := service.NewCurrencyService(exchange.New(cfg.Currency), cfg.Currency.TokenCurrency, cfg.Currency.TokenValueMinor)
+	wallets := service.NewWalletService(repository.NewWalletRepository(db), repository.NewTransactionRepository(db), gifts, currencies, realtime.NewHub(), repository.NewTxManager(db))
+	river.AddWorker(workers, jobs.NewRunGiftSchedulesWorker(service.NewGiftScheduleService(repository.NewGiftScheduleRepository(db), gifts, wallets)))
+
+	river.AddWorker(workers, jobs.NewRefreshGiftStatsWorker(service.NewAnalyticsService(repository.NewAnalyticsRepository(db), repository.NewArtistRepository(db))))
+
+	periodic := []*river.PeriodicJob{jobs.RunGiftSchedulesSchedule(), jobs.RefreshGiftStatsSchedule()}
+	if cfg.Stripe.Enabled() {
+		provider := payment.NewStripeProvider(cfg.Stripe.SecretKey, cfg.Stripe.WebhookSecret, cfg.Stripe.SuccessURL, cfg.Stripe.CancelURL)
+		purchases := repository.NewPurchaseRepository(db)
+		payments := service.NewPaymentService(purchases, wallets, provider)
+		refunds := service.NewRefundService(repository.NewRefundRepository(db), purchases, repository.NewTransactionRepository(db), wallets, provider)
+		river.AddWorker(workers, jobs.NewReconcilePaymentsWorker(payments, refunds))
+
+		payoutProvider := payment.NewStripeConnect(cfg.Stripe.SecretKey, cfg.Payout.ReturnURL, cfg.Payout.RefreshURL)
+		payouts := service.NewPayoutService(repository.NewPayoutRepository(db), wallets, repository.NewArtistRepository(db), payoutProvider, domain.PayoutTerms{
+			TokenCurrency:   cfg.Currency.TokenCurrency,
+			TokenValueMinor: cfg.Currency.TokenValueMinor,
+			FeeRate:         cfg.Payout.FeeRate,
+		})
+		river.AddWorker(workers, jobs.NewReconcilePayoutsWorker(payouts))
+		periodic = append(periodic, jobs.ReconcilePaymentsSchedule(), jobs.ReconcilePayoutsSchedule())
+	}
+
+	return jobs.NewClient(ctx, pool, workers, periodic, cfg.Jobs, worker)
+}
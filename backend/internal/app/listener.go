@@ -0,0 +1,17 @@
+package app
+
+import (
+	"net"
+
+	"github.com/valyala/fasthttp/reuseport"
+)
+
+// Listen opens the API listener. With reusePort the socket is bound with
+// SO_REUSEPORT so a newly deployed binary can start accepting connections
+// on the same port while the old one drains.
+func Listen(addr string, reusePort bool) (net.Listener, error) {
+	if reusePort {
+		return reuseport.Listen("tcp4", addr)
+	}
+	return net.Listen("tcp", addr)
+}
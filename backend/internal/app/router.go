@@ -1,16 +1,322 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+	"tokentide/internal/auth"
+	"tokentide/internal/cache"
+	"tokentide/internal/chaos"
+	grpcapi "tokentide/internal/delivery/grpc"
 	"tokentide/internal/delivery/http"
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+	"tokentide/internal/email"
+	"tokentide/internal/exchange"
+	"tokentide/internal/health"
+	"tokentide/internal/metrics"
+	"tokentide/internal/outbox"
+	"tokentide/internal/payment"
+	"tokentide/internal/ratelimit"
+	"tokentide/internal/realtime"
+	"tokentide/internal/repository"
+	"tokentide/internal/service"
+	"tokentide/internal/storage"
+	"tokentide/internal/tracing"
+	"tokentide/internal/webhook"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
 )
 
-func SetupRouter() *fiber.App {
-	app := fiber.New()
+// SetupRouter builds the API, with every version of it mounted under its
+// prefix next to the unversioned probes, metrics and links. Background
+// workers it starts run until ctx is cancelled and are tracked in workers so
+// shutdown can wait for them.
+func SetupRouter(ctx context.Context, workers *sync.WaitGroup, cfg *config.Config, db *gorm.DB) (*fiber.App, error) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: http.ErrorHandler,
+		// The largest body read at all; BodyLimit below holds all but uploads to less
+		BodyLimit: cfg.UploadBodyLimit,
+	})
 
-	// Health check endpoint
-	app.Get("/healths", http.HealthCheck)
+	// Request metrics, outermost so they see the status of rendered errors
+	if err := metrics.InstrumentGORM(db); err != nil {
+		return nil, err
+	}
+	app.Use(middleware.Metrics())
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})))
 
-	return app
+	// Request, service and query spans; a no-op unless an OTLP endpoint is configured
+	if err := tracing.InstrumentGORM(db); err != nil {
+		return nil, err
+	}
+	app.Use(middleware.Tracing())
+
+	// Liveness and readiness probes, ahead of request logging and fault
+	// injection so frequent polling neither floods the logs nor gets faulted
+	prober := health.NewProber(2 * time.Second)
+	prober.Register("postgres", health.PostgresCheck(db))
+	handlers := Handlers{Health: http.NewHealthHandler(prober)}
+	app.Get("/healthz", handlers.Health.Live)
+	app.Get("/readyz", handlers.Health.Ready)
+
+	// Tag every request with an ID and a logger carrying it, ahead of the
+	// rest of the chain so it logs through it
+	app.Use(middleware.RequestID(logging.FromContext(ctx)))
+
+	// Security headers and bounded bodies on every request, and the
+	// cross-origin calls of the browser frontend, whose preflights end here
+	app.Use(middleware.SecurityHeaders(), middleware.BodyLimit(cfg.BodyLimit, cfg.UploadBodyLimit, fiber.MethodPut+" "+v1Prefix+"/gifts/*/image"))
+	if cfg.CORS.Enabled() {
+		app.Use(middleware.CORS(cfg.CORS.AllowedOrigins, cfg.CORS.AllowedMethods, cfg.CORS.AllowCredentials, cfg.CORS.MaxAge))
+	}
+
+	// Fault injection for resilience testing, opt-in only
+	var injector *chaos.Injector
+	if cfg.ChaosEnabled {
+		injector = chaos.NewInjector()
+		// Never fault the admin routes used to turn faults off again
+		app.Use(middleware.FaultInjection(injector, v1Prefix+"/admin"))
+	}
+
+	// Mirror a sample of read traffic to a secondary deployment
+	if cfg.Shadow.Enabled() {
+		app.Use(middleware.Shadow(cfg.Shadow.URL, cfg.Shadow.Percent, workers))
+	}
+
+	// Short-lived access tokens carry the account, its role and session
+	tokens := auth.NewJWTIssuer(cfg.JWT.Secret, cfg.JWT.TTL)
+	guards := Guards{Authenticate: middleware.Authenticate(tokens)}
+
+	// Admin and debug surfaces are only reachable by admins on allowlisted networks
+	allowlist, err := middleware.IPAllowlist(cfg.AdminAllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	guards.Admin = []fiber.Handler{allowlist, guards.Authenticate, middleware.RequireRole(domain.RoleAdmin)}
+	app.Group("/debug", guards.Admin...)
+
+	// Optional Redis cache for hot gift and artist reads
+	var hotCache *cache.Redis
+	if cfg.Cache.Enabled() {
+		hotCache, err = cache.NewRedis(cfg.Cache.RedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("redis: %w", err)
+		}
+		prober.Register("redis", hotCache.Ping)
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			<-ctx.Done()
+			hotCache.Close()
+		}()
+	}
+
+	// Rate limits on the routes most open to abuse, shared through Redis when it is configured
+	guards.AuthLimit = func(c *fiber.Ctx) error { return c.Next() }
+	guards.GiftLimit = guards.AuthLimit
+	if cfg.RateLimit.Enabled {
+		newLimiter := func(rate config.Rate) ratelimit.Limiter {
+			if hotCache != nil {
+				return ratelimit.NewRedis(hotCache.Client(), rate)
+			}
+			return ratelimit.NewMemory(rate)
+		}
+		guards.AuthLimit = middleware.RateLimit(newLimiter(cfg.RateLimit.Auth), "auth")
+		guards.GiftLimit = middleware.RateLimit(newLimiter(cfg.RateLimit.Gifts), "gifts")
+	}
+
+	// Background job queue, worked here as well unless separate worker processes do it
+	pool, err := pgxpool.New(ctx, cfg.Database.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("job queue pool: %w", err)
+	}
+	jobClient, err := newJobClient(ctx, cfg, db, pool, cfg.Jobs.InProcess)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("job queue: %w", err)
+	}
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := jobClient.Run(ctx); err != nil {
+			logging.FromContext(ctx).Error("Job queue stopped with error", "error", err)
+		}
+		<-ctx.Done()
+		pool.Close()
+	}()
+	handlers.Jobs = http.NewJobHandler(jobClient)
+
+	// Relay domain events recorded in the outbox to the broker, and queue
+	// deliveries to the webhooks artists registered and notification emails
+	webhookRepository := repository.NewWebhookRepository(db)
+	broker, err := outbox.NewBroker(ctx, cfg.Outbox)
+	if err != nil {
+		return nil, fmt.Errorf("outbox broker: %w", err)
+	}
+	broker = outbox.Fanout{webhook.NewNotifier(webhookRepository, jobClient), email.NewNotifier(jobClient), broker}
+	relay := outbox.NewRelay(repository.NewOutboxRepository(db), broker)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		relay.Run(ctx)
+		broker.Close()
+	}()
+
+	// Public status page fed by a rolling day of one-minute probes
+	tracker := health.NewTracker(prober, time.Minute, 24*60)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		tracker.Run(ctx)
+	}()
+	handlers.Status = http.NewStatusHandler(service.NewStatusService(repository.NewIncidentRepository(db), tracker))
+
+	if injector != nil {
+		handlers.Chaos = http.NewChaosHandler(injector)
+	}
+
+	// Units of work services run across several repositories
+	txManager := repository.NewTxManager(db)
+
+	// Audit log of the changes made to gifts and accounts
+	auditRepository := repository.NewAuditRepository(db)
+	handlers.Audit = http.NewAuditHandler(service.NewAuditService(auditRepository))
+
+	// Fan and artist accounts and authentication
+	guards.Idempotent = middleware.Idempotency(repository.NewIdempotencyRepository(db))
+	artistRepository := repository.NewArtistRepository(db)
+	if hotCache != nil {
+		artistRepository = repository.NewCachedArtistRepository(artistRepository, hotCache, cfg.Cache.ArtistTTL)
+	}
+	sessionService := service.NewSessionService(repository.NewSessionRepository(db), artistRepository, tokens, cfg.JWT.TTL, cfg.JWT.RefreshTTL)
+	artistService := service.NewAuditedArtistService(service.NewArtistService(artistRepository, sessionService, txManager), auditRepository)
+	handlers.Artists = http.NewArtistHandler(artistService)
+	handlers.Sessions = http.NewSessionHandler(sessionService)
+
+	// API keys for server integrations; the routes they may reach take
+	// Scoped(scope) instead of Authenticate
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db), artistRepository)
+	guards.Scoped = func(scope string) fiber.Handler {
+		return middleware.AuthenticateScoped(tokens, apiKeyService, scope)
+	}
+	handlers.APIKeys = http.NewAPIKeyHandler(apiKeyService)
+
+	// Leaderboards and statistics, from the aggregates the background workers refresh
+	handlers.Analytics = http.NewAnalyticsHandler(service.NewAnalyticsService(repository.NewAnalyticsRepository(db), artistRepository))
+
+	// Categories and tags gifts are browsed by
+	handlers.Catalog = http.NewCatalogHandler(service.NewCatalogService(repository.NewCatalogRepository(db)))
+
+	// Gifts
+	giftRepository := repository.NewGiftRepository(db)
+	if hotCache != nil {
+		giftRepository = repository.NewCachedGiftRepository(giftRepository, hotCache, cfg.Cache.GiftTTL)
+	}
+	files, err := storage.New(ctx, cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("file storage: %w", err)
+	}
+	if cfg.Storage.Backend == config.StorageLocal {
+		app.Static(config.LocalMediaPath, cfg.Storage.LocalDir, fiber.Static{MaxAge: 365 * 24 * 60 * 60})
+	}
+	// Gift prices are converted at the rates of the configured rates service
+	currencies := service.NewCurrencyService(exchange.New(cfg.Currency), cfg.Currency.TokenCurrency, cfg.Currency.TokenValueMinor)
+	giftService := service.NewAuditedGiftService(service.NewGiftService(giftRepository, files), auditRepository)
+	handlers.Gifts = http.NewGiftHandler(giftService, currencies)
+
+	// Live notifications over WebSocket; the hub disconnects clients on shutdown
+	hub := realtime.NewHub()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		<-ctx.Done()
+		hub.Close()
+	}()
+	handlers.Notifications = http.NewNotificationHandler(hub)
+
+	// Token wallets; arbitrary credits and debits are an admin operation
+	walletService := service.NewWalletService(repository.NewWalletRepository(db), repository.NewTransactionRepository(db), giftRepository, currencies, hub, txManager)
+	handlers.Wallets = http.NewWalletHandler(walletService)
+
+	// Scheduled and recurring gifts, sent by the background workers
+	handlers.Schedules = http.NewGiftScheduleHandler(service.NewGiftScheduleService(repository.NewGiftScheduleRepository(db), giftRepository, walletService))
+
+	// Token purchases through Stripe, only when it is configured
+	purchaseRepository := repository.NewPurchaseRepository(db)
+	var provider domain.PaymentProvider
+	if cfg.Stripe.Enabled() {
+		provider = payment.NewStripeProvider(cfg.Stripe.SecretKey, cfg.Stripe.WebhookSecret, cfg.Stripe.SuccessURL, cfg.Stripe.CancelURL)
+		handlers.Payments = http.NewPaymentHandler(service.NewPaymentService(purchaseRepository, walletService, provider))
+	}
+
+	// Refunds of purchases and gift sends; purchases need a payment provider
+	handlers.Refunds = http.NewRefundHandler(service.NewRefundService(repository.NewRefundRepository(db), purchaseRepository, repository.NewTransactionRepository(db), walletService, provider))
+
+	// Artist payouts of gift earnings through Stripe Connect, approved by admins;
+	// earnings can be read but not withdrawn unless Stripe is configured
+	var payoutProvider domain.PayoutProvider
+	if cfg.Stripe.Enabled() {
+		payoutProvider = payment.NewStripeConnect(cfg.Stripe.SecretKey, cfg.Payout.ReturnURL, cfg.Payout.RefreshURL)
+	}
+	handlers.Payouts = http.NewPayoutHandler(service.NewPayoutService(repository.NewPayoutRepository(db), walletService, artistRepository, payoutProvider, domain.PayoutTerms{
+		TokenCurrency:   cfg.Currency.TokenCurrency,
+		TokenValueMinor: cfg.Currency.TokenValueMinor,
+		FeeRate:         cfg.Payout.FeeRate,
+	}))
+
+	// Full-text search over gifts and artists
+	handlers.Search = http.NewSearchHandler(service.NewSearchService(repository.NewSearchRepository(db)))
+
+	// Webhooks notifying artists' integrations of the events concerning them
+	handlers.Webhooks = http.NewWebhookHandler(service.NewWebhookService(webhookRepository))
+
+	// Notification emails an account opted into
+	handlers.Preferences = http.NewNotificationPreferencesHandler(service.NewNotificationService(repository.NewNotificationRepository(db)))
+
+	// Campaign short links, shared as unversioned URLs
+	handlers.ShortLinks = http.NewShortLinkHandler(service.NewShortLinkService(repository.NewShortLinkRepository(db), cfg.ShortLinkHosts))
+	app.Get("/l/:code", handlers.ShortLinks.Redirect)
+
+	// Every version of the API, sharing the handlers and guards above
+	for _, group := range []RouterGroup{NewV1(handlers, guards)} {
+		group.Register(app.Group(group.Prefix()))
+	}
+
+	// gRPC API for internal services, over the same services as the routes
+	// above; it stops with the workers
+	if cfg.GRPCPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			return nil, fmt.Errorf("grpc: %w", err)
+		}
+		server := grpcapi.NewServer(logging.FromContext(ctx), tokens, apiKeyService, grpcapi.Services{
+			Artists: artistService,
+			Gifts:   giftService,
+			Wallets: walletService,
+		})
+		go func() {
+			if err := server.Serve(listener); err != nil {
+				logging.FromContext(ctx).Error("gRPC server stopped", "error", err)
+			}
+		}()
+		// Calls in flight finish before shutdown moves on
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			<-ctx.Done()
+			server.GracefulStop()
+		}()
+	}
+
+	return app, nil
 }
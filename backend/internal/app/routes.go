@@ -0,0 +1,60 @@
+package app
+
+import (
+	"tokentide/internal/delivery/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RouterGroup is a version of the HTTP API. Each version registers its
+// routes under its own prefix, so a new version can change the shape of
+// requests and responses while clients of the older ones keep working.
+type RouterGroup interface {
+	// Prefix is the path the version is mounted at, such as /api/v1
+	Prefix() string
+	// Register adds the version's routes to router, which is mounted at Prefix
+	Register(router fiber.Router)
+}
+
+// Handlers are the HTTP handlers the API versions route requests to. They
+// are built once and shared, so versions only differ where a handler does.
+type Handlers struct {
+	Health        *http.HealthHandler
+	Jobs          *http.JobHandler
+	Status        *http.StatusHandler
+	Audit         *http.AuditHandler
+	Artists       *http.ArtistHandler
+	Sessions      *http.SessionHandler
+	APIKeys       *http.APIKeyHandler
+	Analytics     *http.AnalyticsHandler
+	Catalog       *http.CatalogHandler
+	Gifts         *http.GiftHandler
+	Notifications *http.NotificationHandler
+	Wallets       *http.WalletHandler
+	Schedules     *http.GiftScheduleHandler
+	Refunds       *http.RefundHandler
+	Payouts       *http.PayoutHandler
+	Search        *http.SearchHandler
+	Webhooks      *http.WebhookHandler
+	Preferences   *http.NotificationPreferencesHandler
+	ShortLinks    *http.ShortLinkHandler
+	// Payments is nil unless Stripe is configured
+	Payments *http.PaymentHandler
+	// Chaos is nil unless fault injection is enabled
+	Chaos *http.ChaosHandler
+}
+
+// Guards are the middleware the API versions protect their routes with
+type Guards struct {
+	// Authenticate requires a signed-in account
+	Authenticate fiber.Handler
+	// Scoped requires a signed-in account or an API key with the scope
+	Scoped func(scope string) fiber.Handler
+	// Admin restricts a group to admins on the allowlisted networks
+	Admin []fiber.Handler
+	// Idempotent replays the response to a repeated Idempotency-Key
+	Idempotent fiber.Handler
+	// AuthLimit and GiftLimit rate limit the routes most open to abuse
+	AuthLimit fiber.Handler
+	GiftLimit fiber.Handler
+}
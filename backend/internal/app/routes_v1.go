@@ -0,0 +1,151 @@
+package app
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const v1Prefix = "/api/v1"
+
+// V1 is the first version of the API
+type V1 struct {
+	handlers Handlers
+	guards   Guards
+}
+
+func NewV1(handlers Handlers, guards Guards) *V1 {
+	return &V1{handlers: handlers, guards: guards}
+}
+
+func (v *V1) Prefix() string {
+	return v1Prefix
+}
+
+func (v *V1) Register(router fiber.Router) {
+	h, authenticate, scoped, idempotent := v.handlers, v.guards.Authenticate, v.guards.Scoped, v.guards.Idempotent
+	admin := router.Group("/admin", v.guards.Admin...)
+
+	// Operations
+	admin.Get("/health/dependencies", h.Health.Dependencies)
+	admin.Get("/jobs/dead", h.Jobs.ListDeadJobs)
+	admin.Post("/jobs/:id/retry", h.Jobs.RetryJob)
+	router.Get("/status", h.Status.GetStatus)
+	admin.Post("/incidents", h.Status.OpenIncident)
+	admin.Patch("/incidents/:id", h.Status.UpdateIncident)
+	admin.Post("/incidents/:id/resolve", h.Status.ResolveIncident)
+	if h.Chaos != nil {
+		admin.Get("/chaos", h.Chaos.GetRules)
+		admin.Put("/chaos", h.Chaos.SetRules)
+		admin.Delete("/chaos", h.Chaos.ClearRules)
+	}
+	admin.Get("/audit", h.Audit.ListEntries)
+
+	// Fan and artist accounts and authentication
+	router.Post("/auth/signup", v.guards.AuthLimit, h.Artists.Signup)
+	router.Post("/auth/login", v.guards.AuthLimit, h.Artists.Login)
+	router.Post("/auth/refresh", v.guards.AuthLimit, h.Sessions.Refresh)
+	router.Post("/auth/logout", h.Sessions.Logout)
+	router.Get("/auth/sessions", authenticate, h.Sessions.ListSessions)
+	router.Delete("/auth/sessions/:id", authenticate, h.Sessions.RevokeSession)
+	router.Get("/artists/:id", h.Artists.GetArtist)
+	admin.Get("/users", h.Artists.ListArtists)
+	admin.Put("/users/:id/role", h.Artists.SetRole)
+	admin.Delete("/users/:id", h.Artists.DeleteArtist)
+
+	// API keys are managed by a signed-in account only
+	router.Post("/api-keys", authenticate, h.APIKeys.CreateAPIKey)
+	router.Get("/api-keys", authenticate, h.APIKeys.ListAPIKeys)
+	router.Delete("/api-keys/:id", authenticate, h.APIKeys.RevokeAPIKey)
+
+	// Leaderboards and statistics
+	router.Get("/artists/:id/leaderboard", h.Analytics.GetLeaderboard)
+	router.Get("/artists/:id/stats", scoped(domain.ScopeStatsRead), h.Analytics.GetStats)
+
+	// Categories and tags gifts are browsed by
+	router.Get("/categories", h.Catalog.ListCategories)
+	router.Get("/tags", h.Catalog.ListTags)
+	admin.Post("/categories", h.Catalog.CreateCategory)
+	admin.Put("/categories/:id", h.Catalog.UpdateCategory)
+	admin.Delete("/categories/:id", h.Catalog.DeleteCategory)
+	admin.Post("/tags", h.Catalog.CreateTag)
+	admin.Put("/tags/:id", h.Catalog.UpdateTag)
+	admin.Delete("/tags/:id", h.Catalog.DeleteTag)
+
+	// Gifts
+	router.Post("/gifts", scoped(domain.ScopeGiftsWrite), middleware.RequireRole(domain.RoleArtist, domain.RoleAdmin), idempotent, h.Gifts.CreateGift)
+	router.Get("/gifts", h.Gifts.ListGifts)
+	router.Get("/gifts/:id", h.Gifts.GetGift)
+	router.Get("/gifts/:id/prices", h.Gifts.ListPriceHistory)
+	router.Put("/gifts/:id", scoped(domain.ScopeGiftsWrite), h.Gifts.UpdateGift)
+	router.Put("/gifts/:id/image", scoped(domain.ScopeGiftsWrite), h.Gifts.UploadImage)
+	router.Delete("/gifts/:id", scoped(domain.ScopeGiftsWrite), h.Gifts.DeleteGift)
+
+	// Live notifications over WebSocket
+	router.Get("/ws", middleware.TokenFromQuery("access_token"), scoped(domain.ScopeNotificationsRead), h.Notifications.Upgrade, websocket.New(h.Notifications.Stream))
+
+	// Token wallets; arbitrary credits and debits are an admin operation
+	router.Get("/wallets/me", scoped(domain.ScopeWalletRead), h.Wallets.GetMyWallet)
+	router.Post("/wallets/me/gifts", scoped(domain.ScopeGiftsSend), v.guards.GiftLimit, idempotent, h.Wallets.SendGift)
+	router.Get("/wallets/:id", scoped(domain.ScopeWalletRead), h.Wallets.GetWallet)
+	router.Get("/wallets/:id/transactions", scoped(domain.ScopeWalletRead), h.Wallets.ListTransactions)
+	admin.Post("/wallets/:id/credit", idempotent, h.Wallets.Credit)
+	admin.Post("/wallets/:id/debit", idempotent, h.Wallets.Debit)
+
+	// Scheduled and recurring gifts
+	router.Post("/schedules", scoped(domain.ScopeGiftsSend), idempotent, h.Schedules.CreateSchedule)
+	router.Get("/schedules", scoped(domain.ScopeGiftsSend), h.Schedules.ListSchedules)
+	router.Get("/schedules/:id", scoped(domain.ScopeGiftsSend), h.Schedules.GetSchedule)
+	router.Delete("/schedules/:id", scoped(domain.ScopeGiftsSend), h.Schedules.CancelSchedule)
+
+	// Token purchases, only when Stripe is configured. The Stripe webhook is
+	// registered ahead of the /webhooks group so it skips its authentication.
+	if h.Payments != nil {
+		router.Get("/token-packages", h.Payments.ListTokenPackages)
+		router.Post("/purchases", authenticate, v.guards.GiftLimit, idempotent, h.Payments.CreatePurchase)
+		router.Get("/purchases/:id", authenticate, h.Payments.GetPurchase)
+		router.Post("/webhooks/stripe", h.Payments.StripeWebhook)
+		admin.Post("/token-packages", h.Payments.CreateTokenPackage)
+	}
+
+	// Refunds of purchases and gift sends
+	admin.Post("/purchases/:id/refunds", idempotent, h.Refunds.RefundPurchase)
+	admin.Post("/transactions/:id/refunds", idempotent, h.Refunds.RefundGiftSend)
+	admin.Get("/refunds", h.Refunds.ListRefunds)
+	admin.Get("/refunds/:id", h.Refunds.GetRefund)
+
+	// Artist payouts, approved by admins
+	payouts := router.Group("/payouts", authenticate, middleware.RequireRole(domain.RoleArtist, domain.RoleAdmin))
+	payouts.Post("/account", h.Payouts.SetUpAccount)
+	payouts.Get("/account", h.Payouts.GetAccount)
+	payouts.Delete("/account", h.Payouts.DeleteAccount)
+	payouts.Get("/balance", h.Payouts.GetBalance)
+	payouts.Post("/", idempotent, h.Payouts.RequestPayout)
+	payouts.Get("/", h.Payouts.ListMyPayouts)
+	payouts.Get("/:id", h.Payouts.GetPayout)
+	admin.Get("/payouts", h.Payouts.ListPayouts)
+	admin.Post("/payouts/:id/approve", idempotent, h.Payouts.ApprovePayout)
+	admin.Post("/payouts/:id/reject", h.Payouts.RejectPayout)
+
+	// Full-text search over gifts and artists
+	router.Get("/search", h.Search.Search)
+
+	// Webhooks notifying artists' integrations of the events concerning them
+	webhooks := router.Group("/webhooks", scoped(domain.ScopeWebhooksManage), middleware.RequireRole(domain.RoleArtist, domain.RoleAdmin))
+	webhooks.Post("/", h.Webhooks.CreateWebhook)
+	webhooks.Get("/", h.Webhooks.ListWebhooks)
+	webhooks.Delete("/:id", h.Webhooks.DeleteWebhook)
+	webhooks.Get("/:id/deliveries", h.Webhooks.ListDeliveries)
+
+	// Notification emails an account opted into
+	router.Get("/notifications/preferences", authenticate, h.Preferences.GetPreferences)
+	router.Put("/notifications/preferences", authenticate, h.Preferences.UpdatePreferences)
+
+	// Campaign short links, readable by the account that created them; the
+	// links themselves redirect from /l/:code
+	links := router.Group("/links", authenticate, middleware.RequireRole(domain.RoleArtist, domain.RoleAdmin))
+	links.Post("/", h.ShortLinks.CreateShortLink)
+	links.Get("/:code/stats", h.ShortLinks.GetStats)
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tokentide/internal/delivery/http"
+	"tokentide/internal/health"
+	"tokentide/internal/metrics"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+// RunWorker works background jobs until ctx is cancelled, then lets running
+// jobs finish. It serves only the probes and metrics, on the API port, and
+// takes ownership of the database pools like Run.
+func RunWorker(ctx context.Context, cfg *config.Config, db *gorm.DB) error {
+	defer config.CloseDatabase(db)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logging.FromContext(ctx).Error("Could not flush traces", "error", err)
+		}
+	}()
+	if err := metrics.InstrumentGORM(db); err != nil {
+		return err
+	}
+	if err := tracing.InstrumentGORM(db); err != nil {
+		return err
+	}
+
+	pool, err := pgxpool.New(ctx, cfg.Database.DSN())
+	if err != nil {
+		return fmt.Errorf("job queue pool: %w", err)
+	}
+	defer pool.Close()
+
+	client, err := newJobClient(ctx, cfg, db, pool, true)
+	if err != nil {
+		return fmt.Errorf("job queue: %w", err)
+	}
+
+	prober := health.NewProber(2 * time.Second)
+	prober.Register("postgres", health.PostgresCheck(db))
+	healthHandler := http.NewHealthHandler(prober)
+	probes := fiber.New()
+	probes.Get("/healthz", healthHandler.Live)
+	probes.Get("/readyz", healthHandler.Ready)
+	probes.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})))
+
+	ln, err := Listen(":"+cfg.Port, false)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	go func() {
+		if err := probes.Listener(ln); err != nil {
+			logging.FromContext(ctx).Error("Probe server stopped with error", "error", err)
+		}
+	}()
+	defer probes.Shutdown()
+
+	logging.FromContext(ctx).Info("Working background jobs", "concurrency", cfg.Jobs.Concurrency)
+	return client.Run(ctx)
+}
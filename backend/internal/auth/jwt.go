@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTIssuer issues HMAC-signed access tokens whose subject is the artist ID
+// and which carry the account's role and session
+type JWTIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewJWTIssuer(secret string, ttl time.Duration) domain.TokenIssuer {
+	return &JWTIssuer{secret: []byte(secret), ttl: ttl}
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role      domain.Role `json:"role,omitempty"`
+	SessionID string      `json:"sid,omitempty"`
+}
+
+func (i *JWTIssuer) Issue(artistID string, role domain.Role, sessionID string) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   artistID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(i.ttl)),
+		},
+		Role:      role,
+		SessionID: sessionID,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+}
+
+func (i *JWTIssuer) Verify(token string) (domain.Identity, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return i.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Subject == "" {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+
+	// Tokens issued before roles existed belong to artists
+	if claims.Role == "" {
+		claims.Role = domain.RoleArtist
+	}
+	if !claims.Role.Valid() {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+	return domain.Identity{ArtistID: claims.Subject, Role: claims.Role, SessionID: claims.SessionID}, nil
+}
@@ -0,0 +1,129 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule describes the faults injected into requests whose path starts with Route
+type Rule struct {
+	Route       string  `json:"route"`
+	LatencyMS   int     `json:"latency_ms"`
+	ErrorRate   float64 `json:"error_rate"`
+	ErrorStatus int     `json:"error_status"`
+	DropRate    float64 `json:"drop_rate"`
+}
+
+// Fault is the outcome of evaluating a rule for a single request
+type Fault struct {
+	Latency     time.Duration
+	ErrorStatus int
+	Drop        bool
+}
+
+// Delay waits out the fault's latency, returning early with the context's
+// error if ctx is done first
+func (f Fault) Delay(ctx context.Context) error {
+	if f.Latency <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(f.Latency):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Injector holds the active fault rules; it is safe for concurrent use
+type Injector struct {
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+func NewInjector() *Injector {
+	return &Injector{}
+}
+
+// Rules returns a copy of the active rules
+func (i *Injector) Rules() []Rule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return append([]Rule{}, i.rules...)
+}
+
+// SetRules validates and replaces the active rules
+func (i *Injector) SetRules(rules []Rule) error {
+	for idx := range rules {
+		if err := validate(&rules[idx]); err != nil {
+			return fmt.Errorf("rule %d: %w", idx, err)
+		}
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.rules = append([]Rule{}, rules...)
+	return nil
+}
+
+// Clear removes every active rule
+func (i *Injector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.rules = nil
+}
+
+// Evaluate picks the most specific rule for the path and rolls the dice on it
+func (i *Injector) Evaluate(path string) (Fault, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var match *Rule
+	for idx := range i.rules {
+		rule := &i.rules[idx]
+		if strings.HasPrefix(path, rule.Route) && (match == nil || len(rule.Route) > len(match.Route)) {
+			match = rule
+		}
+	}
+	if match == nil {
+		return Fault{}, false
+	}
+
+	fault := Fault{Latency: time.Duration(match.LatencyMS) * time.Millisecond}
+	if match.DropRate > 0 && rand.Float64() < match.DropRate {
+		fault.Drop = true
+	} else if match.ErrorRate > 0 && rand.Float64() < match.ErrorRate {
+		fault.ErrorStatus = match.ErrorStatus
+	}
+	return fault, true
+}
+
+func validate(rule *Rule) error {
+	if !strings.HasPrefix(rule.Route, "/") {
+		return errors.New("route must start with /")
+	}
+	if rule.LatencyMS < 0 {
+		return errors.New("latency_ms must not be negative")
+	}
+	if rule.ErrorRate < 0 || rule.ErrorRate > 1 {
+		return errors.New("error_rate must be between 0 and 1")
+	}
+	if rule.DropRate < 0 || rule.DropRate > 1 {
+		return errors.New("drop_rate must be between 0 and 1")
+	}
+	if rule.ErrorStatus == 0 {
+		rule.ErrorStatus = 503
+	}
+	if rule.ErrorStatus < 400 || rule.ErrorStatus > 599 {
+		return errors.New("error_status must be a 4xx or 5xx code")
+	}
+	return nil
+}
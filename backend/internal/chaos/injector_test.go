@@ -0,0 +1,24 @@
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tokentide/internal/chaos"
+)
+
+func TestDelayReturnsEarlyWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := chaos.Fault{Latency: time.Minute}.Delay(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Delay error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Delay took %s, want it to return once the context is done", elapsed)
+	}
+}
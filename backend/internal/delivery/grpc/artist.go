@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+	tokentidev1 "tokentide/proto/tokentide/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type artistServer struct {
+	tokentidev1.UnimplementedArtistServiceServer
+	service domain.ArtistService
+}
+
+// GetArtist returns an account's public profile
+func (s *artistServer) GetArtist(ctx context.Context, req *tokentidev1.GetArtistRequest) (*tokentidev1.Artist, error) {
+	artist, err := s.service.GetArtistByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// The email is private to the artist, so the message has no field for it
+	return &tokentidev1.Artist{
+		Id:        artist.ID,
+		Name:      artist.Name,
+		Role:      string(artist.Role),
+		CreatedAt: timestamppb.New(artist.CreatedAt),
+	}, nil
+}
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"tokentide/internal/domain"
+	tokentidev1 "tokentide/proto/tokentide/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const apiKeyKey = "x-api-key"
+
+var errMissingToken = domain.NewError(domain.ErrUnauthorized, "missing_token", "missing bearer token")
+
+// access is who may call a method. The zero value needs a signed-in account
+// and refuses API keys, so methods left out of methods are never open to keys.
+type access struct {
+	// public methods need no credentials
+	public bool
+	// scope is the API key scope the method needs; without one API keys are refused
+	scope string
+	// roles, when set, are the only ones allowed
+	roles []domain.Role
+}
+
+// methods mirrors the access rules of the matching HTTP routes
+var methods = map[string]access{
+	tokentidev1.ArtistService_GetArtist_FullMethodName:        {public: true},
+	tokentidev1.GiftService_GetGift_FullMethodName:            {public: true},
+	tokentidev1.GiftService_ListGifts_FullMethodName:          {public: true},
+	tokentidev1.GiftService_CreateGift_FullMethodName:         {scope: domain.ScopeGiftsWrite, roles: []domain.Role{domain.RoleArtist, domain.RoleAdmin}},
+	tokentidev1.WalletService_GetMyWallet_FullMethodName:      {scope: domain.ScopeWalletRead},
+	tokentidev1.WalletService_GetWallet_FullMethodName:        {scope: domain.ScopeWalletRead},
+	tokentidev1.WalletService_ListTransactions_FullMethodName: {scope: domain.ScopeWalletRead},
+	tokentidev1.WalletService_SendGift_FullMethodName:         {scope: domain.ScopeGiftsSend},
+}
+
+// authenticate checks the caller's credentials against the method's access
+// rule and puts the identity in the context, like the Authenticate and
+// AuthenticateScoped middleware
+func authenticate(tokens domain.TokenIssuer, keys domain.APIKeyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rule := methods[info.FullMethod]
+		if rule.public {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := firstValue(md, apiKeyKey)
+		token, _ := strings.CutPrefix(firstValue(md, "authorization"), "Bearer ")
+		if key == "" && strings.HasPrefix(token, domain.APIKeyPrefix) {
+			key = token
+		}
+
+		var identity domain.Identity
+		var err error
+		switch {
+		case key != "":
+			if rule.scope == "" {
+				return nil, domain.ErrAPIKeyNotAllowed
+			}
+			identity, err = keys.VerifyAPIKey(ctx, key)
+			if err == nil && !slices.Contains(identity.Scopes, rule.scope) {
+				err = domain.ErrInsufficientScope
+			}
+		case token != "":
+			identity, err = tokens.Verify(token)
+		default:
+			err = errMissingToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rule.roles != nil && !slices.Contains(rule.roles, identity.Role) {
+			return nil, domain.ErrPermissionDenied
+		}
+
+		return handler(domain.WithIdentity(ctx, identity), req)
+	}
+}
+
+// caller returns the identity authenticate stored
+func caller(ctx context.Context) domain.Identity {
+	identity, _ := domain.IdentityFromContext(ctx)
+	return identity
+}
+
+// isOwnerOrAdmin reports whether the caller owns a resource owned by ownerID or is an admin
+func isOwnerOrAdmin(ctx context.Context, ownerID string) bool {
+	identity := caller(ctx)
+	return ownerID == identity.ArtistID || identity.Role == domain.RoleAdmin
+}
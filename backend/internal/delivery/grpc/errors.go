@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain names the API in the ErrorInfo detail of failed calls
+const errorDomain = "tokentide"
+
+// errorKinds maps each domain error kind to its status code, as the HTTP API
+// maps them to HTTP statuses
+var errorKinds = []struct {
+	kind error
+	code codes.Code
+}{
+	{domain.ErrNotFound, codes.NotFound},
+	{domain.ErrValidation, codes.InvalidArgument},
+	{domain.ErrConflict, codes.FailedPrecondition},
+	{domain.ErrInsufficientFunds, codes.FailedPrecondition},
+	{domain.ErrUnauthorized, codes.Unauthenticated},
+	{domain.ErrForbidden, codes.PermissionDenied},
+	{domain.ErrGone, codes.NotFound},
+	{domain.ErrUnavailable, codes.Unavailable},
+}
+
+// errInvalidRequest is returned when a request message fails validation
+var errInvalidRequest = domain.NewError(domain.ErrValidation, "invalid_request", "invalid request")
+
+// toStatus turns an error returned by a method into a status. Domain errors
+// are mapped by kind and carry their code as the reason of an ErrorInfo
+// detail; anything else is logged and reported as an opaque internal error.
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, k := range errorKinds {
+		if !errors.Is(err, k.kind) {
+			continue
+		}
+		st := status.New(k.code, err.Error())
+		var domainErr *domain.Error
+		if errors.As(err, &domainErr) {
+			if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: domainErr.Code, Domain: errorDomain}); detailErr == nil {
+				st = detailed
+			}
+		}
+		if serverFault(k.code) {
+			logging.FromContext(ctx).Error("Call failed", "error", err)
+		}
+		return st.Err()
+	}
+
+	logging.FromContext(ctx).Error("Call failed", "error", err)
+	return status.Error(codes.Internal, "internal server error")
+}
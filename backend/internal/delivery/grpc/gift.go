@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"tokentide/internal/domain"
+	tokentidev1 "tokentide/proto/tokentide/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// giftSorts are the orderings ListGifts accepts, as on the HTTP API
+var giftSorts = []string{
+	"", domain.GiftSortPrice, "-" + domain.GiftSortPrice, domain.GiftSortCreatedAt, "-" + domain.GiftSortCreatedAt,
+}
+
+type giftServer struct {
+	tokentidev1.UnimplementedGiftServiceServer
+	service domain.GiftService
+}
+
+// GetGift returns a single gift
+func (s *giftServer) GetGift(ctx context.Context, req *tokentidev1.GetGiftRequest) (*tokentidev1.Gift, error) {
+	gift, err := s.service.GetGiftByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return giftMessage(gift), nil
+}
+
+// ListGifts returns a page of gifts, optionally filtered by artist, category,
+// tag, currency and price range
+func (s *giftServer) ListGifts(ctx context.Context, req *tokentidev1.ListGiftsRequest) (*tokentidev1.ListGiftsResponse, error) {
+	if !slices.Contains(giftSorts, req.GetSort()) {
+		return nil, fmt.Errorf("%w: sort must be price, -price, created_at or -created_at", errInvalidRequest)
+	}
+	limit, offset := page(req.GetLimit(), req.GetOffset())
+
+	gifts, total, err := s.service.ListGifts(ctx, domain.GiftFilter{
+		ArtistID:   req.GetArtistId(),
+		CategoryID: req.GetCategoryId(),
+		TagID:      req.GetTagId(),
+		Currency:   req.GetCurrency(),
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		Sort:       req.GetSort(),
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &tokentidev1.ListGiftsResponse{Gifts: make([]*tokentidev1.Gift, len(gifts)), Total: total}
+	for i := range gifts {
+		resp.Gifts[i] = giftMessage(&gifts[i])
+	}
+	return resp, nil
+}
+
+// CreateGift creates a gift of the authenticated artist
+func (s *giftServer) CreateGift(ctx context.Context, req *tokentidev1.CreateGiftRequest) (*tokentidev1.Gift, error) {
+	gift := domain.Gift{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		PriceMinor:  req.GetPriceMinor(),
+		Currency:    req.GetCurrency(),
+		Quantity:    req.Quantity,
+		PerFanLimit: req.PerFanLimit,
+		ArtistID:    caller(ctx).ArtistID,
+	}
+	for _, id := range req.GetCategoryIds() {
+		gift.Categories = append(gift.Categories, domain.Category{ID: id})
+	}
+	for _, id := range req.GetTagIds() {
+		gift.Tags = append(gift.Tags, domain.Tag{ID: id})
+	}
+
+	created, err := s.service.CreateGift(ctx, gift)
+	if err != nil {
+		return nil, err
+	}
+	return giftMessage(created), nil
+}
+
+func giftMessage(gift *domain.Gift) *tokentidev1.Gift {
+	return &tokentidev1.Gift{
+		Id:          gift.ID,
+		Name:        gift.Name,
+		Description: gift.Description,
+		PriceMinor:  gift.PriceMinor,
+		Currency:    gift.Currency,
+		ArtistId:    gift.ArtistID,
+		ImageUrl:    gift.ImageURL,
+		Quantity:    gift.Quantity,
+		Sold:        gift.Sold,
+		PerFanLimit: gift.PerFanLimit,
+		Version:     int32(gift.Version),
+		CreatedAt:   timestamppb.New(gift.CreatedAt),
+	}
+}
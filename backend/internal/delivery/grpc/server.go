@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+	tokentidev1 "tokentide/proto/tokentide/v1"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	requestIDKey = "x-request-id"
+	// maxRequestIDLen bounds caller-supplied IDs so they cannot bloat the logs
+	maxRequestIDLen = 128
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Services are the application services exposed over gRPC, the same
+// instances the HTTP API uses
+type Services struct {
+	Artists domain.ArtistService
+	Gifts   domain.GiftService
+	Wallets domain.WalletService
+}
+
+// NewServer returns a gRPC server for the gift, wallet and artist services.
+// Calls authenticate as on the HTTP API, with an access token or API key in
+// the authorization or x-api-key metadata.
+func NewServer(logger *slog.Logger, tokens domain.TokenIssuer, keys domain.APIKeyService, services Services) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		observe(logger),
+		authenticate(tokens, keys),
+	))
+	tokentidev1.RegisterArtistServiceServer(server, &artistServer{service: services.Artists})
+	tokentidev1.RegisterGiftServiceServer(server, &giftServer{service: services.Gifts})
+	tokentidev1.RegisterWalletServiceServer(server, &walletServer{service: services.Wallets})
+	return server
+}
+
+// observe is the gRPC counterpart of the RequestID and Tracing middleware: it
+// continues the caller's trace in a server span, tags the call with a request
+// ID and a logger carrying it, turns the error into a status and logs the call
+func observe(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	propagator := otel.GetTextMapPropagator()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		carrier := propagation.MapCarrier{}
+		for key, values := range md {
+			if len(values) > 0 {
+				carrier[key] = values[0]
+			}
+		}
+		ctx, span := tracing.Tracer().Start(propagator.Extract(ctx, carrier), info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("rpc.method", info.FullMethod)),
+		)
+		defer span.End()
+
+		id := firstValue(md, requestIDKey)
+		if id == "" || len(id) > maxRequestIDLen {
+			id = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+		reqLogger := logger.With(slog.String("request_id", id))
+		if traceID, ok := tracing.TraceID(ctx); ok {
+			reqLogger = reqLogger.With(slog.String("trace_id", traceID))
+		}
+		ctx = logging.WithLogger(ctx, reqLogger)
+
+		resp, err := handler(ctx, req)
+		err = toStatus(ctx, err)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		if serverFault(code) {
+			level = slog.LevelError
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+		attrs := []slog.Attr{
+			slog.String("method", info.FullMethod),
+			slog.String("code", code.String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			attrs = append(attrs, slog.String("ip", p.Addr.String()))
+		}
+		reqLogger.LogAttrs(ctx, level, "rpc", attrs...)
+		return resp, err
+	}
+}
+
+// serverFault reports whether a status code means the server, not the caller, failed
+func serverFault(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		return true
+	}
+	return false
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// page returns the page size and offset of a request, with the HTTP API's default and bounds
+func page(limit, offset int32) (int, int) {
+	if limit < 1 || limit > maxPageSize {
+		limit = defaultPageSize
+	}
+	return int(limit), max(int(offset), 0)
+}
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+	tokentidev1 "tokentide/proto/tokentide/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type walletServer struct {
+	tokentidev1.UnimplementedWalletServiceServer
+	service domain.WalletService
+}
+
+// GetMyWallet returns the authenticated account's wallet, creating it on first use
+func (s *walletServer) GetMyWallet(ctx context.Context, _ *tokentidev1.GetMyWalletRequest) (*tokentidev1.Wallet, error) {
+	wallet, err := s.service.GetWalletForOwner(ctx, caller(ctx).ArtistID)
+	if err != nil {
+		return nil, err
+	}
+	return walletMessage(wallet), nil
+}
+
+// GetWallet returns a wallet owned by the authenticated account, or any wallet to an admin
+func (s *walletServer) GetWallet(ctx context.Context, req *tokentidev1.GetWalletRequest) (*tokentidev1.Wallet, error) {
+	wallet, err := s.owned(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return walletMessage(wallet), nil
+}
+
+// ListTransactions returns a page of the ledger of a wallet owned by the authenticated account
+func (s *walletServer) ListTransactions(ctx context.Context, req *tokentidev1.ListTransactionsRequest) (*tokentidev1.ListTransactionsResponse, error) {
+	wallet, err := s.owned(ctx, req.GetWalletId())
+	if err != nil {
+		return nil, err
+	}
+	limit, offset := page(req.GetLimit(), req.GetOffset())
+
+	transactions, total, err := s.service.ListTransactions(ctx, wallet.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &tokentidev1.ListTransactionsResponse{Transactions: make([]*tokentidev1.Transaction, len(transactions)), Total: total}
+	for i, t := range transactions {
+		resp.Transactions[i] = &tokentidev1.Transaction{
+			Id:                   t.ID,
+			WalletId:             t.WalletID,
+			Type:                 t.Type,
+			Amount:               t.Amount,
+			BalanceAfter:         t.BalanceAfter,
+			CounterpartyWalletId: t.CounterpartyWalletID,
+			GiftId:               t.GiftID,
+			PurchaseId:           t.PurchaseID,
+			RefundId:             t.RefundID,
+			PayoutId:             t.PayoutID,
+			CreatedAt:            timestamppb.New(t.CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
+// SendGift pays for a gift from the authenticated account's wallet
+func (s *walletServer) SendGift(ctx context.Context, req *tokentidev1.SendGiftRequest) (*tokentidev1.Wallet, error) {
+	wallet, err := s.service.SendGift(ctx, caller(ctx).ArtistID, req.GetGiftId())
+	if err != nil {
+		return nil, err
+	}
+	return walletMessage(wallet), nil
+}
+
+// owned returns the wallet if the authenticated account may see it
+func (s *walletServer) owned(ctx context.Context, id string) (*domain.Wallet, error) {
+	wallet, err := s.service.GetWallet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwnerOrAdmin(ctx, wallet.OwnerID) {
+		return nil, domain.ErrWalletAccessDenied
+	}
+	return wallet, nil
+}
+
+func walletMessage(wallet *domain.Wallet) *tokentidev1.Wallet {
+	return &tokentidev1.Wallet{
+		Id:        wallet.ID,
+		OwnerId:   wallet.OwnerID,
+		Balance:   wallet.Balance,
+		CreatedAt: timestamppb.New(wallet.CreatedAt),
+		UpdatedAt: timestamppb.New(wallet.UpdatedAt),
+	}
+}
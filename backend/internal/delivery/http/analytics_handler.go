@@ -0,0 +1,73 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHandler struct {
+	service domain.AnalyticsService
+}
+
+func NewAnalyticsHandler(service domain.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+const defaultStatsDays = 30
+
+type leaderboardQuery struct {
+	Period string `query:"period" validate:"omitempty,oneof=day week month"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+type statsQuery struct {
+	Days int `query:"days" validate:"omitempty,gte=1,lte=365"`
+}
+
+// GetLeaderboard returns an artist's top gifters over the period, a week by default
+func (h *AnalyticsHandler) GetLeaderboard(c *fiber.Ctx) error {
+	var query leaderboardQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Period == "" {
+		query.Period = domain.PeriodWeek
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	gifters, err := h.service.GetLeaderboard(c.UserContext(), c.Params("id"), query.Period, query.Limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"period": query.Period,
+		"items":  gifters,
+	})
+}
+
+// GetStats returns an artist's gift counts and token revenue per day; only
+// the artist and admins may see them
+func (h *AnalyticsHandler) GetStats(c *fiber.Ctx) error {
+	var query statsQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Days == 0 {
+		query.Days = defaultStatsDays
+	}
+	if !middleware.IsOwnerOrAdmin(c, c.Params("id")) {
+		return domain.ErrStatsAccessDenied
+	}
+
+	stats, err := h.service.GetArtistStats(c.UserContext(), c.Params("id"), query.Days)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(stats)
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"time"
+
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyHandler struct {
+	service domain.APIKeyService
+}
+
+func NewAPIKeyHandler(service domain.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+type apiKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+	// ExpiresAt is optional; keys without it last until revoked
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKey issues an API key for the authenticated account. The response
+// is the only one to include the key.
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req apiKeyRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	key, err := h.service.CreateAPIKey(c.UserContext(), middleware.CurrentArtistID(c), req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// ListAPIKeys returns the authenticated account's API keys, revoked ones included
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.ListAPIKeys(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"items": keys})
+}
+
+// RevokeAPIKey stops an API key from authenticating; only its owner or an admin may
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	key, err := h.service.GetAPIKey(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, key.ArtistID) {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	key, err = h.service.RevokeAPIKey(c.UserContext(), key.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(key)
+}
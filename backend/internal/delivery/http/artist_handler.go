@@ -0,0 +1,134 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ArtistHandler struct {
+	service domain.ArtistService
+}
+
+func NewArtistHandler(service domain.ArtistService) *ArtistHandler {
+	return &ArtistHandler{service: service}
+}
+
+type signupRequest struct {
+	Name  string `json:"name" validate:"required,max=100"`
+	Email string `json:"email" validate:"required,email"`
+	// bcrypt ignores anything past 72 bytes
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	// Role defaults to artist; admins are appointed, never self-registered
+	Role string `json:"role" validate:"omitempty,oneof=fan artist"`
+}
+
+type roleRequest struct {
+	Role string `json:"role" validate:"required,oneof=fan artist admin"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Signup registers a fan or artist account and signs it in, returning an
+// access token and a refresh token
+func (h *ArtistHandler) Signup(c *fiber.Ctx) error {
+	var req signupRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	artist, tokens, err := h.service.Register(c.UserContext(), req.Name, req.Email, req.Password, domain.Role(req.Role), device(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(signedIn(artist, tokens))
+}
+
+// Login exchanges an artist's credentials for an access token and a refresh
+// token, opening a session on the device
+func (h *ArtistHandler) Login(c *fiber.Ctx) error {
+	var req loginRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	artist, tokens, err := h.service.Login(c.UserContext(), req.Email, req.Password, device(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(signedIn(artist, tokens))
+}
+
+func signedIn(artist *domain.Artist, tokens *domain.TokenPair) fiber.Map {
+	return fiber.Map{
+		"artist":        artist,
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+	}
+}
+
+// GetArtist returns an artist profile
+func (h *ArtistHandler) GetArtist(c *fiber.Ctx) error {
+	artist, err := h.service.GetArtistByID(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	// Email is private to the artist
+	artist.Email = ""
+	return c.JSON(artist)
+}
+
+// ListArtists returns a page of every account, for admins
+func (h *ArtistHandler) ListArtists(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	artists, total, err := h.service.ListArtists(c.UserContext(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  artists,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// SetRole changes an account's role, for admins
+func (h *ArtistHandler) SetRole(c *fiber.Ctx) error {
+	var req roleRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	artist, err := h.service.SetRole(c.UserContext(), c.Params("id"), domain.Role(req.Role))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(artist)
+}
+
+// DeleteArtist soft deletes an account, for admins
+func (h *ArtistHandler) DeleteArtist(c *fiber.Ctx) error {
+	if err := h.service.DeleteArtist(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
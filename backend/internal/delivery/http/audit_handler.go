@@ -0,0 +1,52 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	service domain.AuditService
+}
+
+func NewAuditHandler(service domain.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+type listAuditQuery struct {
+	EntityType string `query:"entity_type" validate:"omitempty,oneof=gift artist"`
+	EntityID   string `query:"entity_id" validate:"omitempty,max=64"`
+	ActorID    string `query:"actor_id" validate:"omitempty,uuid"`
+	Limit      int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset     int    `query:"offset" validate:"gte=0"`
+}
+
+// ListEntries returns a page of the audit log, newest first, for admins
+func (h *AuditHandler) ListEntries(c *fiber.Ctx) error {
+	var query listAuditQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	entries, total, err := h.service.ListEntries(c.UserContext(), domain.AuditFilter{
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		ActorID:    query.ActorID,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  entries,
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CatalogHandler struct {
+	service domain.CatalogService
+}
+
+func NewCatalogHandler(service domain.CatalogService) *CatalogHandler {
+	return &CatalogHandler{service: service}
+}
+
+// catalogRequest creates or replaces a category or tag
+type catalogRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+	Slug string `json:"slug" validate:"omitempty,slug,max=50"`
+}
+
+// CreateCategory creates a category, deriving its slug from the name when none is given
+func (h *CatalogHandler) CreateCategory(c *fiber.Ctx) error {
+	var req catalogRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateCategory(c.UserContext(), domain.Category{Name: req.Name, Slug: req.Slug})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// ListCategories returns every category, by name
+func (h *CatalogHandler) ListCategories(c *fiber.Ctx) error {
+	categories, err := h.service.ListCategories(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"items": categories})
+}
+
+// UpdateCategory replaces a category's name and slug
+func (h *CatalogHandler) UpdateCategory(c *fiber.Ctx) error {
+	var req catalogRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	updated, err := h.service.UpdateCategory(c.UserContext(), c.Params("id"), domain.Category{Name: req.Name, Slug: req.Slug})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// DeleteCategory deletes a category, removing it from its gifts
+func (h *CatalogHandler) DeleteCategory(c *fiber.Ctx) error {
+	if err := h.service.DeleteCategory(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CreateTag creates a tag, deriving its slug from the name when none is given
+func (h *CatalogHandler) CreateTag(c *fiber.Ctx) error {
+	var req catalogRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateTag(c.UserContext(), domain.Tag{Name: req.Name, Slug: req.Slug})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// ListTags returns every tag, by name
+func (h *CatalogHandler) ListTags(c *fiber.Ctx) error {
+	tags, err := h.service.ListTags(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"items": tags})
+}
+
+// UpdateTag replaces a tag's name and slug
+func (h *CatalogHandler) UpdateTag(c *fiber.Ctx) error {
+	var req catalogRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	updated, err := h.service.UpdateTag(c.UserContext(), c.Params("id"), domain.Tag{Name: req.Name, Slug: req.Slug})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// DeleteTag deletes a tag, removing it from its gifts
+func (h *CatalogHandler) DeleteTag(c *fiber.Ctx) error {
+	if err := h.service.DeleteTag(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"fmt"
+
+	"tokentide/internal/chaos"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChaosHandler struct {
+	injector *chaos.Injector
+}
+
+func NewChaosHandler(injector *chaos.Injector) *ChaosHandler {
+	return &ChaosHandler{injector: injector}
+}
+
+type chaosRulesRequest struct {
+	Rules []chaos.Rule `json:"rules"`
+}
+
+// GetRules lists the active fault injection rules
+func (h *ChaosHandler) GetRules(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"rules": h.injector.Rules(),
+	})
+}
+
+// SetRules replaces the active fault injection rules
+func (h *ChaosHandler) SetRules(c *fiber.Ctx) error {
+	var req chaosRulesRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.injector.SetRules(req.Rules); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	return h.GetRules(c)
+}
+
+// ClearRules removes every fault injection rule
+func (h *ChaosHandler) ClearRules(c *fiber.Ctx) error {
+	h.injector.Clear()
+	return c.SendStatus(fiber.StatusNoContent)
+}
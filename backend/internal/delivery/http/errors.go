@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// errInvalidBody is returned when a request body cannot be parsed
+var errInvalidBody = domain.NewError(domain.ErrValidation, "invalid_body", "invalid request body")
+
+// errInvalidQuery is returned when query parameters cannot be parsed
+var errInvalidQuery = domain.NewError(domain.ErrValidation, "invalid_query", "invalid query parameters")
+
+// errorKinds maps each domain error kind to its status and default code
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{domain.ErrNotFound, fiber.StatusNotFound, "not_found"},
+	{domain.ErrValidation, fiber.StatusBadRequest, "validation_failed"},
+	{domain.ErrConflict, fiber.StatusConflict, "conflict"},
+	{domain.ErrInsufficientFunds, fiber.StatusPaymentRequired, "insufficient_funds"},
+	{domain.ErrUnauthorized, fiber.StatusUnauthorized, "unauthorized"},
+	{domain.ErrForbidden, fiber.StatusForbidden, "forbidden"},
+	{domain.ErrGone, fiber.StatusGone, "gone"},
+	{domain.ErrUnavailable, fiber.StatusServiceUnavailable, "unavailable"},
+}
+
+// ErrorBody is the error envelope of every failed response
+type ErrorBody struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail carries a stable, machine-readable code and a human-readable
+// message, plus the rejected fields when request validation failed
+type ErrorDetail struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// ErrorHandler renders errors returned by handlers and middleware. Domain
+// errors are mapped by kind, Fiber errors keep their status, and anything
+// else is logged and reported as an opaque internal error.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	detail := ErrorDetail{Code: "internal_error", Message: "internal server error"}
+
+	var fiberErr *fiber.Error
+	var domainErr *domain.Error
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		status = fiber.StatusUnprocessableEntity
+		detail = ErrorDetail{Code: "invalid_request", Message: err.Error(), Fields: validationErr.Fields}
+	} else if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+		detail = ErrorDetail{Code: statusCode(fiberErr.Code), Message: fiberErr.Message}
+	} else {
+		for _, k := range errorKinds {
+			if errors.Is(err, k.kind) {
+				status = k.status
+				detail = ErrorDetail{Code: k.code, Message: err.Error()}
+				break
+			}
+		}
+		if errors.As(err, &domainErr) {
+			detail.Code = domainErr.Code
+		}
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		logging.FromContext(c.UserContext()).Error("Request failed", "error", err)
+	}
+
+	return c.Status(status).JSON(ErrorBody{Error: detail})
+}
+
+// statusCode turns an HTTP status into an error code, e.g. 405 into "method_not_allowed"
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(utils.StatusMessage(status)), " ", "_")
+}
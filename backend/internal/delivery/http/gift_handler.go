@@ -0,0 +1,291 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+	"tokentide/internal/storage"
+	"tokentide/pkg/money"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+)
+
+type GiftHandler struct {
+	service    domain.GiftService
+	currencies domain.CurrencyService
+}
+
+func NewGiftHandler(service domain.GiftService, currencies domain.CurrencyService) *GiftHandler {
+	return &GiftHandler{service: service, currencies: currencies}
+}
+
+// defaultLocale formats display prices when the client sends no Accept-Language
+const defaultLocale = "en-US"
+
+type displayQuery struct {
+	// DisplayCurrency asks for prices converted to a currency, e.g. EUR
+	DisplayCurrency string `query:"display_currency" validate:"omitempty,len=3,alpha"`
+}
+
+type listGiftsQuery struct {
+	displayQuery
+	ArtistID   string `query:"artist_id" validate:"omitempty,uuid"`
+	CategoryID string `query:"category_id" validate:"omitempty,uuid"`
+	TagID      string `query:"tag_id" validate:"omitempty,uuid"`
+	Currency   string `query:"currency" validate:"omitempty,len=3,alpha"`
+	MinPrice   *int64 `query:"min_price" validate:"omitempty,gte=0"`
+	MaxPrice   *int64 `query:"max_price" validate:"omitempty,gte=0"`
+	Sort       string `query:"sort" validate:"omitempty,oneof=price -price created_at -created_at"`
+	Limit      int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset     int    `query:"offset" validate:"gte=0"`
+}
+
+type giftRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	// PriceMinor is in minor units of Currency, e.g. 499 USD for $4.99
+	PriceMinor int64  `json:"price_minor" validate:"gt=0"`
+	Currency   string `json:"currency" validate:"required,len=3,alpha"`
+	// Quantity and PerFanLimit cap the sends of the gift in all and per fan;
+	// omitted, they are unlimited
+	Quantity    *int64 `json:"quantity" validate:"omitempty,gte=1"`
+	PerFanLimit *int64 `json:"per_fan_limit" validate:"omitempty,gte=1"`
+	// CategoryIDs and TagIDs replace the gift's links; omitted, they are left unchanged
+	CategoryIDs []string `json:"category_ids" validate:"omitempty,max=10,dive,uuid"`
+	TagIDs      []string `json:"tag_ids" validate:"omitempty,max=20,dive,uuid"`
+}
+
+type updateGiftRequest struct {
+	giftRequest
+	// Version is the version of the gift the update was made from
+	Version int `json:"version" validate:"required,gte=1"`
+}
+
+// links returns the categories and tags of the request, nil for those omitted
+func (r giftRequest) links() ([]domain.Category, []domain.Tag) {
+	var categories []domain.Category
+	if r.CategoryIDs != nil {
+		categories = make([]domain.Category, len(r.CategoryIDs))
+		for i, id := range r.CategoryIDs {
+			categories[i] = domain.Category{ID: id}
+		}
+	}
+	var tags []domain.Tag
+	if r.TagIDs != nil {
+		tags = make([]domain.Tag, len(r.TagIDs))
+		for i, id := range r.TagIDs {
+			tags[i] = domain.Tag{ID: id}
+		}
+	}
+	return categories, tags
+}
+
+// CreateGift creates a gift owned by the authenticated artist
+func (h *GiftHandler) CreateGift(c *fiber.Ctx) error {
+	var req giftRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	categories, tags := req.links()
+	created, err := h.service.CreateGift(c.UserContext(), domain.Gift{
+		Name:        req.Name,
+		Description: req.Description,
+		PriceMinor:  req.PriceMinor,
+		Currency:    req.Currency,
+		Quantity:    req.Quantity,
+		PerFanLimit: req.PerFanLimit,
+		ArtistID:    middleware.CurrentArtistID(c),
+		Categories:  categories,
+		Tags:        tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// GetGift returns a single gift, with its price in the display currency when one is asked for
+func (h *GiftHandler) GetGift(c *fiber.Ctx) error {
+	var query displayQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	gift, err := h.service.GetGiftByID(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.displayPrice(c, gift, query.DisplayCurrency, preferredLocale(c)); err != nil {
+		return err
+	}
+
+	return c.JSON(gift)
+}
+
+// ListGifts returns a page of gifts, optionally filtered by artist, category,
+// tag, currency and price range, with their prices in the display currency
+// when one is asked for
+func (h *GiftHandler) ListGifts(c *fiber.Ctx) error {
+	var query listGiftsQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	gifts, total, err := h.service.ListGifts(c.UserContext(), domain.GiftFilter{
+		ArtistID:   query.ArtistID,
+		CategoryID: query.CategoryID,
+		TagID:      query.TagID,
+		Currency:   query.Currency,
+		MinPrice:   query.MinPrice,
+		MaxPrice:   query.MaxPrice,
+		Sort:       query.Sort,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+	locale := preferredLocale(c)
+	for i := range gifts {
+		if err := h.displayPrice(c, &gifts[i], query.DisplayCurrency, locale); err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  gifts,
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
+
+// UpdateGift replaces a gift's name, description, price, stock limits and,
+// when given, its categories and tags; only its artist or an admin may. It
+// fails with 409 when the gift changed since the version the request names.
+func (h *GiftHandler) UpdateGift(c *fiber.Ctx) error {
+	var req updateGiftRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+	if err := h.authorize(c, c.Params("id")); err != nil {
+		return err
+	}
+
+	categories, tags := req.links()
+	updated, err := h.service.UpdateGift(c.UserContext(), c.Params("id"), domain.Gift{
+		Name:        req.Name,
+		Description: req.Description,
+		PriceMinor:  req.PriceMinor,
+		Currency:    req.Currency,
+		Quantity:    req.Quantity,
+		PerFanLimit: req.PerFanLimit,
+		Version:     req.Version,
+		Categories:  categories,
+		Tags:        tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(updated)
+}
+
+// UploadImage sets a gift's artwork from the "image" field of a multipart
+// form; only its artist or an admin may
+func (h *GiftHandler) UploadImage(c *fiber.Ctx) error {
+	if err := h.authorize(c, c.Params("id")); err != nil {
+		return err
+	}
+
+	header, err := c.FormFile("image")
+	if err != nil {
+		return fmt.Errorf("%w: the image field of a multipart form is required", domain.ErrInvalidImage)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// Read one byte past the limit so oversized images are rejected rather than truncated
+	upload, err := io.ReadAll(io.LimitReader(file, storage.MaxImageBytes+1))
+	if err != nil {
+		return err
+	}
+
+	gift, err := h.service.SetGiftImage(c.UserContext(), c.Params("id"), upload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(gift)
+}
+
+// ListPriceHistory returns a gift's prices, oldest first, to tell what a gift
+// cost when it was sent
+func (h *GiftHandler) ListPriceHistory(c *fiber.Ctx) error {
+	prices, err := h.service.ListPriceHistory(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"items": prices})
+}
+
+// DeleteGift deletes a gift; only its artist or an admin may
+func (h *GiftHandler) DeleteGift(c *fiber.Ctx) error {
+	if err := h.authorize(c, c.Params("id")); err != nil {
+		return err
+	}
+	if err := h.service.DeleteGift(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// displayPrice sets the gift's price converted to currency, formatted for
+// locale; it does nothing when no currency is asked for
+func (h *GiftHandler) displayPrice(c *fiber.Ctx, gift *domain.Gift, currency, locale string) error {
+	if currency == "" {
+		return nil
+	}
+	converted, err := h.currencies.Convert(c.UserContext(), gift.PriceMinor, gift.Currency, currency)
+	if err != nil {
+		return err
+	}
+	amount, err := money.NewAmount(converted, currency, locale)
+	if err != nil {
+		return err
+	}
+	gift.DisplayPrice = &amount
+	return nil
+}
+
+// preferredLocale returns the client's preferred Accept-Language tag
+func preferredLocale(c *fiber.Ctx) string {
+	tags, _, err := language.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+	if err != nil || len(tags) == 0 {
+		return defaultLocale
+	}
+	return tags[0].String()
+}
+
+// authorize checks that the authenticated account may manage the gift
+func (h *GiftHandler) authorize(c *fiber.Ctx, id string) error {
+	gift, err := h.service.GetGiftByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, gift.ArtistID) {
+		return domain.ErrGiftAccessDenied
+	}
+	return nil
+}
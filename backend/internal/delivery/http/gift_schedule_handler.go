@@ -0,0 +1,111 @@
+package http
+
+import (
+	"time"
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GiftScheduleHandler struct {
+	service domain.GiftScheduleService
+}
+
+func NewGiftScheduleHandler(service domain.GiftScheduleService) *GiftScheduleHandler {
+	return &GiftScheduleHandler{service: service}
+}
+
+type giftScheduleRequest struct {
+	GiftID string    `json:"gift_id" validate:"required,uuid"`
+	SendAt time.Time `json:"send_at" validate:"required"`
+	// Recurrence defaults to once
+	Recurrence string `json:"recurrence" validate:"omitempty,oneof=once daily weekly monthly"`
+}
+
+type listGiftSchedulesQuery struct {
+	Limit  int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int `query:"offset" validate:"gte=0"`
+}
+
+// CreateSchedule schedules a gift from the authenticated account
+func (h *GiftScheduleHandler) CreateSchedule(c *fiber.Ctx) error {
+	var req giftScheduleRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+	if req.Recurrence == "" {
+		req.Recurrence = domain.RecurrenceOnce
+	}
+
+	schedule, err := h.service.CreateSchedule(c.UserContext(), domain.GiftSchedule{
+		SenderID:   middleware.CurrentArtistID(c),
+		GiftID:     req.GiftID,
+		Recurrence: req.Recurrence,
+		NextRunAt:  req.SendAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(schedule)
+}
+
+// ListSchedules returns a page of the authenticated account's schedules, newest first
+func (h *GiftScheduleHandler) ListSchedules(c *fiber.Ctx) error {
+	var query listGiftSchedulesQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	schedules, total, err := h.service.ListSchedules(c.UserContext(), middleware.CurrentArtistID(c), query.Limit, query.Offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  schedules,
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
+
+// GetSchedule returns a schedule of the authenticated account
+func (h *GiftScheduleHandler) GetSchedule(c *fiber.Ctx) error {
+	schedule, err := h.owned(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(schedule)
+}
+
+// CancelSchedule stops a schedule of the authenticated account from sending more gifts
+func (h *GiftScheduleHandler) CancelSchedule(c *fiber.Ctx) error {
+	if _, err := h.owned(c); err != nil {
+		return err
+	}
+	schedule, err := h.service.CancelSchedule(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(schedule)
+}
+
+// owned returns the schedule in the path if the authenticated account may
+// manage it; other accounts' schedules are reported as not found
+func (h *GiftScheduleHandler) owned(c *fiber.Ctx) (*domain.GiftSchedule, error) {
+	schedule, err := h.service.GetSchedule(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+	if !middleware.IsOwnerOrAdmin(c, schedule.SenderID) {
+		return nil, domain.ErrGiftScheduleNotFound
+	}
+	return schedule, nil
+}
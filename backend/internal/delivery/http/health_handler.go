@@ -0,0 +1,60 @@
+package http
+
+import (
+	"tokentide/internal/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	prober *health.Prober
+}
+
+func NewHealthHandler(prober *health.Prober) *HealthHandler {
+	return &HealthHandler{prober: prober}
+}
+
+// Dependencies probes every external dependency and reports their status
+func (h *HealthHandler) Dependencies(c *fiber.Ctx) error {
+	dependencies := h.prober.Probe(c.UserContext())
+
+	status := "ok"
+	for _, dep := range dependencies {
+		if !dep.Healthy {
+			status = "degraded"
+			break
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"status":       status,
+		"dependencies": dependencies,
+	})
+}
+
+// Live reports that the process is up and serving; it never touches a
+// dependency, so a database outage does not get healthy instances restarted
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "ok"})
+}
+
+// Ready probes every dependency and answers 503 when any of them is down, so
+// the instance is taken out of rotation until it recovers
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	dependencies := h.prober.Probe(c.UserContext())
+
+	status := "ok"
+	code := fiber.StatusOK
+	for _, dep := range dependencies {
+		if !dep.Healthy {
+			status = "unavailable"
+			code = fiber.StatusServiceUnavailable
+			break
+		}
+	}
+
+	return c.Status(code).JSON(fiber.Map{
+		"status":       status,
+		"dependencies": dependencies,
+	})
+}
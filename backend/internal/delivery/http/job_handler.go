@@ -0,0 +1,46 @@
+package http
+
+import (
+	"strconv"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct {
+	queue domain.JobQueue
+}
+
+func NewJobHandler(queue domain.JobQueue) *JobHandler {
+	return &JobHandler{queue: queue}
+}
+
+// ListDeadJobs returns the most recent jobs that ran out of attempts
+func (h *JobHandler) ListDeadJobs(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	jobs, err := h.queue.ListDeadJobs(c.UserContext(), limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"items": jobs, "limit": limit})
+}
+
+// RetryJob runs a job again right away, typically a dead one once its cause is fixed
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return domain.ErrJobNotFound
+	}
+
+	job, err := h.queue.RetryJob(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(job)
+}
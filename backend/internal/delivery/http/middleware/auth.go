@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	artistIDKey  = "artist_id"
+	roleKey      = "role"
+	sessionIDKey = "session_id"
+)
+
+// apiKeyHeader carries an API key; keys are also accepted as bearer tokens
+const apiKeyHeader = "X-API-Key"
+
+var errMissingToken = domain.NewError(domain.ErrUnauthorized, "missing_token", "missing bearer token")
+
+// Authenticate requires a valid bearer access token and stores the account it
+// identifies. API keys are refused; routes open to them use AuthenticateScoped.
+func Authenticate(tokens domain.TokenIssuer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if apiKey(c) != "" {
+			return domain.ErrAPIKeyNotAllowed
+		}
+		token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || token == "" {
+			return errMissingToken
+		}
+
+		identity, err := tokens.Verify(token)
+		if err != nil {
+			return err
+		}
+
+		setIdentity(c, identity)
+		return c.Next()
+	}
+}
+
+// AuthenticateScoped accepts either a bearer access token, like Authenticate,
+// or an API key, in the X-API-Key header or as the bearer token, that was
+// granted scope
+func AuthenticateScoped(tokens domain.TokenIssuer, keys domain.APIKeyService, scope string) fiber.Handler {
+	authenticate := Authenticate(tokens)
+	return func(c *fiber.Ctx) error {
+		key := apiKey(c)
+		if key == "" {
+			return authenticate(c)
+		}
+
+		identity, err := keys.VerifyAPIKey(c.UserContext(), key)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(identity.Scopes, scope) {
+			return domain.ErrInsufficientScope
+		}
+
+		setIdentity(c, identity)
+		return c.Next()
+	}
+}
+
+// apiKey returns the API key the request carries, if any
+func apiKey(c *fiber.Ctx) string {
+	if key := c.Get(apiKeyHeader); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "); ok && strings.HasPrefix(token, domain.APIKeyPrefix) {
+		return token
+	}
+	return ""
+}
+
+func setIdentity(c *fiber.Ctx, identity domain.Identity) {
+	c.Locals(artistIDKey, identity.ArtistID)
+	c.Locals(roleKey, identity.Role)
+	c.Locals(sessionIDKey, identity.SessionID)
+	c.SetUserContext(domain.WithIdentity(c.UserContext(), identity))
+}
+
+// RequireRole lets through only accounts holding one of roles; it must run after Authenticate
+func RequireRole(roles ...domain.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if slices.Contains(roles, CurrentRole(c)) {
+			return c.Next()
+		}
+		return domain.ErrPermissionDenied
+	}
+}
+
+// CurrentArtistID returns the artist authenticated by Authenticate, or "" on public routes
+func CurrentArtistID(c *fiber.Ctx) string {
+	artistID, _ := c.Locals(artistIDKey).(string)
+	return artistID
+}
+
+// CurrentRole returns the role of the account authenticated by Authenticate, or "" on public routes
+func CurrentRole(c *fiber.Ctx) domain.Role {
+	role, _ := c.Locals(roleKey).(domain.Role)
+	return role
+}
+
+// CurrentSessionID returns the session the request's access token was issued
+// to, or "" for API keys and tokens issued before sessions existed
+func CurrentSessionID(c *fiber.Ctx) string {
+	sessionID, _ := c.Locals(sessionIDKey).(string)
+	return sessionID
+}
+
+// IsOwnerOrAdmin reports whether the authenticated account owns a resource
+// owned by ownerID or is an admin, who may manage everything
+func IsOwnerOrAdmin(c *fiber.Ctx, ownerID string) bool {
+	return ownerID == CurrentArtistID(c) || CurrentRole(c) == domain.RoleAdmin
+}
+
+// TokenFromQuery lets clients that cannot set headers, such as browser
+// WebSockets, pass their access token or API key in the named query
+// parameter. It must run before Authenticate.
+func TokenFromQuery(param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token := c.Query(param); token != "" && c.Get(fiber.HeaderAuthorization) == "" {
+			c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"tokentide/internal/chaos"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FaultInjection delays, fails or drops requests according to the injector's
+// rules, except those under the exempt path prefixes
+func FaultInjection(injector *chaos.Injector, exempt ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.Path(), prefix) {
+				return c.Next()
+			}
+		}
+
+		fault, ok := injector.Evaluate(c.Path())
+		if !ok {
+			return c.Next()
+		}
+
+		// Nothing cancels the user context, but fasthttp closes the request
+		// context when the server shuts down, so draining does not wait out
+		// the injected latency
+		if err := fault.Delay(c.Context()); err != nil {
+			return err
+		}
+
+		if fault.Drop {
+			// Close the connection without writing any response
+			c.Context().HijackSetNoResponse(true)
+			c.Context().Hijack(func(net.Conn) {})
+			return nil
+		}
+
+		if fault.ErrorStatus != 0 {
+			return fiber.NewError(fault.ErrorStatus, "injected fault")
+		}
+
+		return c.Next()
+	}
+}
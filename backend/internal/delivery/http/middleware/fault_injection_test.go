@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"tokentide/internal/chaos"
+	"tokentide/internal/delivery/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestFaultInjectionLatencyEndsOnShutdown(t *testing.T) {
+	injector := chaos.NewInjector()
+	if err := injector.SetRules([]chaos.Rule{{Route: "/gifts", LatencyMS: 60_000}}); err != nil {
+		t.Fatalf("SetRules: %v", err)
+	}
+	app := fiber.New()
+	app.Use(middleware.FaultInjection(injector))
+	app.Get("/gifts", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go app.Listener(ln)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if resp, err := http.Get("http://" + ln.Addr().String() + "/gifts"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	// Let the request reach the injected latency before shutting down
+	time.Sleep(200 * time.Millisecond)
+	start := time.Now()
+	if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("shutdown took %s, want the injected latency cut short", elapsed)
+	}
+	<-done
+}
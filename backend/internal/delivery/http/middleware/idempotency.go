@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+	// idempotencyKeyExpiry is how long a key's first response is replayed
+	idempotencyKeyExpiry = 24 * time.Hour
+)
+
+var (
+	errIdempotencyKeyTooLong    = domain.NewError(domain.ErrValidation, "idempotency_key_too_long", "Idempotency-Key is too long")
+	errIdempotencyKeyInProgress = domain.NewError(domain.ErrConflict, "idempotency_key_in_progress", "a request with this Idempotency-Key is still in progress")
+)
+
+// Idempotency replays the stored response when a request is retried with the
+// same Idempotency-Key header. Keys are scoped to the authenticated account and
+// route, so it must run after Authenticate. Requests without the header pass through.
+func Idempotency(repo domain.IdempotencyRepository) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		key := c.Get(idempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return errIdempotencyKeyTooLong
+		}
+
+		hash := sha256.Sum256(append([]byte(c.Method()+" "+c.OriginalURL()+"\n"), c.Body()...))
+		record := domain.IdempotencyRecord{
+			Key:         CurrentArtistID(c) + ":" + c.Method() + ":" + c.Route().Path + ":" + key,
+			RequestHash: hex.EncodeToString(hash[:]),
+			CreatedAt:   time.Now(),
+		}
+
+		existing, reserved, err := repo.Reserve(c.UserContext(), record, idempotencyKeyExpiry)
+		if err != nil {
+			return err
+		}
+
+		if !reserved {
+			switch {
+			case existing.RequestHash != record.RequestHash:
+				return fiber.NewError(fiber.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case !existing.Completed:
+				return errIdempotencyKeyInProgress
+			}
+
+			c.Set("Idempotent-Replayed", "true")
+			c.Set(fiber.HeaderContentType, existing.ContentType)
+			return c.Status(existing.StatusCode).Send(existing.Body)
+		}
+
+		// The key is released when the handler fails or panics so that the
+		// client can retry. Once it has answered, its changes may be committed,
+		// so the key stays reserved even if the response cannot be stored and
+		// retries are refused as in progress rather than run again.
+		answered := false
+		defer func() {
+			if answered {
+				return
+			}
+			if releaseErr := repo.Release(context.WithoutCancel(c.UserContext()), record.Key); err == nil {
+				err = releaseErr
+			}
+		}()
+
+		// Render errors here so the stored response is the one the client sees
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		// Server errors are not stored so the client can retry them
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			return nil
+		}
+
+		answered = true
+		return repo.Complete(c.UserContext(), record.Key, status,
+			string(c.Response().Header.ContentType()), c.Response().Body())
+	}
+}
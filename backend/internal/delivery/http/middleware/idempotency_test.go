@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// failingComplete stores reservations but cannot store responses
+type failingComplete struct {
+	domain.IdempotencyRepository
+}
+
+func (failingComplete) Complete(context.Context, string, int, string, []byte) error {
+	return errors.New("connection reset by peer")
+}
+
+func TestIdempotencyKeepsKeyWhenResponseIsNotStored(t *testing.T) {
+	repo := failingComplete{memory.NewIdempotencyRepository(memory.NewStore())}
+	calls := 0
+	app := fiber.New()
+	app.Post("/gifts/:id/send", middleware.Idempotency(repo), func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"sent": true})
+	})
+
+	send := func() int {
+		req := httptest.NewRequest(fiber.MethodPost, "/gifts/rose/send", strings.NewReader(`{}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set("Idempotency-Key", "send-1")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	send()
+	if status := send(); status == fiber.StatusCreated {
+		t.Errorf("retry status = %d, want it refused", status)
+	}
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+}
+
+func TestIdempotencyReleasesKeyWhenHandlerFails(t *testing.T) {
+	repo := memory.NewIdempotencyRepository(memory.NewStore())
+	calls := 0
+	app := fiber.New()
+	app.Post("/gifts/:id/send", middleware.Idempotency(repo), func(c *fiber.Ctx) error {
+		calls++
+		if calls == 1 {
+			return fiber.ErrServiceUnavailable
+		}
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"sent": true})
+	})
+
+	for range 2 {
+		req := httptest.NewRequest(fiber.MethodPost, "/gifts/rose/send", strings.NewReader(`{}`))
+		req.Header.Set("Idempotency-Key", "send-1")
+		if _, err := app.Test(req); err != nil {
+			t.Fatalf("request: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want the failed request retried", calls)
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errAccessDenied = domain.NewError(domain.ErrForbidden, "access_denied", "access denied")
+
+// IPAllowlist rejects requests whose client IP is not inside one of the given CIDR ranges
+func IPAllowlist(cidrs []string) (fiber.Handler, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *fiber.Ctx) error {
+		ip := net.ParseIP(c.IP())
+		if ip != nil {
+			for _, network := range networks {
+				if network.Contains(ip) {
+					return c.Next()
+				}
+			}
+		}
+
+		return errAccessDenied
+	}, nil
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+	"tokentide/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Metrics records the count and latency of every request by route template,
+// so /gifts/:id is one series however many gifts there are. It must run
+// before the middleware that renders errors so it sees the final status.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// Fiber reuses the request buffer, so copy the method before it outlives the request
+		method := utils.CopyString(c.Method())
+		route := c.Route().Path
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Response().StatusCode())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
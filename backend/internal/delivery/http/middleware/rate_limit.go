@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"tokentide/internal/metrics"
+	"tokentide/internal/ratelimit"
+	"tokentide/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit takes a token from the caller's bucket for the named limit,
+// answering 429 with Retry-After once it is empty. Authenticated callers are
+// limited per account and anonymous ones per IP, so it must run after
+// Authenticate on protected routes. When the limiter itself fails the
+// request is let through rather than locking everyone out.
+func RateLimit(limiter ratelimit.Limiter, name string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		subject := "ip:" + c.IP()
+		if artistID := CurrentArtistID(c); artistID != "" {
+			subject = "artist:" + artistID
+		}
+
+		result, err := limiter.Allow(c.UserContext(), name+":"+subject)
+		if err != nil {
+			logging.FromContext(c.UserContext()).Warn("Rate limiter unavailable, letting request through", "limit", name, "error", err)
+			return c.Next()
+		}
+		if !result.Allowed {
+			metrics.RateLimited.WithLabelValues(name).Inc()
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			return fiber.NewError(fiber.StatusTooManyRequests, "rate limit exceeded, retry later")
+		}
+
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		return c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLen bounds caller-supplied IDs so they cannot bloat the logs
+	maxRequestIDLen = 128
+)
+
+// RequestID propagates the caller's X-Request-ID, or generates one, echoes it
+// in the response and puts a logger tagged with it in the request context.
+// Each request is logged once it completes.
+func RequestID(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		id := c.Get(requestIDHeader)
+		if id == "" || len(id) > maxRequestIDLen {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDHeader, id)
+
+		reqLogger := logger.With(slog.String("request_id", id))
+		if traceID, ok := tracing.TraceID(c.UserContext()); ok {
+			reqLogger = reqLogger.With(slog.String("trace_id", traceID))
+		}
+		c.SetUserContext(logging.WithLogger(c.UserContext(), reqLogger))
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// Let the error handler write the response so the logged status matches it
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+			status = c.Response().StatusCode()
+		}
+
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		reqLogger.LogAttrs(c.UserContext(), level, "request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("ip", c.IP()),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
+)
+
+// corsHeaders are the request headers browsers may send cross-origin, and
+// corsExposedHeaders the response headers their scripts may read
+var (
+	corsHeaders        = []string{fiber.HeaderAuthorization, fiber.HeaderContentType, apiKeyHeader, idempotencyKeyHeader, requestIDHeader}
+	corsExposedHeaders = []string{requestIDHeader, fiber.HeaderRetryAfter, "X-RateLimit-Remaining", "Idempotent-Replayed"}
+)
+
+// CORS answers preflights and tags the responses to the origins allowed to
+// call the API from a browser
+func CORS(origins, methods []string, credentials bool, maxAge time.Duration) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(origins, ","),
+		AllowMethods:     strings.Join(methods, ","),
+		AllowHeaders:     strings.Join(corsHeaders, ","),
+		ExposeHeaders:    strings.Join(corsExposedHeaders, ","),
+		AllowCredentials: credentials,
+		MaxAge:           int(maxAge.Seconds()),
+	})
+}
+
+// SecurityHeaders sets the headers that keep browsers from sniffing, framing
+// or running the API's responses. Images under /media are still embeddable
+// by the frontend on another origin, and HSTS is only sent over HTTPS.
+func SecurityHeaders() fiber.Handler {
+	return helmet.New(helmet.Config{
+		ContentSecurityPolicy:     "default-src 'none'; frame-ancestors 'none'",
+		XFrameOptions:             "DENY",
+		CrossOriginResourcePolicy: "cross-origin",
+		HSTSMaxAge:                int((365 * 24 * time.Hour).Seconds()),
+	})
+}
+
+// BodyLimit rejects request bodies over limit bytes with 413, and those of
+// the upload routes over uploadLimit. Uploads are given as a method and a
+// path pattern, such as "PUT /api/v1/gifts/*/image", where * matches one
+// path segment.
+func BodyLimit(limit, uploadLimit int, uploads ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		bound := limit
+		if isUpload(c, uploads) {
+			bound = uploadLimit
+		}
+		// The raw body, as Ctx.Body would decompress it first
+		if len(c.Request().Body()) > bound {
+			return fiber.ErrRequestEntityTooLarge
+		}
+		return c.Next()
+	}
+}
+
+// isUpload reports whether the request is for one of the upload routes
+func isUpload(c *fiber.Ctx, uploads []string) bool {
+	route := c.Method() + " " + strings.TrimSuffix(c.Path(), "/")
+	for _, upload := range uploads {
+		if ok, _ := path.Match(upload, route); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"tokentide/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxInFlightShadows bounds concurrent mirrored requests; extra samples are skipped
+const maxInFlightShadows = 64
+
+type shadowRequest struct {
+	method string
+	uri    string
+	header http.Header
+	status int
+	body   []byte
+}
+
+// Shadow mirrors the given percentage of read requests to targetURL after the
+// primary response is produced, discarding the mirrored response and logging
+// any status or body difference. Mirrors in flight are tracked in inFlight so
+// shutdown can wait for them.
+func Shadow(targetURL string, percent float64, inFlight *sync.WaitGroup) fiber.Handler {
+	client := &http.Client{Timeout: 5 * time.Second}
+	slots := make(chan struct{}, maxInFlightShadows)
+	targetURL = strings.TrimRight(targetURL, "/")
+
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+			return c.Next()
+		}
+		if rand.Float64()*100 >= percent {
+			return c.Next()
+		}
+
+		err := c.Next()
+
+		// The fiber context is reused once the handler returns, so copy what the mirror needs
+		req := shadowRequest{
+			method: c.Method(),
+			uri:    targetURL + string(c.Request().RequestURI()),
+			header: http.Header{},
+			status: c.Response().StatusCode(),
+			body:   append([]byte{}, c.Response().Body()...),
+		}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			req.header.Add(string(key), string(value))
+		})
+
+		select {
+		case slots <- struct{}{}:
+			inFlight.Add(1)
+			logger := logging.FromContext(c.UserContext())
+			go func() {
+				defer inFlight.Done()
+				defer func() { <-slots }()
+				mirror(client, logger, req)
+			}()
+		default:
+		}
+
+		return err
+	}
+}
+
+func mirror(client *http.Client, logger *slog.Logger, req shadowRequest) {
+	httpReq, err := http.NewRequest(req.method, req.uri, nil)
+	if err != nil {
+		logger.Error("shadow: could not build request", "uri", req.uri, "error", err)
+		return
+	}
+	httpReq.Header = req.header
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		logger.Warn("shadow: request failed", "method", req.method, "uri", req.uri, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Warn("shadow: could not read body", "method", req.method, "uri", req.uri, "error", err)
+		return
+	}
+
+	if resp.StatusCode != req.status {
+		logger.Warn("shadow: status mismatch", "method", req.method, "uri", req.uri, "primary", req.status, "shadow", resp.StatusCode)
+		return
+	}
+	if !bytes.Equal(body, req.body) {
+		logger.Warn("shadow: body mismatch", "method", req.method, "uri", req.uri, "primary_bytes", len(req.body), "shadow_bytes", len(body))
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"tokentide/internal/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing opens a server span for every request, continuing the trace from
+// an incoming traceparent header, and puts it in the request context so
+// service and query spans nest under it. Like Metrics it must run before
+// the middleware that renders errors so it sees the final status.
+func Tracing() fiber.Handler {
+	propagator := otel.GetTextMapPropagator()
+	return func(c *fiber.Ctx) error {
+		carrier := propagation.MapCarrier{}
+		for key, value := range c.GetReqHeaders() {
+			if len(value) > 0 {
+				carrier[key] = value[0]
+			}
+		}
+		ctx := propagator.Extract(c.UserContext(), carrier)
+
+		method := utils.CopyString(c.Method())
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", method),
+				attribute.String("url.path", utils.CopyString(c.Path())),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// The route is only known once the router has matched it
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+		span.SetName(method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", status),
+		)
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, utils.StatusMessage(status))
+		}
+		return err
+	}
+}
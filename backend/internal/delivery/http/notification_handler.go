@@ -0,0 +1,87 @@
+package http
+
+import (
+	"time"
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/metrics"
+	"tokentide/internal/realtime"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// socketArtistIDKey carries the authenticated artist over the upgrade
+	socketArtistIDKey = "socket_artist_id"
+	writeTimeout      = 10 * time.Second
+	// pongTimeout drops clients that stop answering pings
+	pongTimeout  = 60 * time.Second
+	pingInterval = pongTimeout * 9 / 10
+)
+
+type NotificationHandler struct {
+	hub *realtime.Hub
+}
+
+func NewNotificationHandler(hub *realtime.Hub) *NotificationHandler {
+	return &NotificationHandler{hub: hub}
+}
+
+// Upgrade accepts WebSocket handshakes from authenticated accounts
+func (h *NotificationHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	c.Locals(socketArtistIDKey, middleware.CurrentArtistID(c))
+	return c.Next()
+}
+
+// Stream pushes the events of the authenticated artist's channel, such as
+// gifts they receive, until the client disconnects. Clients only listen;
+// anything they send is discarded.
+func (h *NotificationHandler) Stream(conn *websocket.Conn) {
+	artistID, _ := conn.Locals(socketArtistIDKey).(string)
+	messages, unsubscribe := h.hub.Subscribe(artistID)
+	defer unsubscribe()
+
+	metrics.RealtimeConnections.Inc()
+	defer metrics.RealtimeConnections.Dec()
+
+	_ = conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case payload, ok := <-messages:
+			if !ok {
+				closing := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+				_ = conn.WriteControl(websocket.CloseMessage, closing, time.Now().Add(writeTimeout))
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case <-disconnected:
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationPreferencesHandler struct {
+	service domain.NotificationService
+}
+
+func NewNotificationPreferencesHandler(service domain.NotificationService) *NotificationPreferencesHandler {
+	return &NotificationPreferencesHandler{service: service}
+}
+
+type preferencesRequest struct {
+	GiftReceived    *bool `json:"gift_received" validate:"required"`
+	PurchaseReceipt *bool `json:"purchase_receipt" validate:"required"`
+	PayoutSent      *bool `json:"payout_sent" validate:"required"`
+}
+
+// GetPreferences returns the emails the authenticated account receives
+func (h *NotificationPreferencesHandler) GetPreferences(c *fiber.Ctx) error {
+	preferences, err := h.service.GetPreferences(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(preferences)
+}
+
+// UpdatePreferences replaces the emails the authenticated account receives
+func (h *NotificationPreferencesHandler) UpdatePreferences(c *fiber.Ctx) error {
+	var req preferencesRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	preferences, err := h.service.UpdatePreferences(c.UserContext(), domain.NotificationPreferences{
+		ArtistID:        middleware.CurrentArtistID(c),
+		GiftReceived:    *req.GiftReceived,
+		PurchaseReceipt: *req.PurchaseReceipt,
+		PayoutSent:      *req.PayoutSent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(preferences)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaymentHandler struct {
+	service domain.PaymentService
+}
+
+func NewPaymentHandler(service domain.PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: service}
+}
+
+type tokenPackageRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Tokens      int64  `json:"tokens" validate:"gt=0"`
+	AmountMinor int64  `json:"amount_minor" validate:"gt=0"`
+	Currency    string `json:"currency" validate:"required,len=3"`
+}
+
+type createPurchaseRequest struct {
+	PackageID string `json:"package_id" validate:"required,uuid"`
+}
+
+// ListTokenPackages returns the token packages on sale
+func (h *PaymentHandler) ListTokenPackages(c *fiber.Ctx) error {
+	packages, err := h.service.ListTokenPackages(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items": packages,
+	})
+}
+
+// CreateTokenPackage puts a new token package on sale
+func (h *PaymentHandler) CreateTokenPackage(c *fiber.Ctx) error {
+	var req tokenPackageRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateTokenPackage(c.UserContext(), domain.TokenPackage{
+		Name:        req.Name,
+		Tokens:      req.Tokens,
+		AmountMinor: req.AmountMinor,
+		Currency:    req.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// CreatePurchase starts buying a token package and returns the checkout URL
+func (h *PaymentHandler) CreatePurchase(c *fiber.Ctx) error {
+	var req createPurchaseRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	purchase, checkoutURL, err := h.service.CreatePurchase(c.UserContext(), middleware.CurrentArtistID(c), req.PackageID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"purchase":     purchase,
+		"checkout_url": checkoutURL,
+	})
+}
+
+// GetPurchase returns a purchase made by the authenticated account
+func (h *PaymentHandler) GetPurchase(c *fiber.Ctx) error {
+	purchase, err := h.service.GetPurchase(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, purchase.BuyerID) {
+		return domain.ErrPurchaseNotFound
+	}
+
+	return c.JSON(purchase)
+}
+
+// StripeWebhook receives payment notifications from Stripe
+func (h *PaymentHandler) StripeWebhook(c *fiber.Ctx) error {
+	if err := h.service.HandleWebhook(c.UserContext(), c.Body(), c.Get("Stripe-Signature")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PayoutHandler struct {
+	service domain.PayoutService
+}
+
+func NewPayoutHandler(service domain.PayoutService) *PayoutHandler {
+	return &PayoutHandler{service: service}
+}
+
+type payoutRequest struct {
+	Tokens int64 `json:"tokens" validate:"required,gte=1"`
+}
+
+type rejectPayoutRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type listPayoutsQuery struct {
+	Status string `query:"status" validate:"omitempty,oneof=requested processing paid failed rejected"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+type listAllPayoutsQuery struct {
+	listPayoutsQuery
+	ArtistID string `query:"artist_id" validate:"omitempty,uuid"`
+}
+
+// SetUpAccount opens the authenticated artist's payout account, returning a
+// link to complete it at the provider until it is ready
+func (h *PayoutHandler) SetUpAccount(c *fiber.Ctx) error {
+	account, err := h.service.SetUpAccount(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
+
+// GetAccount returns the authenticated artist's payout account
+func (h *PayoutHandler) GetAccount(c *fiber.Ctx) error {
+	account, err := h.service.GetAccount(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
+
+// DeleteAccount forgets the authenticated artist's payout account
+func (h *PayoutHandler) DeleteAccount(c *fiber.Ctx) error {
+	if err := h.service.DeleteAccount(c.UserContext(), middleware.CurrentArtistID(c)); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetBalance returns the authenticated artist's earnings and what they can withdraw
+func (h *PayoutHandler) GetBalance(c *fiber.Ctx) error {
+	balance, err := h.service.GetBalance(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(balance)
+}
+
+// RequestPayout asks to withdraw some of the authenticated artist's earnings
+func (h *PayoutHandler) RequestPayout(c *fiber.Ctx) error {
+	var req payoutRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	payout, err := h.service.RequestPayout(c.UserContext(), middleware.CurrentArtistID(c), req.Tokens)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(payout)
+}
+
+// ListMyPayouts returns a page of the authenticated artist's payouts, newest first
+func (h *PayoutHandler) ListMyPayouts(c *fiber.Ctx) error {
+	var query listPayoutsQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	return h.list(c, domain.PayoutFilter{
+		ArtistID: middleware.CurrentArtistID(c),
+		Status:   query.Status,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	})
+}
+
+// ListPayouts returns a page of every artist's payouts, newest first,
+// optionally filtered by artist and status
+func (h *PayoutHandler) ListPayouts(c *fiber.Ctx) error {
+	var query listAllPayoutsQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+
+	return h.list(c, domain.PayoutFilter{
+		ArtistID: query.ArtistID,
+		Status:   query.Status,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	})
+}
+
+func (h *PayoutHandler) list(c *fiber.Ctx, filter domain.PayoutFilter) error {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+
+	payouts, total, err := h.service.ListPayouts(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  payouts,
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+// GetPayout returns a payout of the authenticated artist; other artists'
+// payouts are reported as not found
+func (h *PayoutHandler) GetPayout(c *fiber.Ctx) error {
+	payout, err := h.service.GetPayout(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, payout.ArtistID) {
+		return domain.ErrPayoutNotFound
+	}
+
+	return c.JSON(payout)
+}
+
+// ApprovePayout sends a requested payout's money. A transfer the provider
+// rejected is returned with the failed status.
+func (h *PayoutHandler) ApprovePayout(c *fiber.Ctx) error {
+	payout, err := h.service.ApprovePayout(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(payout)
+}
+
+// RejectPayout turns down a requested payout, returning its tokens to the artist
+func (h *PayoutHandler) RejectPayout(c *fiber.Ctx) error {
+	var req rejectPayoutRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	payout, err := h.service.RejectPayout(c.UserContext(), c.Params("id"), req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(payout)
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RefundHandler struct {
+	service domain.RefundService
+}
+
+func NewRefundHandler(service domain.RefundService) *RefundHandler {
+	return &RefundHandler{service: service}
+}
+
+type refundRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type listRefundsQuery struct {
+	Kind   string `query:"kind" validate:"omitempty,oneof=purchase gift_send"`
+	Status string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+// RefundPurchase takes back a purchase's tokens and returns its payment. A
+// refund the provider rejected is returned with the failed status, and one
+// whose outcome is unknown stays pending until it is reconciled.
+func (h *RefundHandler) RefundPurchase(c *fiber.Ctx) error {
+	var req refundRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	refund, err := h.service.RefundPurchase(c.UserContext(), c.Params("id"), req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(refund)
+}
+
+// RefundGiftSend reverses the gift send recorded by a sender's debit entry
+func (h *RefundHandler) RefundGiftSend(c *fiber.Ctx) error {
+	var req refundRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	refund, err := h.service.RefundGiftSend(c.UserContext(), c.Params("id"), req.Reason)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(refund)
+}
+
+// GetRefund returns a single refund
+func (h *RefundHandler) GetRefund(c *fiber.Ctx) error {
+	refund, err := h.service.GetRefund(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(refund)
+}
+
+// ListRefunds returns a page of refunds, newest first, optionally filtered by kind and status
+func (h *RefundHandler) ListRefunds(c *fiber.Ctx) error {
+	var query listRefundsQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	refunds, total, err := h.service.ListRefunds(c.UserContext(), domain.RefundFilter{
+		Kind:   query.Kind,
+		Status: query.Status,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  refunds,
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
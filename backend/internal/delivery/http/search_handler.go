@@ -0,0 +1,50 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchHandler struct {
+	service domain.SearchService
+}
+
+func NewSearchHandler(service domain.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+type searchQuery struct {
+	Q      string `query:"q" validate:"required,max=200"`
+	Type   string `query:"type" validate:"omitempty,oneof=gift artist"`
+	Limit  int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	Offset int    `query:"offset" validate:"gte=0"`
+}
+
+// Search returns a page of the gifts and artists matching q, best matches first
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	var query searchQuery
+	if err := parseQuery(c, &query); err != nil {
+		return err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPageLimit
+	}
+
+	results, total, err := h.service.Search(c.UserContext(), domain.SearchQuery{
+		Text:   query.Q,
+		Type:   query.Type,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  results,
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
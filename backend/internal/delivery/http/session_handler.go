@@ -0,0 +1,88 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionHandler struct {
+	service domain.SessionService
+}
+
+func NewSessionHandler(service domain.SessionService) *SessionHandler {
+	return &SessionHandler{service: service}
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// Refresh exchanges a refresh token for a new access token and refresh token;
+// the old refresh token stops working
+func (h *SessionHandler) Refresh(c *fiber.Ctx) error {
+	var req refreshTokenRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	tokens, err := h.service.Refresh(c.UserContext(), req.RefreshToken, device(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(tokens)
+}
+
+// Logout ends the session of a refresh token
+func (h *SessionHandler) Logout(c *fiber.Ctx) error {
+	var req refreshTokenRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.service.Logout(c.UserContext(), req.RefreshToken); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListSessions returns the authenticated account's active sessions, marking
+// the one the request comes from
+func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
+	sessions, err := h.service.ListSessions(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == middleware.CurrentSessionID(c)
+	}
+
+	return c.JSON(fiber.Map{"items": sessions})
+}
+
+// RevokeSession ends a session of the authenticated account, such as that
+// of a lost or stolen device
+func (h *SessionHandler) RevokeSession(c *fiber.Ctx) error {
+	session, err := h.service.GetSession(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	// Other accounts' sessions are reported as not found
+	if !middleware.IsOwnerOrAdmin(c, session.ArtistID) {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := h.service.RevokeSession(c.UserContext(), session.ID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// device describes the client a request comes from
+func device(c *fiber.Ctx) domain.Device {
+	return domain.Device{UserAgent: c.Get(fiber.HeaderUserAgent), IP: c.IP()}
+}
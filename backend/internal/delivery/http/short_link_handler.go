@@ -0,0 +1,100 @@
+package http
+
+import (
+	"strings"
+	"time"
+
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ShortLinkHandler struct {
+	service domain.ShortLinkService
+}
+
+func NewShortLinkHandler(service domain.ShortLinkService) *ShortLinkHandler {
+	return &ShortLinkHandler{service: service}
+}
+
+type shortLinkRequest struct {
+	Code        string     `json:"code" validate:"omitempty,slug,max=64"`
+	TargetURL   string     `json:"target_url" validate:"required,http_url"`
+	UTMSource   string     `json:"utm_source" validate:"max=100"`
+	UTMMedium   string     `json:"utm_medium" validate:"max=100"`
+	UTMCampaign string     `json:"utm_campaign" validate:"max=100"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// CreateShortLink creates a short link owned by the authenticated account,
+// generating a code when none is given
+func (h *ShortLinkHandler) CreateShortLink(c *fiber.Ctx) error {
+	var req shortLinkRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateShortLink(c.UserContext(), domain.ShortLink{
+		ArtistID:    middleware.CurrentArtistID(c),
+		Code:        req.Code,
+		TargetURL:   req.TargetURL,
+		UTMSource:   req.UTMSource,
+		UTMMedium:   req.UTMMedium,
+		UTMCampaign: req.UTMCampaign,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Redirect records the click and redirects to the link target, passing UTM parameters through
+func (h *ShortLinkHandler) Redirect(c *fiber.Ctx) error {
+	utm := map[string]string{}
+	for key, value := range c.Queries() {
+		if strings.HasPrefix(key, "utm_") {
+			utm[key] = value
+		}
+	}
+
+	click := domain.ShortLinkClick{
+		Referrer: c.Get(fiber.HeaderReferer),
+		Country:  c.Get("CF-IPCountry"),
+	}
+
+	target, err := h.service.Resolve(c.UserContext(), c.Params("code"), click, utm)
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(target, fiber.StatusFound)
+}
+
+// GetStats returns click counts of a short link by referrer and country
+func (h *ShortLinkHandler) GetStats(c *fiber.Ctx) error {
+	if err := h.authorize(c, c.Params("code")); err != nil {
+		return err
+	}
+
+	stats, err := h.service.GetStats(c.UserContext(), c.Params("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(stats)
+}
+
+// authorize checks that the authenticated account may read the short link
+func (h *ShortLinkHandler) authorize(c *fiber.Ctx, code string) error {
+	link, err := h.service.GetShortLink(c.UserContext(), code)
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, link.ArtistID) {
+		return domain.ErrShortLinkAccessDenied
+	}
+	return nil
+}
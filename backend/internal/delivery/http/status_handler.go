@@ -0,0 +1,91 @@
+package http
+
+import (
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatusHandler struct {
+	service domain.StatusService
+}
+
+func NewStatusHandler(service domain.StatusService) *StatusHandler {
+	return &StatusHandler{service: service}
+}
+
+type openIncidentRequest struct {
+	Component string `json:"component" validate:"required,max=100"`
+	Title     string `json:"title" validate:"required,max=200"`
+	Impact    string `json:"impact" validate:"required,oneof=degraded partial_outage major_outage"`
+	Message   string `json:"message" validate:"max=2000"`
+}
+
+type updateIncidentRequest struct {
+	Status  string `json:"status" validate:"required,oneof=investigating identified monitoring"`
+	Impact  string `json:"impact" validate:"omitempty,oneof=degraded partial_outage major_outage"`
+	Message string `json:"message" validate:"max=2000"`
+}
+
+type resolveIncidentRequest struct {
+	Message string `json:"message" validate:"max=2000"`
+}
+
+// GetStatus returns the public status page: component states, uptime and incidents
+func (h *StatusHandler) GetStatus(c *fiber.Ctx) error {
+	status, err := h.service.GetStatus(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(status)
+}
+
+// OpenIncident opens an incident against a component
+func (h *StatusHandler) OpenIncident(c *fiber.Ctx) error {
+	var req openIncidentRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	incident, err := h.service.OpenIncident(c.UserContext(), domain.Incident{
+		Component: req.Component,
+		Title:     req.Title,
+		Impact:    req.Impact,
+	}, req.Message)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(incident)
+}
+
+// UpdateIncident moves an incident to a new status and adds a timeline entry
+func (h *StatusHandler) UpdateIncident(c *fiber.Ctx) error {
+	var req updateIncidentRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	incident, err := h.service.UpdateIncident(c.UserContext(), c.Params("id"), req.Status, req.Impact, req.Message)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(incident)
+}
+
+// ResolveIncident resolves an incident
+func (h *StatusHandler) ResolveIncident(c *fiber.Ctx) error {
+	var req resolveIncidentRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	incident, err := h.service.ResolveIncident(c.UserContext(), c.Params("id"), req.Message)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(incident)
+}
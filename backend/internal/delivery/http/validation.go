@@ -0,0 +1,131 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+var validate = newValidator()
+
+// slugPattern matches URL-safe identifiers such as "summer-sale_2024"
+var slugPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by their JSON or query parameter name, as the client sent them
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		tag := field.Tag.Get("json")
+		if tag == "" {
+			tag = field.Tag.Get("query")
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	_ = v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	})
+	return v
+}
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request body fails validation; it is
+// rendered as 422 with one entry per rejected field
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return "request validation failed"
+}
+
+// parseBody decodes the request body into req and validates it against its
+// `validate` struct tags
+func parseBody(c *fiber.Ctx, req any) error {
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+	return validateStruct(req)
+}
+
+// parseQuery decodes the query string into req and validates it against its
+// `validate` struct tags
+func parseQuery(c *fiber.Ctx, req any) error {
+	if err := c.QueryParser(req); err != nil {
+		return errInvalidQuery
+	}
+	return validateStruct(req)
+}
+
+func validateStruct(req any) error {
+	err := validate.Struct(req)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	validationErr := &ValidationError{}
+	for _, fe := range fieldErrs {
+		validationErr.Fields = append(validationErr.Fields, FieldError{
+			Field:   fieldPath(fe),
+			Message: fieldMessage(fe),
+		})
+	}
+	return validationErr
+}
+
+// fieldPath returns the JSON path of a field without the request type, e.g. "rules[0].path"
+func fieldPath(fe validator.FieldError) string {
+	_, path, _ := strings.Cut(fe.Namespace(), ".")
+	return path
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "email":
+		return "must be a valid email address"
+	case "url", "http_url":
+		return "must be an absolute http(s) URL"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "slug":
+		return "may only contain letters, digits, '-' and '_'"
+	case "len":
+		return fmt.Sprintf("must be exactly %s characters", fe.Param())
+	default:
+		return "is invalid"
+	}
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WalletHandler struct {
+	service domain.WalletService
+}
+
+func NewWalletHandler(service domain.WalletService) *WalletHandler {
+	return &WalletHandler{service: service}
+}
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type amountRequest struct {
+	Amount int64 `json:"amount" validate:"gt=0"`
+}
+
+type sendGiftRequest struct {
+	GiftID string `json:"gift_id" validate:"required,uuid"`
+}
+
+// GetMyWallet returns the authenticated account's wallet, creating it on first use
+func (h *WalletHandler) GetMyWallet(c *fiber.Ctx) error {
+	wallet, err := h.service.GetWalletForOwner(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(wallet)
+}
+
+// GetWallet returns a wallet owned by the authenticated account, or any wallet to an admin
+func (h *WalletHandler) GetWallet(c *fiber.Ctx) error {
+	wallet, err := h.service.GetWallet(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, wallet.OwnerID) {
+		return domain.ErrWalletAccessDenied
+	}
+
+	return c.JSON(wallet)
+}
+
+// ListTransactions returns a page of the ledger of a wallet owned by the authenticated account
+func (h *WalletHandler) ListTransactions(c *fiber.Ctx) error {
+	wallet, err := h.service.GetWallet(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if !middleware.IsOwnerOrAdmin(c, wallet.OwnerID) {
+		return domain.ErrWalletAccessDenied
+	}
+
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	transactions, total, err := h.service.ListTransactions(c.UserContext(), wallet.ID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  transactions,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// SendGift pays for a gift from the authenticated account's wallet
+func (h *WalletHandler) SendGift(c *fiber.Ctx) error {
+	var req sendGiftRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	wallet, err := h.service.SendGift(c.UserContext(), middleware.CurrentArtistID(c), req.GiftID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(wallet)
+}
+
+// Credit adds tokens to a wallet
+func (h *WalletHandler) Credit(c *fiber.Ctx) error {
+	var req amountRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	wallet, err := h.service.Credit(c.UserContext(), c.Params("id"), req.Amount)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(wallet)
+}
+
+// Debit removes tokens from a wallet
+func (h *WalletHandler) Debit(c *fiber.Ctx) error {
+	var req amountRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	wallet, err := h.service.Debit(c.UserContext(), c.Params("id"), req.Amount)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(wallet)
+}
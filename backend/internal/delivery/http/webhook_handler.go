@@ -0,0 +1,102 @@
+package http
+
+import (
+	"tokentide/internal/delivery/http/middleware"
+	"tokentide/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	service domain.WebhookService
+}
+
+func NewWebhookHandler(service domain.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+type webhookRequest struct {
+	URL string `json:"url" validate:"required,url,max=2048"`
+}
+
+// CreateWebhook registers an endpoint for the authenticated artist. The
+// response is the only one to include the signing secret.
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req webhookRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	webhook, err := h.service.CreateWebhook(c.UserContext(), middleware.CurrentArtistID(c), req.URL)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(webhook)
+}
+
+// ListWebhooks returns the authenticated artist's webhooks
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.service.ListWebhooks(c.UserContext(), middleware.CurrentArtistID(c))
+	if err != nil {
+		return err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return c.JSON(fiber.Map{"items": webhooks})
+}
+
+// DeleteWebhook removes a webhook and its delivery log
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	if _, err := h.authorize(c); err != nil {
+		return err
+	}
+	if err := h.service.DeleteWebhook(c.UserContext(), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListDeliveries returns a page of a webhook's delivery log, newest first
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	webhook, err := h.authorize(c)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	deliveries, total, err := h.service.ListDeliveries(c.UserContext(), webhook.ID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"items":  deliveries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// authorize loads the webhook in the path if the authenticated account may manage it
+func (h *WebhookHandler) authorize(c *fiber.Ctx) (*domain.Webhook, error) {
+	webhook, err := h.service.GetWebhook(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+	if !middleware.IsOwnerOrAdmin(c, webhook.ArtistID) {
+		return nil, domain.ErrWebhookAccessDenied
+	}
+	return webhook, nil
+}
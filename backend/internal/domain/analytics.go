@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrInvalidPeriod     = NewError(ErrValidation, "invalid_period", "period must be day, week or month")
+	ErrStatsAccessDenied = NewError(ErrForbidden, "stats_access_denied", "statistics belong to another artist")
+)
+
+// Leaderboard periods, in whole UTC days ending today
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// GiftStats is the aggregate of the gifts one fan sent an artist on one UTC
+// day, net of refunds. The rows are recomputed from the ledger by a
+// background job, so they trail it by a few minutes.
+type GiftStats struct {
+	ArtistID string    `gorm:"primaryKey"`
+	SenderID string    `gorm:"primaryKey"`
+	Day      time.Time `gorm:"primaryKey;type:date"`
+	Gifts    int64     `gorm:"not null"`
+	Tokens   int64     `gorm:"not null"`
+}
+
+func (GiftStats) TableName() string {
+	return "artist_gift_stats"
+}
+
+// Gifter is a fan's entry in an artist's leaderboard
+type Gifter struct {
+	SenderID string `json:"sender_id"`
+	Name     string `json:"name"`
+	Gifts    int64  `json:"gifts"`
+	Tokens   int64  `json:"tokens"`
+}
+
+// DailyStats are an artist's gifts and token revenue on one UTC day
+type DailyStats struct {
+	Day    time.Time `json:"day"`
+	Gifts  int64     `json:"gifts"`
+	Tokens int64     `json:"tokens"`
+}
+
+// ArtistStats summarize the gifts an artist received over the last Days days
+type ArtistStats struct {
+	ArtistID string       `json:"artist_id"`
+	Days     int          `json:"days"`
+	Gifts    int64        `json:"gifts"`
+	Tokens   int64        `json:"tokens"`
+	Gifters  int64        `json:"gifters"`
+	Daily    []DailyStats `json:"daily"`
+}
+
+// AnalyticsRepository is the interface for the gift aggregates
+type AnalyticsRepository interface {
+	// RefreshStats recomputes the aggregates of the days from since on from
+	// the ledger; a zero since rebuilds them all
+	RefreshStats(ctx context.Context, since time.Time) error
+	// HasStats reports whether any aggregates were computed yet
+	HasStats(ctx context.Context) (bool, error)
+	// TopGifters returns up to limit fans who sent the artist the most tokens since the day given
+	TopGifters(ctx context.Context, artistID string, since time.Time, limit int) ([]Gifter, error)
+	// DailyStats returns the artist's days with gifts since the day given, oldest first
+	DailyStats(ctx context.Context, artistID string, since time.Time) ([]DailyStats, error)
+	// CountGifters counts the fans who sent the artist gifts since the day given
+	CountGifters(ctx context.Context, artistID string, since time.Time) (int64, error)
+}
+
+// AnalyticsService is the interface for artist leaderboards and statistics
+type AnalyticsService interface {
+	// GetLeaderboard returns the artist's top gifters over one of the periods
+	GetLeaderboard(ctx context.Context, artistID, period string, limit int) ([]Gifter, error)
+	// GetArtistStats returns the artist's totals and daily series over the last days days
+	GetArtistStats(ctx context.Context, artistID string, days int) (*ArtistStats, error)
+	// RefreshStats brings the aggregates up to date with the ledger
+	RefreshStats(ctx context.Context) error
+}
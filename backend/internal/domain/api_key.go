@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrAPIKeyNotFound     = NewError(ErrNotFound, "api_key_not_found", "API key not found")
+	ErrInvalidAPIKey      = NewError(ErrUnauthorized, "invalid_api_key", "invalid, expired or revoked API key")
+	ErrInvalidAPIKeyScope = NewError(ErrValidation, "invalid_api_key_scope", "unknown API key scope")
+	ErrAPIKeyExpiry       = NewError(ErrValidation, "invalid_api_key_expiry", "API key expiry must be in the future")
+	// ErrInsufficientScope is returned when an API key is used on a route its scopes do not cover
+	ErrInsufficientScope = NewError(ErrForbidden, "insufficient_scope", "the API key's scopes do not allow this")
+	// ErrAPIKeyNotAllowed is returned when an API key is used on a route that needs a signed-in account
+	ErrAPIKeyNotAllowed = NewError(ErrForbidden, "api_key_not_allowed", "this route cannot be used with an API key")
+)
+
+// APIKeyPrefix starts every API key, telling them apart from access tokens
+const APIKeyPrefix = "tt_"
+
+// Scopes an API key can be granted. Routes outside of them, such as purchases,
+// payouts, account settings and the admin API, need a signed-in account.
+const (
+	// ScopeGiftsWrite publishes, edits and deletes the owner's gifts
+	ScopeGiftsWrite = "gifts:write"
+	// ScopeGiftsSend sends and schedules gifts from the owner's wallet
+	ScopeGiftsSend = "gifts:send"
+	// ScopeWalletRead reads wallets and their transactions
+	ScopeWalletRead = "wallet:read"
+	// ScopeStatsRead reads the owner's gift statistics
+	ScopeStatsRead = "stats:read"
+	// ScopeNotificationsRead streams live notifications, e.g. for stream overlays
+	ScopeNotificationsRead = "notifications:read"
+	// ScopeWebhooksManage registers and removes the owner's webhooks
+	ScopeWebhooksManage = "webhooks:manage"
+)
+
+// APIKeyScopes are every known scope
+var APIKeyScopes = []string{ScopeGiftsWrite, ScopeGiftsSend, ScopeWalletRead, ScopeStatsRead, ScopeNotificationsRead, ScopeWebhooksManage}
+
+// APIKey lets a server integration, such as an overlay or a bot, act for the
+// account that created it within its scopes. Only a hash of the key is
+// stored; the key itself is returned once, when it is created.
+type APIKey struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	ArtistID string `json:"artist_id" gorm:"index;not null"`
+	Name     string `json:"name" gorm:"not null"`
+	// Prefix is the start of the key, to recognize it by
+	Prefix     string     `json:"prefix" gorm:"not null"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex;not null"`
+	Scopes     []string   `json:"scopes" gorm:"type:jsonb;serializer:json;not null"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	// Key is only set on the key just created
+	Key string `json:"key,omitempty" gorm:"-"`
+}
+
+// Active reports whether the key can still be used at now
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIKeyRepository is the interface for API key persistence
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*APIKey, error)
+	// ListAPIKeys returns the account's keys, revoked ones included, newest first
+	ListAPIKeys(ctx context.Context, artistID string) ([]APIKey, error)
+	// RevokeAPIKey marks a key revoked at the time given, unless it already was
+	RevokeAPIKey(ctx context.Context, id string, at time.Time) error
+	// TouchAPIKey records when a key was last used
+	TouchAPIKey(ctx context.Context, id string, at time.Time) error
+}
+
+// APIKeyService is the interface for managing and verifying API keys
+type APIKeyService interface {
+	// CreateAPIKey issues a key for the account; the returned key carries the
+	// secret, which cannot be retrieved again
+	CreateAPIKey(ctx context.Context, artistID, name string, scopes []string, expiresAt *time.Time) (*APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, artistID string) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*APIKey, error)
+	// VerifyAPIKey returns the identity of the account an active key belongs
+	// to, with the account's current role and the key's scopes
+	VerifyAPIKey(ctx context.Context, key string) (Identity, error)
+}
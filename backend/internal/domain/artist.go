@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrArtistNotFound     = NewError(ErrNotFound, "artist_not_found", "artist not found")
+	ErrArtistExists       = NewError(ErrConflict, "artist_exists", "an artist with this email already exists")
+	ErrInvalidArtist      = NewError(ErrValidation, "invalid_artist", "invalid artist")
+	ErrInvalidCredentials = NewError(ErrUnauthorized, "invalid_credentials", "invalid email or password")
+	ErrInvalidToken       = NewError(ErrUnauthorized, "invalid_token", "invalid or expired token")
+	ErrPermissionDenied   = NewError(ErrForbidden, "permission_denied", "your role does not allow this")
+)
+
+// Role decides what an account may do
+type Role string
+
+const (
+	// RoleFan can buy tokens and send gifts
+	RoleFan Role = "fan"
+	// RoleArtist can also publish gifts and manage their own
+	RoleArtist Role = "artist"
+	// RoleAdmin can manage every gift and account and reach the /admin routes
+	RoleAdmin Role = "admin"
+)
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	return r == RoleFan || r == RoleArtist || r == RoleAdmin
+}
+
+type Artist struct {
+	ID           string         `json:"id" gorm:"primaryKey"`
+	Name         string         `json:"name" gorm:"not null"`
+	Email        string         `json:"email,omitempty" gorm:"uniqueIndex;not null"`
+	PasswordHash string         `json:"-" gorm:"not null"`
+	Role         Role           `json:"role" gorm:"not null;default:artist"`
+	CreatedAt    time.Time      `json:"created_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// Identity is the authenticated account behind a request
+type Identity struct {
+	ArtistID string
+	Role     Role
+	// SessionID is the session an access token was issued to
+	SessionID string
+	// APIKeyID and Scopes are set when the request authenticated with an API
+	// key, which may only do what its scopes allow
+	APIKeyID string
+	Scopes   []string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the authenticated account
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the account stored by WithIdentity, if any
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// TokenIssuer issues and verifies access tokens identifying an account
+type TokenIssuer interface {
+	// Issue returns an access token for the account, issued to a session
+	Issue(artistID string, role Role, sessionID string) (string, error)
+	// Verify returns the identity carried by a valid token
+	Verify(token string) (Identity, error)
+}
+
+// ArtistRepository is the interface for artist persistence
+type ArtistRepository interface {
+	CreateArtist(ctx context.Context, artist Artist) error
+	GetArtistByID(ctx context.Context, id string) (*Artist, error)
+	GetArtistByEmail(ctx context.Context, email string) (*Artist, error)
+	ListArtists(ctx context.Context, limit, offset int) ([]Artist, int64, error)
+	UpdateRole(ctx context.Context, id string, role Role) error
+	// DeleteArtist soft deletes the account, which can no longer log in
+	DeleteArtist(ctx context.Context, id string) error
+}
+
+// ArtistService is the interface for artist accounts and authentication
+type ArtistService interface {
+	// Register creates a fan or artist account, signed in on the device;
+	// admins are only appointed by SetRole
+	Register(ctx context.Context, name, email, password string, role Role, device Device) (*Artist, *TokenPair, error)
+	// CreateAdmin creates an admin account without signing it in, to appoint
+	// the first admin from the command line
+	CreateAdmin(ctx context.Context, name, email, password string) (*Artist, error)
+	// Login signs an account in on the device
+	Login(ctx context.Context, email, password string, device Device) (*Artist, *TokenPair, error)
+	GetArtistByID(ctx context.Context, id string) (*Artist, error)
+	ListArtists(ctx context.Context, limit, offset int) ([]Artist, int64, error)
+	SetRole(ctx context.Context, id string, role Role) (*Artist, error)
+	DeleteArtist(ctx context.Context, id string) error
+}
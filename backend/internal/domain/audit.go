@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Audited actions
+const (
+	AuditCreated = "created"
+	AuditUpdated = "updated"
+	AuditDeleted = "deleted"
+)
+
+// Audited entity types
+const (
+	AuditGift   = "gift"
+	AuditArtist = "artist"
+)
+
+// AuditChange is the value of a field before and after a change; Old is nil
+// for created entities and New for deleted ones
+type AuditChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// AuditEntry records who changed which fields of an entity, and when
+type AuditEntry struct {
+	ID string `json:"id" gorm:"primaryKey"`
+	// ActorID is the account that made the change, empty when no account did, such as from the CLI
+	ActorID    string                 `json:"actor_id"`
+	Action     string                 `json:"action" gorm:"not null"`
+	EntityType string                 `json:"entity_type" gorm:"not null"`
+	EntityID   string                 `json:"entity_id" gorm:"not null"`
+	Changes    map[string]AuditChange `json:"changes" gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt  time.Time              `json:"created_at" gorm:"not null"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+// AuditFilter selects a page of audit entries, newest first
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	Limit      int
+	Offset     int
+}
+
+// AuditRepository is the interface for audit log persistence
+type AuditRepository interface {
+	RecordEntry(ctx context.Context, entry AuditEntry) error
+	ListEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, int64, error)
+}
+
+// AuditService is the interface for browsing the audit log
+type AuditService interface {
+	ListEntries(ctx context.Context, filter AuditFilter) ([]AuditEntry, int64, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrCategoryNotFound = NewError(ErrNotFound, "category_not_found", "category not found")
+	ErrCategoryExists   = NewError(ErrConflict, "category_exists", "category slug already in use")
+	ErrTagNotFound      = NewError(ErrNotFound, "tag_not_found", "tag not found")
+	ErrTagExists        = NewError(ErrConflict, "tag_exists", "tag slug already in use")
+	ErrInvalidSlug      = NewError(ErrValidation, "invalid_slug", "slug must contain a letter or digit")
+)
+
+// Category groups gifts for browsing, such as "Flowers"
+type Category struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tag labels gifts across categories, such as "valentines"
+type Tag struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CatalogRepository is the interface for category and tag persistence
+type CatalogRepository interface {
+	CreateCategory(ctx context.Context, category Category) error
+	GetCategory(ctx context.Context, id string) (*Category, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+	UpdateCategory(ctx context.Context, category Category) error
+	DeleteCategory(ctx context.Context, id string) error
+	CreateTag(ctx context.Context, tag Tag) error
+	GetTag(ctx context.Context, id string) (*Tag, error)
+	ListTags(ctx context.Context) ([]Tag, error)
+	UpdateTag(ctx context.Context, tag Tag) error
+	DeleteTag(ctx context.Context, id string) error
+}
+
+// CatalogService is the interface for managing categories and tags. The slug
+// is derived from the name unless one is given.
+type CatalogService interface {
+	CreateCategory(ctx context.Context, category Category) (*Category, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+	UpdateCategory(ctx context.Context, id string, category Category) (*Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+	CreateTag(ctx context.Context, tag Tag) (*Tag, error)
+	ListTags(ctx context.Context) ([]Tag, error)
+	UpdateTag(ctx context.Context, id string, tag Tag) (*Tag, error)
+	DeleteTag(ctx context.Context, id string) error
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrUnsupportedCurrency = NewError(ErrValidation, "unsupported_currency", "currency is not supported")
+	// ErrExchangeRatesUnavailable is returned when an amount must be converted
+	// but no rates provider is configured or it cannot be reached
+	ErrExchangeRatesUnavailable = NewError(ErrUnavailable, "exchange_rates_unavailable", "exchange rates are unavailable")
+)
+
+// ExchangeRates quote currencies against a base currency. Rates are decimal
+// strings, units of the currency per unit of Base, so they never pass through a float.
+type ExchangeRates struct {
+	Base      string
+	Rates     map[string]string
+	FetchedAt time.Time
+}
+
+// ExchangeRateProvider fetches the latest exchange rates from a rates service
+type ExchangeRateProvider interface {
+	// LatestRates returns the latest rates against the base currency the service quotes
+	LatestRates(ctx context.Context) (*ExchangeRates, error)
+}
+
+// CurrencyService converts prices between currencies and into tokens
+type CurrencyService interface {
+	// Convert converts an amount in minor units of from into minor units of to
+	Convert(ctx context.Context, amountMinor int64, from, to string) (int64, error)
+	// TokenCost returns the whole tokens a price costs, rounded up
+	TokenCost(ctx context.Context, priceMinor int64, currency string) (int64, error)
+}
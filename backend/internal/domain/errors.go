@@ -0,0 +1,37 @@
+package domain
+
+import "errors"
+
+// Error kinds. Every domain error belongs to one of them, so callers can tell
+// a missing record from a bad input without knowing each specific error.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrValidation        = errors.New("validation failed")
+	ErrConflict          = errors.New("conflict")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrForbidden         = errors.New("forbidden")
+	ErrGone              = errors.New("gone")
+	ErrUnavailable       = errors.New("unavailable")
+)
+
+// Error is a domain error with a stable, machine-readable code. It unwraps to
+// its kind, so errors.Is(ErrGiftNotFound, ErrNotFound) holds.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+}
+
+// NewError returns a domain error of the given kind
+func NewError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
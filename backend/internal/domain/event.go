@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// GiftSentEvent is raised once a gift has been paid for; it is also the data of EventGiftSent
+type GiftSentEvent struct {
+	GiftID   string    `json:"gift_id"`
+	GiftName string    `json:"gift_name"`
+	SenderID string    `json:"sender_id"`
+	ArtistID string    `json:"artist_id"`
+	Amount   int64     `json:"amount"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// EventPublisher tells interested parties about business events. Publishing
+// is best effort and never fails the operation that raised the event.
+type EventPublisher interface {
+	PublishGiftSent(ctx context.Context, event GiftSentEvent)
+}
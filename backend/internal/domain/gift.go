@@ -1,20 +1,116 @@
 package domain
 
+import (
+	"context"
+	"time"
+
+	"tokentide/pkg/money"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrGiftNotFound = NewError(ErrNotFound, "gift_not_found", "gift not found")
+	ErrInvalidGift  = NewError(ErrValidation, "invalid_gift", "invalid gift")
+	// ErrGiftAccessDenied is returned when an artist manages another artist's gift
+	ErrGiftAccessDenied = NewError(ErrForbidden, "gift_access_denied", "gift belongs to another artist")
+	// ErrGiftVersionConflict is returned when a gift changed since the version being updated was read
+	ErrGiftVersionConflict = NewError(ErrConflict, "gift_version_conflict", "gift was changed since it was read; reload it and try again")
+	ErrGiftSoldOut         = NewError(ErrConflict, "gift_sold_out", "gift is sold out")
+	// ErrGiftLimitReached is returned when a fan already sent a gift as many times as it allows
+	ErrGiftLimitReached = NewError(ErrConflict, "gift_limit_reached", "you already sent this gift as many times as it allows")
+)
+
 type Gift struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	ArtistID string  `json:"artist_id"`
+	ID          string `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"not null"`
+	Description string `json:"description" gorm:"not null;default:''"`
+	// PriceMinor is the price in minor units of Currency; senders pay its worth in tokens
+	PriceMinor int64     `json:"price_minor" gorm:"not null;index:idx_gifts_currency_price,priority:2"`
+	Currency   string    `json:"currency" gorm:"not null;index:idx_gifts_currency_price,priority:1"`
+	ArtistID   string    `json:"artist_id" gorm:"index"`
+	ImageURL   string    `json:"image_url,omitempty" gorm:"not null;default:''"`
+	ImageKey   string    `json:"-" gorm:"not null;default:''"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+	// Quantity caps how many times the gift can be sent in all, for limited
+	// editions; Sold counts the sends so far, net of refunds. Unlimited when nil.
+	Quantity *int64 `json:"quantity,omitempty"`
+	Sold     int64  `json:"sold" gorm:"not null;default:0"`
+	// PerFanLimit caps how many times each fan can send the gift; unlimited when nil
+	PerFanLimit *int64 `json:"per_fan_limit,omitempty"`
+	// Version goes up with every change; updates must name the version they replace
+	Version int `json:"version" gorm:"not null;default:1"`
+	// DeletedAt is set when the gift is deleted; deleted gifts are hidden from every query
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	// Categories and Tags are left unchanged by updates when nil
+	Categories []Category `json:"categories" gorm:"many2many:gift_categories"`
+	Tags       []Tag      `json:"tags" gorm:"many2many:gift_tags"`
+	// DisplayPrice is the price converted to the currency a client asked for
+	DisplayPrice *money.Amount `json:"display_price,omitempty" gorm:"-"`
+}
+
+// GiftPrice is an entry of a gift's price history: the price in effect from
+// EffectiveFrom until the next entry
+type GiftPrice struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	GiftID        string    `json:"gift_id" gorm:"index:idx_gift_price_history_gift,priority:1;not null"`
+	PriceMinor    int64     `json:"price_minor" gorm:"not null"`
+	Currency      string    `json:"currency" gorm:"not null"`
+	EffectiveFrom time.Time `json:"effective_from" gorm:"index:idx_gift_price_history_gift,priority:2;not null"`
+}
+
+func (GiftPrice) TableName() string {
+	return "gift_price_history"
+}
+
+// Gift list orderings; prefix with "-" to sort descending
+const (
+	GiftSortPrice     = "price"
+	GiftSortCreatedAt = "created_at"
+)
+
+// GiftFilter selects and orders a page of gifts
+type GiftFilter struct {
+	ArtistID   string
+	CategoryID string
+	TagID      string
+	Currency   string
+	// MinPrice and MaxPrice are in minor units and require Currency, since
+	// prices in different currencies do not compare
+	MinPrice *int64
+	MaxPrice *int64
+	// Sort is one of the GiftSort orderings, newest first when empty
+	Sort   string
+	Limit  int
+	Offset int
 }
 
 // GiftRepository is the interface for database operations
 type GiftRepository interface {
-	CreateGift(gift Gift) error
-	GetGiftByID(id string) (*Gift, error)
+	// CreateGift stores the gift and its first price and records events in the
+	// outbox in one transaction
+	CreateGift(ctx context.Context, gift Gift, events ...Event) error
+	GetGiftByID(ctx context.Context, id string) (*Gift, error)
+	ListGifts(ctx context.Context, filter GiftFilter) ([]Gift, int64, error)
+	// UpdateGift stores the gift if its stored version is still gift.Version,
+	// failing with ErrGiftVersionConflict otherwise, and records a price change.
+	// Sold is left unchanged, and a Quantity below it is rejected.
+	UpdateGift(ctx context.Context, gift Gift) error
+	// SetGiftImage records the storage key and URL of the gift's artwork
+	SetGiftImage(ctx context.Context, id, key, url string) error
+	DeleteGift(ctx context.Context, id string) error
+	// ListPriceHistory returns a gift's prices, oldest first, and none for deleted gifts
+	ListPriceHistory(ctx context.Context, giftID string) ([]GiftPrice, error)
 }
 
 // GiftService is the interface for business logic operations
 type GiftService interface {
-	CreateGift(gift Gift) error
-	GetGiftByID(id string) (*Gift, error)
+	CreateGift(ctx context.Context, gift Gift) (*Gift, error)
+	GetGiftByID(ctx context.Context, id string) (*Gift, error)
+	ListGifts(ctx context.Context, filter GiftFilter) ([]Gift, int64, error)
+	UpdateGift(ctx context.Context, id string, gift Gift) (*Gift, error)
+	// SetGiftImage validates and stores an uploaded image as the gift's artwork
+	SetGiftImage(ctx context.Context, id string, upload []byte) (*Gift, error)
+	DeleteGift(ctx context.Context, id string) error
+	ListPriceHistory(ctx context.Context, giftID string) ([]GiftPrice, error)
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrGiftScheduleNotFound = NewError(ErrNotFound, "gift_schedule_not_found", "gift schedule not found")
+	ErrInvalidGiftSchedule  = NewError(ErrValidation, "invalid_gift_schedule", "invalid gift schedule")
+	// ErrGiftScheduleInactive is returned when cancelling a schedule that already ended
+	ErrGiftScheduleInactive = NewError(ErrConflict, "gift_schedule_inactive", "gift schedule is no longer active")
+)
+
+// How often a scheduled gift is sent
+const (
+	RecurrenceOnce    = "once"
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// Gift schedule states; only active schedules are run
+const (
+	ScheduleActive    = "active"
+	ScheduleCompleted = "completed"
+	ScheduleCancelled = "cancelled"
+	// ScheduleFailed schedules stopped because their gift or a wallet is gone
+	ScheduleFailed = "failed"
+)
+
+// GiftSchedule sends a gift on behalf of a fan at NextRunAt and, unless it
+// happens once, again at every recurrence after that
+type GiftSchedule struct {
+	ID         string `json:"id" gorm:"primaryKey"`
+	SenderID   string `json:"sender_id" gorm:"index;not null"`
+	GiftID     string `json:"gift_id" gorm:"not null"`
+	Recurrence string `json:"recurrence" gorm:"not null"`
+	Status     string `json:"status" gorm:"not null"`
+	// NextRunAt is when the gift is next due; once a schedule ends it keeps the last due time
+	NextRunAt time.Time  `json:"next_run_at" gorm:"index;not null"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	// SentCount counts the gifts sent; LastError explains why the last run sent nothing
+	SentCount int       `json:"sent_count" gorm:"not null;default:0"`
+	LastError string    `json:"last_error,omitempty" gorm:"not null;default:''"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GiftScheduleRepository is the interface for gift schedule persistence
+type GiftScheduleRepository interface {
+	CreateSchedule(ctx context.Context, schedule GiftSchedule) error
+	GetSchedule(ctx context.Context, id string) (*GiftSchedule, error)
+	// ListSchedules returns a page of a fan's schedules, newest first, and the total count
+	ListSchedules(ctx context.Context, senderID string, limit, offset int) ([]GiftSchedule, int64, error)
+	// CancelSchedule cancels an active schedule, failing with ErrGiftScheduleInactive otherwise
+	CancelSchedule(ctx context.Context, id string) error
+	// ListDueSchedules returns up to limit active schedules due by now, the longest overdue first
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]GiftSchedule, error)
+	// ClaimRun moves an active schedule from the run due at due to next and
+	// sets its status, reporting false when another run claimed it first
+	ClaimRun(ctx context.Context, id string, due, next time.Time, status string) (bool, error)
+	// RecordRun records the outcome of a claimed run: a sent gift when runErr is
+	// empty, and otherwise the error, failing the schedule when stop is set
+	RecordRun(ctx context.Context, id string, ranAt time.Time, runErr string, stop bool) error
+}
+
+// GiftScheduleService is the interface for scheduled and recurring gifts
+type GiftScheduleService interface {
+	CreateSchedule(ctx context.Context, schedule GiftSchedule) (*GiftSchedule, error)
+	GetSchedule(ctx context.Context, id string) (*GiftSchedule, error)
+	ListSchedules(ctx context.Context, senderID string, limit, offset int) ([]GiftSchedule, int64, error)
+	CancelSchedule(ctx context.Context, id string) (*GiftSchedule, error)
+	// RunDueSchedules runs up to limit schedules due by now, sending their
+	// gifts, and returns how many it ran
+	RunDueSchedules(ctx context.Context, now time.Time, limit int) (int, error)
+}
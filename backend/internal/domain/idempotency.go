@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord stores the first response to a request carrying an Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string    `gorm:"primaryKey"`
+	RequestHash string    `gorm:"not null"`
+	Completed   bool      `gorm:"not null;default:false"`
+	StatusCode  int       `gorm:"not null;default:0"`
+	ContentType string    `gorm:"not null;default:''"`
+	Body        []byte    `gorm:""`
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+// IdempotencyRepository is the interface for idempotency key persistence
+type IdempotencyRepository interface {
+	// Reserve stores record as in progress. When the key is already taken by a
+	// record newer than expiry it returns that record and false instead.
+	Reserve(ctx context.Context, record IdempotencyRecord, expiry time.Duration) (*IdempotencyRecord, bool, error)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// Release forgets a reserved key so the request can be retried
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+var ErrJobNotFound = NewError(ErrNotFound, "job_not_found", "job not found")
+
+// Job is a unit of background work, as shown to operators
+type Job struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	State       string          `json:"state"`
+	Attempt     int             `json:"attempt"`
+	MaxAttempts int             `json:"max_attempts"`
+	Args        json.RawMessage `json:"args"`
+	// Errors are the errors of the failed attempts, oldest first
+	Errors      []string   `json:"errors,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinalizedAt *time.Time `json:"finalized_at,omitempty"`
+}
+
+// JobQueue is the interface for inspecting the background job queue
+type JobQueue interface {
+	// ListDeadJobs returns up to limit jobs discarded after running out of attempts, most recent first
+	ListDeadJobs(ctx context.Context, limit int) ([]Job, error)
+	// RetryJob makes a job available to run again right away, granting a
+	// dead job one more attempt
+	RetryJob(ctx context.Context, id int64) (*Job, error)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Email notifications
+const (
+	NotificationGiftReceived    = "gift_received"
+	NotificationPurchaseReceipt = "purchase_receipt"
+	NotificationPayoutSent      = "payout_sent"
+)
+
+// Email is a rendered message ready to send
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends email through a provider
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// NotificationPreferences are the emails an account agreed to receive. An
+// account without saved preferences receives them all.
+type NotificationPreferences struct {
+	ArtistID        string    `json:"-" gorm:"primaryKey"`
+	GiftReceived    bool      `json:"gift_received" gorm:"not null"`
+	PurchaseReceipt bool      `json:"purchase_receipt" gorm:"not null"`
+	PayoutSent      bool      `json:"payout_sent" gorm:"not null"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// DefaultNotificationPreferences opts an account into every notification
+func DefaultNotificationPreferences(artistID string) NotificationPreferences {
+	return NotificationPreferences{ArtistID: artistID, GiftReceived: true, PurchaseReceipt: true, PayoutSent: true}
+}
+
+// Allows reports whether the account wants the given notification
+func (p NotificationPreferences) Allows(notification string) bool {
+	switch notification {
+	case NotificationGiftReceived:
+		return p.GiftReceived
+	case NotificationPurchaseReceipt:
+		return p.PurchaseReceipt
+	case NotificationPayoutSent:
+		return p.PayoutSent
+	default:
+		return false
+	}
+}
+
+// NotificationRepository is the interface for notification preference persistence
+type NotificationRepository interface {
+	// GetPreferences returns the saved preferences, or the defaults when there are none
+	GetPreferences(ctx context.Context, artistID string) (*NotificationPreferences, error)
+	SavePreferences(ctx context.Context, preferences NotificationPreferences) error
+}
+
+// NotificationService is the interface for managing notification preferences
+type NotificationService interface {
+	GetPreferences(ctx context.Context, artistID string) (*NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences NotificationPreferences) (*NotificationPreferences, error)
+}
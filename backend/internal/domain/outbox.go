@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types recorded in the outbox
+const (
+	EventGiftCreated       = "gift.created"
+	EventTokensTransferred = "tokens.transferred"
+	EventGiftSent          = "gift.sent"
+	EventPaymentSucceeded  = "payment.succeeded"
+	// EventRefundSucceeded carries the Refund
+	EventRefundSucceeded = "refund.succeeded"
+	EventPayoutCompleted = "payout.completed"
+)
+
+// Event is a domain event. It is written to the outbox in the same
+// transaction as the state change it describes, then relayed to the message
+// broker, so an event is published if and only if the change was committed.
+type Event struct {
+	ID          string          `json:"id" gorm:"primaryKey"`
+	Type        string          `json:"type" gorm:"not null"`
+	AggregateID string          `json:"aggregate_id" gorm:"not null"`
+	Data        json.RawMessage `json:"data" gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time       `json:"occurred_at" gorm:"not null"`
+	PublishedAt *time.Time      `json:"-"`
+}
+
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+// NewEvent builds an event about the aggregate identified by aggregateID
+func NewEvent(eventType, aggregateID string, data any) (Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Data:        payload,
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+// TokensTransferred is the data of EventTokensTransferred
+type TokensTransferred struct {
+	FromWalletID string `json:"from_wallet_id"`
+	ToWalletID   string `json:"to_wallet_id"`
+	Amount       int64  `json:"amount"`
+	GiftID       string `json:"gift_id"`
+}
+
+// PaymentSucceeded is the data of EventPaymentSucceeded
+type PaymentSucceeded struct {
+	PurchaseID  string `json:"purchase_id"`
+	BuyerID     string `json:"buyer_id"`
+	WalletID    string `json:"wallet_id"`
+	Tokens      int64  `json:"tokens"`
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+}
+
+// PayoutCompleted is the data of EventPayoutCompleted
+type PayoutCompleted struct {
+	PayoutID    string `json:"payout_id"`
+	ArtistID    string `json:"artist_id"`
+	Tokens      int64  `json:"tokens"`
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+}
+
+// EventBroker delivers relayed events to downstream consumers. Delivery is
+// at least once; consumers deduplicate by event ID.
+type EventBroker interface {
+	Publish(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// OutboxRepository is the interface for reading back the outbox
+type OutboxRepository interface {
+	// Relay hands up to limit unpublished events, oldest first, to publish
+	// and marks them published once it succeeds. The events stay locked
+	// meanwhile so concurrent relays skip them.
+	Relay(ctx context.Context, limit int, publish func(ctx context.Context, events []Event) error) (int, error)
+	// Purge deletes events published before the cutoff
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
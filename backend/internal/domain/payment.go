@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrTokenPackageNotFound = NewError(ErrNotFound, "token_package_not_found", "token package not found")
+	ErrInvalidTokenPackage  = NewError(ErrValidation, "invalid_token_package", "invalid token package")
+	ErrPurchaseNotFound     = NewError(ErrNotFound, "purchase_not_found", "purchase not found")
+	ErrInvalidWebhook       = NewError(ErrValidation, "invalid_webhook", "invalid webhook")
+	// ErrPaymentRejected is returned by providers that definitely refused a
+	// request; any other provider error leaves its outcome unknown
+	ErrPaymentRejected = NewError(ErrConflict, "payment_rejected", "rejected by the payment provider")
+)
+
+// Purchase states
+const (
+	PurchasePending   = "pending"
+	PurchaseSucceeded = "succeeded"
+	PurchaseFailed    = "failed"
+	PurchaseRefunded  = "refunded"
+)
+
+// TokenPackage is a bundle of tokens sold for real money
+type TokenPackage struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null"`
+	Tokens      int64     `json:"tokens" gorm:"not null"`
+	AmountMinor int64     `json:"amount_minor" gorm:"not null"`
+	Currency    string    `json:"currency" gorm:"not null"`
+	Active      bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Purchase tracks the payment of a token package; tokens are credited once it succeeds
+type Purchase struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	BuyerID     string    `json:"buyer_id" gorm:"index;not null"`
+	PackageID   string    `json:"package_id" gorm:"not null"`
+	Tokens      int64     `json:"tokens" gorm:"not null"`
+	AmountMinor int64     `json:"amount_minor" gorm:"not null"`
+	Currency    string    `json:"currency" gorm:"not null"`
+	Provider    string    `json:"provider" gorm:"not null"`
+	ProviderRef string    `json:"-" gorm:"index"`
+	Status      string    `json:"status" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CheckoutSession is where the buyer is sent to pay
+type CheckoutSession struct {
+	ProviderRef string
+	URL         string
+}
+
+// PaymentEvent is a verified payment notification from a provider
+type PaymentEvent struct {
+	PurchaseID string
+	Status     string
+}
+
+// PaymentProvider is a payment gateway able to take payment for a purchase
+type PaymentProvider interface {
+	Name() string
+	CreateCheckout(ctx context.Context, purchase Purchase, pkg TokenPackage) (*CheckoutSession, error)
+	// ParseWebhook verifies a webhook's signature and extracts the payment outcome;
+	// it returns nil for events that don't affect a purchase
+	ParseWebhook(payload []byte, signature string) (*PaymentEvent, error)
+	// GetPaymentStatus asks the provider for the outcome of a purchase; it
+	// returns nil while the payment is still open
+	GetPaymentStatus(ctx context.Context, purchase Purchase) (*PaymentEvent, error)
+	// Refund returns the full amount of a succeeded purchase to the buyer and
+	// returns the provider's reference for the refund. Retrying with the same
+	// refundID does not refund twice, and returns the outcome of the first
+	// attempt. It returns ErrPaymentRejected when the refund was refused.
+	Refund(ctx context.Context, purchase Purchase, refundID string) (string, error)
+}
+
+// PurchaseRepository is the interface for token package and purchase persistence
+type PurchaseRepository interface {
+	CreateTokenPackage(ctx context.Context, pkg TokenPackage) error
+	GetTokenPackage(ctx context.Context, id string) (*TokenPackage, error)
+	ListTokenPackages(ctx context.Context) ([]TokenPackage, error)
+	CreatePurchase(ctx context.Context, purchase Purchase) error
+	GetPurchase(ctx context.Context, id string) (*Purchase, error)
+	SetProviderRef(ctx context.Context, id, providerRef string) error
+	// CompletePurchase marks a pending or failed purchase succeeded and credits
+	// the wallet in one transaction, along with events; completing a purchase
+	// that succeeded or was refunded since is a no-op
+	CompletePurchase(ctx context.Context, id, walletID string, events ...Event) error
+	FailPurchase(ctx context.Context, id string) error
+	// ListPendingPurchases returns up to limit purchases still pending that were created before before, oldest first
+	ListPendingPurchases(ctx context.Context, before time.Time, limit int) ([]Purchase, error)
+}
+
+// PaymentService is the interface for buying tokens
+type PaymentService interface {
+	CreateTokenPackage(ctx context.Context, pkg TokenPackage) (*TokenPackage, error)
+	ListTokenPackages(ctx context.Context) ([]TokenPackage, error)
+	// CreatePurchase starts a purchase and returns the URL where the buyer pays
+	CreatePurchase(ctx context.Context, buyerID, packageID string) (*Purchase, string, error)
+	GetPurchase(ctx context.Context, id string) (*Purchase, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	// ReconcilePurchases settles up to limit purchases left pending since
+	// before, in case their webhook never arrived, and returns how many it settled
+	ReconcilePurchases(ctx context.Context, before time.Time, limit int) (int, error)
+}
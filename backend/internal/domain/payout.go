@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrPayoutNotFound = NewError(ErrNotFound, "payout_not_found", "payout not found")
+	ErrInvalidPayout  = NewError(ErrValidation, "invalid_payout", "invalid payout")
+	// ErrPayoutNotRequested is returned when a payout was already approved or rejected
+	ErrPayoutNotRequested    = NewError(ErrConflict, "payout_not_requested", "payout was already reviewed")
+	ErrInsufficientEarnings  = NewError(ErrInsufficientFunds, "insufficient_earnings", "not enough withdrawable earnings")
+	ErrPayoutAccountNotFound = NewError(ErrNotFound, "payout_account_not_found", "no payout account is set up")
+	ErrPayoutAccountNotReady = NewError(ErrConflict, "payout_account_not_ready", "payout account onboarding is not complete")
+	ErrPayoutsUnavailable    = NewError(ErrUnavailable, "payouts_unavailable", "payouts are not configured")
+)
+
+// Payout states. A payout is requested by the artist, then either rejected
+// by an admin or approved, which sends the money and leaves it paid or failed.
+// It stays processing while the provider's answer to the transfer is unknown.
+const (
+	PayoutRequested  = "requested"
+	PayoutProcessing = "processing"
+	PayoutPaid       = "paid"
+	PayoutFailed     = "failed"
+	PayoutRejected   = "rejected"
+)
+
+// PayoutAccount is the account at the payout provider an artist is paid to
+type PayoutAccount struct {
+	ArtistID   string `json:"artist_id" gorm:"primaryKey"`
+	Provider   string `json:"provider" gorm:"not null"`
+	ExternalID string `json:"-" gorm:"not null"`
+	// Ready is set once the artist completed the provider's onboarding
+	Ready     bool      `json:"ready" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// OnboardingURL is where the artist completes the account, while it is not ready
+	OnboardingURL string `json:"onboarding_url,omitempty" gorm:"-"`
+}
+
+// Payout withdraws tokens an artist earned from gifts as money. The tokens
+// leave the artist's wallet when the payout is requested and come back if it
+// is rejected or fails.
+type Payout struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	ArtistID string `json:"artist_id" gorm:"index;not null"`
+	WalletID string `json:"wallet_id" gorm:"not null"`
+	Tokens   int64  `json:"tokens" gorm:"not null"`
+	// GrossMinor is the tokens' worth, FeeMinor the platform's share of it and
+	// AmountMinor what the artist is paid, all in minor units of Currency
+	GrossMinor    int64     `json:"gross_minor" gorm:"not null"`
+	FeeMinor      int64     `json:"fee_minor" gorm:"not null"`
+	AmountMinor   int64     `json:"amount_minor" gorm:"not null"`
+	Currency      string    `json:"currency" gorm:"not null"`
+	Status        string    `json:"status" gorm:"not null;index"`
+	ReviewedBy    string    `json:"reviewed_by,omitempty" gorm:"not null;default:''"`
+	ProviderRef   string    `json:"-" gorm:"not null;default:''"`
+	FailureReason string    `json:"failure_reason,omitempty" gorm:"not null;default:''"`
+	CreatedAt     time.Time `json:"created_at" gorm:"index"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PayoutTerms value the tokens artists withdraw
+type PayoutTerms struct {
+	// TokenCurrency and TokenValueMinor value one token, as for gift prices
+	TokenCurrency   string
+	TokenValueMinor int64
+	// FeeRate is the decimal fraction of every payout the platform keeps, e.g. "0.2"
+	FeeRate string
+}
+
+// PayoutBalance is an artist's gift earnings, in tokens
+type PayoutBalance struct {
+	// Earned is every token received from gifts, net of refunds
+	Earned int64 `json:"earned"`
+	// PaidOut and Pending are the tokens of paid payouts and of those not yet settled
+	PaidOut int64 `json:"paid_out"`
+	Pending int64 `json:"pending"`
+	// Withdrawable is the earnings not paid out that are still in the wallet
+	Withdrawable int64 `json:"withdrawable"`
+	// WithdrawableMinor is what withdrawing them all would pay, after the fee
+	WithdrawableMinor int64  `json:"withdrawable_minor"`
+	Currency          string `json:"currency"`
+}
+
+// PayoutFilter selects a page of payouts
+type PayoutFilter struct {
+	ArtistID string
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+// PayoutProvider opens the accounts artists are paid to and sends them money
+type PayoutProvider interface {
+	Name() string
+	// CreateAccount opens an account for the artist and returns its ID
+	CreateAccount(ctx context.Context, artist Artist) (string, error)
+	// OnboardingURL returns a single-use link where the artist completes the account
+	OnboardingURL(ctx context.Context, accountID string) (string, error)
+	// AccountReady reports whether the account can be paid
+	AccountReady(ctx context.Context, accountID string) (bool, error)
+	// Transfer pays the payout's amount to the account and returns the
+	// provider's reference. Retrying the same payout does not pay twice, and
+	// returns the first transfer. It returns ErrPaymentRejected when the
+	// transfer was refused.
+	Transfer(ctx context.Context, payout Payout, accountID string) (string, error)
+}
+
+// PayoutRepository is the interface for payout persistence. Every method that
+// moves tokens records ledger entries in the same transaction.
+type PayoutRepository interface {
+	GetAccount(ctx context.Context, artistID string) (*PayoutAccount, error)
+	// SaveAccount creates or replaces the artist's account
+	SaveAccount(ctx context.Context, account PayoutAccount) error
+	DeleteAccount(ctx context.Context, artistID string) error
+	// GetBalance returns the artist's earnings, with Withdrawable capped by the wallet's balance
+	GetBalance(ctx context.Context, artistID, walletID string) (*PayoutBalance, error)
+	// RequestPayout stores a requested payout and takes its tokens from the
+	// wallet, failing with ErrInsufficientEarnings unless they are withdrawable
+	RequestPayout(ctx context.Context, payout Payout) error
+	// StartPayout moves a requested payout to processing for the reviewer
+	StartPayout(ctx context.Context, id, reviewerID string) (*Payout, error)
+	// CompletePayout marks a processing payout paid, along with events
+	CompletePayout(ctx context.Context, id, providerRef string, events ...Event) error
+	// FailPayout marks a processing payout failed and returns its tokens
+	FailPayout(ctx context.Context, id, reason string) error
+	// RejectPayout marks a requested payout rejected and returns its tokens
+	RejectPayout(ctx context.Context, id, reviewerID, reason string) error
+	// ListProcessingPayouts returns up to limit payouts still processing that were last updated before before, oldest first
+	ListProcessingPayouts(ctx context.Context, before time.Time, limit int) ([]Payout, error)
+	GetPayout(ctx context.Context, id string) (*Payout, error)
+	// ListPayouts returns a page of payouts, newest first, and the total count
+	ListPayouts(ctx context.Context, filter PayoutFilter) ([]Payout, int64, error)
+}
+
+// PayoutService is the interface for artists' payout accounts and withdrawals
+type PayoutService interface {
+	// SetUpAccount opens the artist's payout account unless they have one, and
+	// returns it with an onboarding link while it is not ready
+	SetUpAccount(ctx context.Context, artistID string) (*PayoutAccount, error)
+	// GetAccount returns the artist's payout account, checking with the provider whether it became ready
+	GetAccount(ctx context.Context, artistID string) (*PayoutAccount, error)
+	DeleteAccount(ctx context.Context, artistID string) error
+	GetBalance(ctx context.Context, artistID string) (*PayoutBalance, error)
+	// RequestPayout asks to withdraw tokens, which an admin approves or rejects
+	RequestPayout(ctx context.Context, artistID string, tokens int64) (*Payout, error)
+	// ApprovePayout sends a requested payout's money; a transfer the provider
+	// rejected leaves the payout failed, with its tokens returned, and one
+	// whose outcome is unknown leaves it processing
+	ApprovePayout(ctx context.Context, id string) (*Payout, error)
+	RejectPayout(ctx context.Context, id, reason string) (*Payout, error)
+	GetPayout(ctx context.Context, id string) (*Payout, error)
+	ListPayouts(ctx context.Context, filter PayoutFilter) ([]Payout, int64, error)
+	// ReconcilePayouts asks the provider again about up to limit payouts left
+	// processing since before, and returns how many it settled
+	ReconcilePayouts(ctx context.Context, before time.Time, limit int) (int, error)
+}
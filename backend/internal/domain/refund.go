@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrRefundNotFound = NewError(ErrNotFound, "refund_not_found", "refund not found")
+	// ErrNotRefundable is returned for purchases that did not succeed and ledger
+	// entries that are not the sender's side of a gift send
+	ErrNotRefundable   = NewError(ErrValidation, "not_refundable", "cannot be refunded")
+	ErrAlreadyRefunded = NewError(ErrConflict, "already_refunded", "already refunded")
+)
+
+// Refund kinds, by what is reversed
+const (
+	RefundPurchase = "purchase"
+	RefundGiftSend = "gift_send"
+)
+
+// Refund states. Gift send refunds only move tokens and succeed at once;
+// purchase refunds are pending while the payment provider returns the money,
+// and until its answer is known.
+const (
+	RefundPending   = "pending"
+	RefundSucceeded = "succeeded"
+	RefundFailed    = "failed"
+)
+
+// Refund reverses a token purchase or a gift send. A purchase refund takes
+// the tokens back from the buyer's wallet and returns the money; a gift send
+// refund moves the tokens from the artist's wallet back to the sender's.
+type Refund struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	Kind string `json:"kind" gorm:"not null"`
+	// PurchaseID is set for purchase refunds
+	PurchaseID string `json:"purchase_id,omitempty" gorm:"index"`
+	// TransactionID is the sender's debit entry of a refunded gift send
+	TransactionID string `json:"transaction_id,omitempty" gorm:"index"`
+	GiftID        string `json:"gift_id,omitempty"`
+	// WalletID is the wallet of the buyer or sender being refunded
+	WalletID string `json:"wallet_id" gorm:"not null"`
+	// CounterpartyWalletID is the artist's wallet the tokens of a gift send are taken back from
+	CounterpartyWalletID string    `json:"counterparty_wallet_id,omitempty"`
+	Tokens               int64     `json:"tokens" gorm:"not null"`
+	AmountMinor          int64     `json:"amount_minor,omitempty" gorm:"not null;default:0"`
+	Currency             string    `json:"currency,omitempty" gorm:"not null;default:''"`
+	Reason               string    `json:"reason" gorm:"not null;default:''"`
+	RequestedBy          string    `json:"requested_by" gorm:"not null;default:''"`
+	Status               string    `json:"status" gorm:"not null;index"`
+	ProviderRef          string    `json:"-" gorm:"not null;default:''"`
+	FailureReason        string    `json:"failure_reason,omitempty" gorm:"not null;default:''"`
+	CreatedAt            time.Time `json:"created_at" gorm:"index"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// RefundFilter selects a page of refunds
+type RefundFilter struct {
+	Kind   string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// RefundRepository is the interface for refund persistence. Every method that
+// moves tokens records compensating ledger entries in the same transaction.
+type RefundRepository interface {
+	// RefundGiftSend stores a succeeded gift send refund, moving its tokens
+	// from the artist's wallet back to the sender's, along with events
+	RefundGiftSend(ctx context.Context, refund Refund, events ...Event) error
+	// StartPurchaseRefund stores a pending purchase refund, marks the purchase
+	// refunded and takes its tokens back from the buyer's wallet
+	StartPurchaseRefund(ctx context.Context, refund Refund) error
+	// CompleteRefund marks a pending refund succeeded, along with events
+	CompleteRefund(ctx context.Context, id, providerRef string, events ...Event) error
+	// FailPurchaseRefund marks a pending purchase refund failed, returning the
+	// tokens to the buyer and the purchase to succeeded
+	FailPurchaseRefund(ctx context.Context, id, reason string) error
+	// ListPendingRefunds returns up to limit purchase refunds still pending that were created before before, oldest first
+	ListPendingRefunds(ctx context.Context, before time.Time, limit int) ([]Refund, error)
+	GetRefund(ctx context.Context, id string) (*Refund, error)
+	// ListRefunds returns a page of refunds, newest first, and the total count
+	ListRefunds(ctx context.Context, filter RefundFilter) ([]Refund, int64, error)
+}
+
+// RefundService is the interface for reversing purchases and gift sends. It
+// is used by admins through the API and can be called by automated rules.
+type RefundService interface {
+	// RefundPurchase returns a refund that failed when the provider rejected
+	// it, and one still pending when the provider's answer was not received
+	RefundPurchase(ctx context.Context, purchaseID, reason string) (*Refund, error)
+	// RefundGiftSend reverses the gift send recorded by the sender's debit entry transactionID
+	RefundGiftSend(ctx context.Context, transactionID, reason string) (*Refund, error)
+	GetRefund(ctx context.Context, id string) (*Refund, error)
+	ListRefunds(ctx context.Context, filter RefundFilter) ([]Refund, int64, error)
+	// ReconcileRefunds asks the provider again about up to limit purchase
+	// refunds left pending since before, and returns how many it settled
+	ReconcileRefunds(ctx context.Context, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+// Kinds of search results
+const (
+	SearchGift   = "gift"
+	SearchArtist = "artist"
+)
+
+// SearchQuery selects a page of search results
+type SearchQuery struct {
+	// Text accepts web search syntax: quoted phrases, "or" and a leading "-" to exclude a word
+	Text string
+	// Type is SearchGift or SearchArtist, or empty to search both
+	Type   string
+	Limit  int
+	Offset int
+}
+
+// SearchResult is a gift or artist matching a search, best matches first
+type SearchResult struct {
+	Type string  `json:"type"`
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Rank float64 `json:"rank"`
+}
+
+// SearchRepository is the interface for full-text search over gifts and artists
+type SearchRepository interface {
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, int64, error)
+}
+
+// SearchService is the interface for searching gifts and artists
+type SearchService interface {
+	Search(ctx context.Context, query SearchQuery) ([]SearchResult, int64, error)
+}
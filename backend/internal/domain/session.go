@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrSessionNotFound     = NewError(ErrNotFound, "session_not_found", "session not found")
+	ErrInvalidRefreshToken = NewError(ErrUnauthorized, "invalid_refresh_token", "invalid, expired or revoked refresh token")
+)
+
+// Session is a device an account signed in on. Its refresh token is
+// exchanged for new access tokens and replaced on every exchange. Only hashes
+// of the current and the previous token are stored; a previous token used
+// again means it was stolen, and ends the session.
+type Session struct {
+	ID                string     `json:"id" gorm:"primaryKey"`
+	ArtistID          string     `json:"artist_id" gorm:"index;not null"`
+	TokenHash         string     `json:"-" gorm:"uniqueIndex;not null"`
+	PreviousTokenHash string     `json:"-" gorm:"index;not null;default:''"`
+	UserAgent         string     `json:"user_agent" gorm:"not null;default:''"`
+	IP                string     `json:"ip" gorm:"not null;default:''"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastUsedAt        time.Time  `json:"last_used_at"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	// Current is set on the session of the request listing them
+	Current bool `json:"current" gorm:"-"`
+}
+
+// Active reports whether the session can still be refreshed at now
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Device is the client a session is used from
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// TokenPair is what signing in and refreshing a session return
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token, in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// SessionRepository is the interface for session persistence
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session Session) error
+	GetSession(ctx context.Context, id string) (*Session, error)
+	// GetSessionByTokenHash returns the session whose current or previous refresh token has the hash
+	GetSessionByTokenHash(ctx context.Context, hash string) (*Session, error)
+	// RotateSession stores the session's new token, device and expiry if its
+	// token is still the one with oldHash, and fails with
+	// ErrInvalidRefreshToken if it was rotated or revoked meanwhile
+	RotateSession(ctx context.Context, session Session, oldHash string) error
+	// ListSessions returns the account's sessions still active at now, most recently used first
+	ListSessions(ctx context.Context, artistID string, now time.Time) ([]Session, error)
+	// RevokeSession marks a session revoked at the time given, unless it already was
+	RevokeSession(ctx context.Context, id string, at time.Time) error
+}
+
+// SessionService is the interface for signed-in sessions and their tokens
+type SessionService interface {
+	// StartSession signs the account in on a device and issues its first tokens
+	StartSession(ctx context.Context, artist Artist, device Device) (*TokenPair, error)
+	// Refresh exchanges a refresh token for a new access token and refresh token
+	Refresh(ctx context.Context, refreshToken string, device Device) (*TokenPair, error)
+	// Logout ends the session of a refresh token
+	Logout(ctx context.Context, refreshToken string) error
+	GetSession(ctx context.Context, id string) (*Session, error)
+	ListSessions(ctx context.Context, artistID string) ([]Session, error)
+	RevokeSession(ctx context.Context, id string) error
+}
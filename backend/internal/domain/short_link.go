@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrShortLinkNotFound     = NewError(ErrNotFound, "short_link_not_found", "short link not found")
+	ErrShortLinkExpired      = NewError(ErrGone, "short_link_expired", "short link expired")
+	ErrShortLinkExists       = NewError(ErrConflict, "short_link_exists", "short link code already in use")
+	ErrInvalidTargetURL      = NewError(ErrValidation, "invalid_target_url", "target_url must be an absolute http(s) URL on a platform host")
+	ErrShortLinkAccessDenied = NewError(ErrForbidden, "short_link_access_denied", "short link belongs to another account")
+)
+
+// ShortLink redirects /l/:code to a campaign target URL
+type ShortLink struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	ArtistID    string     `json:"artist_id" gorm:"index;not null"`
+	Code        string     `json:"code" gorm:"uniqueIndex;not null"`
+	TargetURL   string     `json:"target_url" gorm:"not null"`
+	UTMSource   string     `json:"utm_source,omitempty"`
+	UTMMedium   string     `json:"utm_medium,omitempty"`
+	UTMCampaign string     `json:"utm_campaign,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ShortLinkClick records a single visit of a short link
+type ShortLinkClick struct {
+	ID          uint      `json:"-" gorm:"primaryKey"`
+	ShortLinkID string    `json:"short_link_id" gorm:"index;not null"`
+	Referrer    string    `json:"referrer"`
+	Country     string    `json:"country"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ShortLinkStats aggregates clicks of a short link
+type ShortLinkStats struct {
+	Code        string           `json:"code"`
+	TotalClicks int64            `json:"total_clicks"`
+	ByReferrer  map[string]int64 `json:"by_referrer"`
+	ByCountry   map[string]int64 `json:"by_country"`
+}
+
+// ShortLinkRepository is the interface for short link persistence
+type ShortLinkRepository interface {
+	CreateShortLink(ctx context.Context, link ShortLink) error
+	GetShortLinkByCode(ctx context.Context, code string) (*ShortLink, error)
+	RecordClick(ctx context.Context, click ShortLinkClick) error
+	GetClickStats(ctx context.Context, linkID string) (*ShortLinkStats, error)
+}
+
+// ShortLinkService is the interface for short link business logic
+type ShortLinkService interface {
+	CreateShortLink(ctx context.Context, link ShortLink) (*ShortLink, error)
+	GetShortLink(ctx context.Context, code string) (*ShortLink, error)
+	// Resolve records the click and returns the URL to redirect to, with UTM parameters applied
+	Resolve(ctx context.Context, code string, click ShortLinkClick, utm map[string]string) (string, error)
+	GetStats(ctx context.Context, code string) (*ShortLinkStats, error)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrIncidentNotFound = NewError(ErrNotFound, "incident_not_found", "incident not found")
+	ErrInvalidIncident  = NewError(ErrValidation, "invalid_incident", "invalid incident")
+)
+
+// Incident lifecycle states, in the order they usually happen
+const (
+	IncidentInvestigating = "investigating"
+	IncidentIdentified    = "identified"
+	IncidentMonitoring    = "monitoring"
+	IncidentResolved      = "resolved"
+)
+
+// Component states shown on the status page, from best to worst
+const (
+	ComponentOperational   = "operational"
+	ComponentDegraded      = "degraded"
+	ComponentPartialOutage = "partial_outage"
+	ComponentMajorOutage   = "major_outage"
+)
+
+// Incident is a user-facing disruption of a single component
+type Incident struct {
+	ID         string           `json:"id" gorm:"primaryKey"`
+	Component  string           `json:"component" gorm:"index;not null"`
+	Title      string           `json:"title" gorm:"not null"`
+	Impact     string           `json:"impact" gorm:"not null"`
+	Status     string           `json:"status" gorm:"index;not null"`
+	StartedAt  time.Time        `json:"started_at"`
+	ResolvedAt *time.Time       `json:"resolved_at,omitempty"`
+	Updates    []IncidentUpdate `json:"updates" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// IncidentUpdate is one entry of an incident's public timeline
+type IncidentUpdate struct {
+	ID         uint      `json:"-" gorm:"primaryKey"`
+	IncidentID string    `json:"-" gorm:"index;not null"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ComponentUptime is the rolling availability of a component as seen by the health prober
+type ComponentUptime struct {
+	Name          string  `json:"name"`
+	Healthy       bool    `json:"healthy"`
+	UptimePercent float64 `json:"uptime_percent"`
+	AvgLatencyMS  float64 `json:"avg_latency_ms"`
+	Samples       int     `json:"samples"`
+}
+
+// ComponentStatus is the public state of a single component
+type ComponentStatus struct {
+	Name          string  `json:"name"`
+	Status        string  `json:"status"`
+	UptimePercent float64 `json:"uptime_percent"`
+	AvgLatencyMS  float64 `json:"avg_latency_ms"`
+}
+
+// Status is the payload of the public status page
+type Status struct {
+	Status          string            `json:"status"`
+	Components      []ComponentStatus `json:"components"`
+	ActiveIncidents []Incident        `json:"active_incidents"`
+	RecentIncidents []Incident        `json:"recent_incidents"`
+}
+
+// UptimeTracker provides rolling availability statistics per component
+type UptimeTracker interface {
+	Uptime() []ComponentUptime
+}
+
+// IncidentRepository is the interface for incident persistence
+type IncidentRepository interface {
+	CreateIncident(ctx context.Context, incident Incident) error
+	GetIncidentByID(ctx context.Context, id string) (*Incident, error)
+	UpdateIncident(ctx context.Context, incident Incident, update IncidentUpdate) error
+	// ListIncidents returns unresolved incidents plus those resolved after resolvedSince
+	ListIncidents(ctx context.Context, resolvedSince time.Time) ([]Incident, error)
+}
+
+// StatusService is the interface for the status page and incident management
+type StatusService interface {
+	GetStatus(ctx context.Context) (*Status, error)
+	OpenIncident(ctx context.Context, incident Incident, message string) (*Incident, error)
+	UpdateIncident(ctx context.Context, id, status, impact, message string) (*Incident, error)
+	ResolveIncident(ctx context.Context, id, message string) (*Incident, error)
+}
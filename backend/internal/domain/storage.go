@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+// ErrInvalidImage is returned for uploads that are not a supported image
+var ErrInvalidImage = NewError(ErrValidation, "invalid_image", "invalid image")
+
+// FileStorage keeps uploaded files and serves them from public URLs
+type FileStorage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Delete(ctx context.Context, key string) error
+	// URL is where clients fetch the file stored under key
+	URL(key string) string
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var ErrTransactionNotFound = NewError(ErrNotFound, "transaction_not_found", "transaction not found")
+
+// Transaction types, seen from the wallet the entry belongs to
+const (
+	TransactionCredit = "credit"
+	TransactionDebit  = "debit"
+)
+
+// Transaction is an append-only ledger entry for a single balance change.
+// Transfers produce a debit on the sender and a credit on the recipient,
+// each pointing at the other wallet as counterparty.
+type Transaction struct {
+	ID                   string    `json:"id" gorm:"primaryKey"`
+	WalletID             string    `json:"wallet_id" gorm:"index:idx_transactions_wallet_created,priority:1;not null"`
+	Type                 string    `json:"type" gorm:"not null"`
+	Amount               int64     `json:"amount" gorm:"not null;check:amount > 0"`
+	BalanceAfter         int64     `json:"balance_after" gorm:"not null"`
+	CounterpartyWalletID string    `json:"counterparty_wallet_id,omitempty"`
+	GiftID               string    `json:"gift_id,omitempty" gorm:"index"`
+	PurchaseID           string    `json:"purchase_id,omitempty" gorm:"index"`
+	RefundID             string    `json:"refund_id,omitempty" gorm:"index"`
+	PayoutID             string    `json:"payout_id,omitempty" gorm:"index"`
+	CreatedAt            time.Time `json:"created_at" gorm:"index:idx_transactions_wallet_created,priority:2"`
+}
+
+// TransactionRepository reads the ledger; entries are written by WalletRepository
+// in the same database transaction as the balance change they record
+type TransactionRepository interface {
+	// ListTransactions returns a page of a wallet's entries, newest first, and the total count
+	ListTransactions(ctx context.Context, walletID string, limit, offset int) ([]Transaction, int64, error)
+	GetTransaction(ctx context.Context, id string) (*Transaction, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// TxManager runs units of work spanning several repositories in one database
+// transaction. Repository calls made with the context fn gets join the
+// transaction, which commits when fn returns nil and rolls back otherwise.
+type TxManager interface {
+	// WithinTransaction runs fn in a transaction. Nested calls join the
+	// transaction already open rather than starting their own.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrWalletNotFound     = NewError(ErrNotFound, "wallet_not_found", "wallet not found")
+	ErrInvalidAmount      = NewError(ErrValidation, "invalid_amount", "amount must be greater than zero")
+	ErrInvalidTransfer    = NewError(ErrValidation, "invalid_transfer", "invalid transfer")
+	ErrWalletAccessDenied = NewError(ErrForbidden, "wallet_access_denied", "wallet belongs to another account")
+)
+
+// Wallet holds an account's token balance, in whole tokens
+type Wallet struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	OwnerID   string    `json:"owner_id" gorm:"uniqueIndex;not null"`
+	Balance   int64     `json:"balance" gorm:"not null;default:0;check:balance >= 0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WalletRepository is the interface for wallet persistence; balance changes are atomic
+type WalletRepository interface {
+	// CreateWallet creates the wallet unless its owner already has one
+	CreateWallet(ctx context.Context, wallet Wallet) error
+	GetWalletByID(ctx context.Context, id string) (*Wallet, error)
+	GetWalletByOwner(ctx context.Context, ownerID string) (*Wallet, error)
+	// AdjustBalance adds delta (which may be negative) to the balance, failing
+	// with ErrInsufficientFunds rather than going below zero
+	AdjustBalance(ctx context.Context, id string, delta int64) (*Wallet, error)
+	// Transfer moves amount between two wallets in a single transaction,
+	// optionally paying for a gift. A gift send takes one of the gift's stock,
+	// failing with ErrGiftSoldOut or ErrGiftLimitReached when there is none
+	// left for the sender.
+	Transfer(ctx context.Context, fromID, toID string, amount int64, giftID string, events ...Event) (*Wallet, error)
+}
+
+// WalletService is the interface for token balance operations
+type WalletService interface {
+	GetWallet(ctx context.Context, id string) (*Wallet, error)
+	// GetWalletForOwner returns the owner's wallet, creating it on first use
+	GetWalletForOwner(ctx context.Context, ownerID string) (*Wallet, error)
+	Credit(ctx context.Context, walletID string, amount int64) (*Wallet, error)
+	Debit(ctx context.Context, walletID string, amount int64) (*Wallet, error)
+	// SendGift pays the gift's price from the sender's wallet to the gift artist's wallet
+	SendGift(ctx context.Context, senderID, giftID string) (*Wallet, error)
+	ListTransactions(ctx context.Context, walletID string, limit, offset int) ([]Transaction, int64, error)
+}
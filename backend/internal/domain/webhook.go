@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound     = NewError(ErrNotFound, "webhook_not_found", "webhook not found")
+	ErrInvalidWebhookURL   = NewError(ErrValidation, "invalid_webhook_url", "webhook URL must be an absolute http or https URL")
+	ErrWebhookAccessDenied = NewError(ErrForbidden, "webhook_access_denied", "webhook belongs to another account")
+
+	ErrWebhookDeliveryNotFound = NewError(ErrNotFound, "webhook_delivery_not_found", "webhook delivery not found")
+)
+
+// Events delivered to webhooks
+const (
+	WebhookGiftReceived    = "gift.received"
+	WebhookPayoutCompleted = "payout.completed"
+)
+
+// Webhook delivery states
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook is an artist's endpoint notified of the events concerning them
+type Webhook struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	ArtistID string `json:"artist_id" gorm:"index;not null"`
+	URL      string `json:"url" gorm:"not null"`
+	// Secret signs every delivery; it is only returned when the webhook is created
+	Secret    string    `json:"secret,omitempty" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one event sent, or being retried, to a webhook
+type WebhookDelivery struct {
+	ID             string          `json:"id" gorm:"primaryKey"`
+	WebhookID      string          `json:"webhook_id" gorm:"not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventID        string          `json:"event_id" gorm:"not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string          `json:"event_type" gorm:"not null"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	Status         string          `json:"status" gorm:"not null"`
+	Attempts       int             `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookRepository is the interface for webhook and delivery log persistence
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook Webhook) error
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+	ListWebhooks(ctx context.Context, artistID string) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	// EnqueueDeliveries records a pending delivery of payload for every
+	// webhook of the artist and returns them; an event already recorded for a
+	// webhook is not recorded again
+	EnqueueDeliveries(ctx context.Context, artistID, eventID, eventType string, payload []byte) ([]WebhookDelivery, error)
+	GetDelivery(ctx context.Context, webhookID, eventID string) (*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]WebhookDelivery, int64, error)
+}
+
+// WebhookService is the interface for managing webhooks
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, artistID, url string) (*Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+	ListWebhooks(ctx context.Context, artistID string) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]WebhookDelivery, int64, error)
+}
@@ -0,0 +1,31 @@
+// Package email sends notification emails about the events concerning an
+// account, through SMTP (including providers such as SES) or SendGrid
+package email
+
+import (
+	"context"
+	"tokentide/internal/domain"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+)
+
+// NewMailer returns the mailer of the configured provider, or one that only
+// logs emails when none is configured
+func NewMailer(cfg config.EmailConfig) domain.Mailer {
+	switch cfg.Provider {
+	case config.EmailSMTP:
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case config.EmailSendGrid:
+		return NewSendGridMailer(cfg.SendGridAPIKey, cfg.From)
+	default:
+		return LogMailer{}
+	}
+}
+
+// LogMailer logs emails instead of sending them, for development
+type LogMailer struct{}
+
+func (LogMailer) Send(ctx context.Context, email domain.Email) error {
+	logging.FromContext(ctx).Debug("Email not sent, no provider configured", "to", email.To, "subject", email.Subject, "text", email.Text)
+	return nil
+}
@@ -0,0 +1,71 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"tokentide/internal/domain"
+	"tokentide/internal/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// Notifier is an EventBroker that queues the notification email each
+// relevant event calls for. Whether the recipient opted out is checked when
+// the email is sent, so a change of preferences applies to queued emails too.
+type Notifier struct {
+	queue *jobs.Client
+}
+
+func NewNotifier(queue *jobs.Client) *Notifier {
+	return &Notifier{queue: queue}
+}
+
+func (n *Notifier) Publish(ctx context.Context, events []domain.Event) error {
+	var args []river.JobArgs
+	for _, event := range events {
+		recipientID, notification, ok, err := route(event)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		args = append(args, jobs.EmailArgs{
+			Notification: notification,
+			RecipientID:  recipientID,
+			EventID:      event.ID,
+			Data:         event.Data,
+		})
+	}
+	return n.queue.Enqueue(ctx, args...)
+}
+
+func (n *Notifier) Close() error {
+	return nil
+}
+
+// route maps a domain event to the account notified and the notification sent
+func route(event domain.Event) (recipientID, notification string, ok bool, err error) {
+	switch event.Type {
+	case domain.EventGiftSent:
+		var sent domain.GiftSentEvent
+		if err := json.Unmarshal(event.Data, &sent); err != nil {
+			return "", "", false, err
+		}
+		return sent.ArtistID, domain.NotificationGiftReceived, true, nil
+	case domain.EventPaymentSucceeded:
+		var paid domain.PaymentSucceeded
+		if err := json.Unmarshal(event.Data, &paid); err != nil {
+			return "", "", false, err
+		}
+		return paid.BuyerID, domain.NotificationPurchaseReceipt, true, nil
+	case domain.EventPayoutCompleted:
+		var paid domain.PayoutCompleted
+		if err := json.Unmarshal(event.Data, &paid); err != nil {
+			return "", "", false, err
+		}
+		return paid.ArtistID, domain.NotificationPayoutSent, true, nil
+	default:
+		return "", "", false, nil
+	}
+}
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"tokentide/internal/domain"
+	"tokentide/pkg/money"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// locale formats amounts in emails until accounts have a locale of their own
+const locale = "en-US"
+
+var (
+	textTemplates = map[string]*texttemplate.Template{}
+	htmlTemplates = map[string]*htmltemplate.Template{}
+)
+
+// templateFuncs are available to every template
+var templateFuncs = map[string]any{
+	// money formats an amount in minor units, e.g. money 1999 "USD" is "$19.99"
+	"money": func(amountMinor any, currency any) (string, error) {
+		n, err := wholeNumber(amountMinor)
+		if err != nil {
+			return "", err
+		}
+		return money.Format(n, fmt.Sprint(currency), locale)
+	},
+}
+
+func init() {
+	for _, notification := range []string{domain.NotificationGiftReceived, domain.NotificationPurchaseReceipt, domain.NotificationPayoutSent} {
+		file := "templates/" + notification + ".tmpl"
+		textTemplates[notification] = texttemplate.Must(texttemplate.New(notification).Funcs(templateFuncs).Option("missingkey=error").ParseFS(templateFiles, file))
+		htmlTemplates[notification] = htmltemplate.Must(htmltemplate.New(notification).Funcs(templateFuncs).Option("missingkey=error").ParseFS(templateFiles, file))
+	}
+}
+
+// message is what templates render: the recipient's name and the event data
+type message struct {
+	Name string
+	Data map[string]any
+}
+
+// Render builds the email for a notification from the data of its event
+func Render(notification, to, name string, data json.RawMessage) (domain.Email, error) {
+	text, ok := textTemplates[notification]
+	if !ok {
+		return domain.Email{}, fmt.Errorf("no email template for %q", notification)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	msg := message{Name: name}
+	if err := decoder.Decode(&msg.Data); err != nil {
+		return domain.Email{}, fmt.Errorf("decode %s data: %w", notification, err)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", msg); err != nil {
+		return domain.Email{}, err
+	}
+	if err := text.ExecuteTemplate(&body, "text", msg); err != nil {
+		return domain.Email{}, err
+	}
+	if err := htmlTemplates[notification].ExecuteTemplate(&html, "html", msg); err != nil {
+		return domain.Email{}, err
+	}
+
+	return domain.Email{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    body.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// wholeNumber reads a whole number decoded from JSON
+func wholeNumber(value any) (int64, error) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+	return n.Int64()
+}
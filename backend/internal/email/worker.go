@@ -0,0 +1,61 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"tokentide/internal/domain"
+	"tokentide/internal/jobs"
+	"tokentide/internal/metrics"
+	"tokentide/pkg/logging"
+
+	"github.com/riverqueue/river"
+)
+
+// SendWorker renders and sends a queued notification email, unless the
+// recipient opted out of it
+type SendWorker struct {
+	river.WorkerDefaults[jobs.EmailArgs]
+	mailer        domain.Mailer
+	artists       domain.ArtistRepository
+	notifications domain.NotificationRepository
+}
+
+func NewSendWorker(mailer domain.Mailer, artists domain.ArtistRepository, notifications domain.NotificationRepository) *SendWorker {
+	return &SendWorker{mailer: mailer, artists: artists, notifications: notifications}
+}
+
+func (w *SendWorker) Work(ctx context.Context, job *river.Job[jobs.EmailArgs]) error {
+	args := job.Args
+	logger := logging.FromContext(ctx).With("notification", args.Notification, "recipient_id", args.RecipientID, "event_id", args.EventID)
+
+	recipient, err := w.artists.GetArtistByID(ctx, args.RecipientID)
+	if errors.Is(err, domain.ErrArtistNotFound) {
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	preferences, err := w.notifications.GetPreferences(ctx, recipient.ID)
+	if err != nil {
+		return err
+	}
+	if !preferences.Allows(args.Notification) {
+		logger.Debug("Email skipped, recipient opted out")
+		metrics.EmailsSent.WithLabelValues(args.Notification, "opted_out").Inc()
+		return nil
+	}
+
+	email, err := Render(args.Notification, recipient.Email, recipient.Name, args.Data)
+	if err != nil {
+		// The data will not render any better on a retry
+		return river.JobCancel(err)
+	}
+	if err := w.mailer.Send(ctx, email); err != nil {
+		metrics.EmailsSent.WithLabelValues(args.Notification, "failed").Inc()
+		return err
+	}
+	metrics.EmailsSent.WithLabelValues(args.Notification, "sent").Inc()
+	logger.Info("Email sent")
+	return nil
+}
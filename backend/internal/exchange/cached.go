@@ -0,0 +1,53 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+)
+
+// retryAfter is how long stale rates are served after a failed refresh before trying again
+const retryAfter = time.Minute
+
+// Cached keeps the latest rates of a provider in memory for ttl. Rates
+// services bill per request and publish hourly at best, so each process
+// fetches at most once per ttl. When a refresh fails, the previous rates keep
+// being served rather than failing every conversion.
+type Cached struct {
+	provider domain.ExchangeRateProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	rates     *domain.ExchangeRates
+	expiresAt time.Time
+}
+
+func NewCached(provider domain.ExchangeRateProvider, ttl time.Duration) *Cached {
+	return &Cached{provider: provider, ttl: ttl}
+}
+
+func (c *Cached) LatestRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.rates != nil && now.Before(c.expiresAt) {
+		return c.rates, nil
+	}
+
+	rates, err := c.provider.LatestRates(ctx)
+	if err != nil {
+		if c.rates == nil {
+			return nil, err
+		}
+		logging.FromContext(ctx).Warn("Could not refresh exchange rates, serving stale rates", "fetched_at", c.rates.FetchedAt, "error", err)
+		c.expiresAt = now.Add(retryAfter)
+		return c.rates, nil
+	}
+	c.rates = rates
+	c.expiresAt = now.Add(c.ttl)
+	return rates, nil
+}
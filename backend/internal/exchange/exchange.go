@@ -0,0 +1,63 @@
+// Package exchange fetches currency exchange rates from third-party rates services
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/config"
+)
+
+// getJSON fetches a rates document. Rates are decoded as json.Number so their
+// decimal text reaches the conversion untouched.
+func getJSON(ctx context.Context, client *http.Client, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, 1<<20))
+	decoder.UseNumber()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("rates service answered %d: %w", resp.StatusCode, err)
+	}
+	return nil
+}
+
+// rateStrings turns decoded rates into the decimal strings of domain.ExchangeRates
+func rateStrings(rates map[string]json.Number) map[string]string {
+	out := make(map[string]string, len(rates))
+	for code, rate := range rates {
+		out[code] = rate.String()
+	}
+	return out
+}
+
+func newClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// New returns the configured rates service behind an in-memory cache, or nil
+// when none is configured
+func New(cfg config.CurrencyConfig) domain.ExchangeRateProvider {
+	switch cfg.RatesProvider {
+	case config.ExchangeFixer:
+		return NewCached(NewFixer(cfg.RatesAPIKey), cfg.RatesTTL)
+	case config.ExchangeOpenExchangeRates:
+		return NewCached(NewOpenExchangeRates(cfg.RatesAPIKey), cfg.RatesTTL)
+	default:
+		return nil
+	}
+}
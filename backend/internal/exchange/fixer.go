@@ -0,0 +1,53 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+const fixerURL = "https://data.fixer.io/api/latest"
+
+// Fixer fetches rates from fixer.io, quoted against EUR on the free plans
+type Fixer struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewFixer(apiKey string) *Fixer {
+	return &Fixer{apiKey: apiKey, client: newClient()}
+}
+
+type fixerResponse struct {
+	Success   bool                   `json:"success"`
+	Timestamp int64                  `json:"timestamp"`
+	Base      string                 `json:"base"`
+	Rates     map[string]json.Number `json:"rates"`
+	Error     struct {
+		Code int    `json:"code"`
+		Type string `json:"type"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+func (f *Fixer) LatestRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	var body fixerResponse
+	if err := getJSON(ctx, f.client, fixerURL+"?access_key="+url.QueryEscape(f.apiKey), &body); err != nil {
+		return nil, fmt.Errorf("fixer: %w", err)
+	}
+	// Fixer answers errors with a 200 and success set to false
+	if !body.Success {
+		return nil, fmt.Errorf("fixer: error %d: %s %s", body.Error.Code, body.Error.Type, body.Error.Info)
+	}
+
+	return &domain.ExchangeRates{
+		Base:      body.Base,
+		Rates:     rateStrings(body.Rates),
+		FetchedAt: time.Unix(body.Timestamp, 0),
+	}, nil
+}
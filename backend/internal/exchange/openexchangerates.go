@@ -0,0 +1,50 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+const openExchangeRatesURL = "https://openexchangerates.org/api/latest.json"
+
+// OpenExchangeRates fetches rates from openexchangerates.org, quoted against USD on the free plans
+type OpenExchangeRates struct {
+	appID  string
+	client *http.Client
+}
+
+func NewOpenExchangeRates(appID string) *OpenExchangeRates {
+	return &OpenExchangeRates{appID: appID, client: newClient()}
+}
+
+type openExchangeRatesResponse struct {
+	Timestamp   int64                  `json:"timestamp"`
+	Base        string                 `json:"base"`
+	Rates       map[string]json.Number `json:"rates"`
+	Error       bool                   `json:"error"`
+	Status      int                    `json:"status"`
+	Message     string                 `json:"message"`
+	Description string                 `json:"description"`
+}
+
+func (o *OpenExchangeRates) LatestRates(ctx context.Context) (*domain.ExchangeRates, error) {
+	var body openExchangeRatesResponse
+	if err := getJSON(ctx, o.client, openExchangeRatesURL+"?app_id="+url.QueryEscape(o.appID), &body); err != nil {
+		return nil, fmt.Errorf("openexchangerates: %w", err)
+	}
+	if body.Error {
+		return nil, fmt.Errorf("openexchangerates: error %d: %s %s", body.Status, body.Message, body.Description)
+	}
+
+	return &domain.ExchangeRates{
+		Base:      body.Base,
+		Rates:     rateStrings(body.Rates),
+		FetchedAt: time.Unix(body.Timestamp, 0),
+	}, nil
+}
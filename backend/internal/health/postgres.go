@@ -0,0 +1,18 @@
+package health
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PostgresCheck pings the database behind the GORM connection
+func PostgresCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
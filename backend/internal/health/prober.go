@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CheckFunc probes a single dependency, returning an error when it is unavailable
+type CheckFunc func(ctx context.Context) error
+
+// DependencyStatus is the outcome of the latest probe of a dependency
+type DependencyStatus struct {
+	Name        string     `json:"name"`
+	Healthy     bool       `json:"healthy"`
+	LatencyMS   float64    `json:"latency_ms"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type dependency struct {
+	name  string
+	check CheckFunc
+}
+
+// Prober runs dependency checks concurrently, each bounded by a timeout
+type Prober struct {
+	timeout      time.Duration
+	dependencies []dependency
+
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
+}
+
+func NewProber(timeout time.Duration) *Prober {
+	return &Prober{
+		timeout:     timeout,
+		lastSuccess: make(map[string]time.Time),
+	}
+}
+
+// Register adds a dependency to probe; it must be called before Probe is used
+func (p *Prober) Register(name string, check CheckFunc) {
+	p.dependencies = append(p.dependencies, dependency{name: name, check: check})
+}
+
+// Probe checks every registered dependency and returns their statuses in registration order
+func (p *Prober) Probe(ctx context.Context) []DependencyStatus {
+	statuses := make([]DependencyStatus, len(p.dependencies))
+
+	var wg sync.WaitGroup
+	for i, dep := range p.dependencies {
+		wg.Add(1)
+		go func(i int, dep dependency) {
+			defer wg.Done()
+			statuses[i] = p.probe(ctx, dep)
+		}(i, dep)
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+func (p *Prober) probe(ctx context.Context, dep dependency) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.check(ctx)
+	status := DependencyStatus{
+		Name:      dep.name,
+		Healthy:   err == nil,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		p.lastSuccess[dep.name] = start
+	}
+	if last, ok := p.lastSuccess[dep.name]; ok {
+		status.LastSuccess = &last
+	}
+	return status
+}
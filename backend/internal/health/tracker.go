@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type sample struct {
+	healthy   bool
+	latencyMS float64
+}
+
+// Tracker probes dependencies on an interval and keeps a rolling window of
+// results per dependency. Samples are kept in memory, so each replica
+// reports what it observed itself.
+type Tracker struct {
+	prober   *Prober
+	interval time.Duration
+	window   int
+
+	mu      sync.RWMutex
+	samples map[string][]sample
+	order   []string
+}
+
+// NewTracker keeps the last window probes of each dependency, taken every interval
+func NewTracker(prober *Prober, interval time.Duration, window int) *Tracker {
+	return &Tracker{
+		prober:   prober,
+		interval: interval,
+		window:   window,
+		samples:  make(map[string][]sample),
+	}
+}
+
+// Run probes until the context is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		t.record(t.prober.Probe(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) record(statuses []DependencyStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, status := range statuses {
+		samples, ok := t.samples[status.Name]
+		if !ok {
+			t.order = append(t.order, status.Name)
+		}
+
+		samples = append(samples, sample{healthy: status.Healthy, latencyMS: status.LatencyMS})
+		if len(samples) > t.window {
+			samples = samples[len(samples)-t.window:]
+		}
+		t.samples[status.Name] = samples
+	}
+}
+
+// Uptime summarizes the rolling window of every dependency probed so far
+func (t *Tracker) Uptime() []domain.ComponentUptime {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	uptimes := make([]domain.ComponentUptime, 0, len(t.order))
+	for _, name := range t.order {
+		samples := t.samples[name]
+
+		var healthy int
+		var latency float64
+		for _, s := range samples {
+			if s.healthy {
+				healthy++
+			}
+			latency += s.latencyMS
+		}
+
+		uptimes = append(uptimes, domain.ComponentUptime{
+			Name:          name,
+			Healthy:       samples[len(samples)-1].healthy,
+			UptimePercent: 100 * float64(healthy) / float64(len(samples)),
+			AvgLatencyMS:  latency / float64(len(samples)),
+			Samples:       len(samples),
+		})
+	}
+	return uptimes
+}
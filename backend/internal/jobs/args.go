@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"encoding/json"
+
+	"github.com/riverqueue/river"
+)
+
+// Job kinds, as stored in the queue; renaming one orphans the queued jobs of that kind
+const (
+	KindWebhookDelivery   = "webhook_delivery"
+	KindReconcilePayments = "reconcile_payments"
+	KindReconcilePayouts  = "reconcile_payouts"
+	KindEmail             = "email"
+	KindRunGiftSchedules  = "run_gift_schedules"
+	KindRefreshGiftStats  = "refresh_gift_stats"
+)
+
+// WebhookDeliveryArgs sends an event to one webhook
+type WebhookDeliveryArgs struct {
+	WebhookID string `json:"webhook_id"`
+	EventID   string `json:"event_id"`
+}
+
+func (WebhookDeliveryArgs) Kind() string {
+	return KindWebhookDelivery
+}
+
+// InsertOpts gives a delivery about a day of attempts with the webhook
+// backoff, and keeps an event from being queued twice for the same webhook
+func (WebhookDeliveryArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		MaxAttempts: 10,
+		UniqueOpts:  river.UniqueOpts{ByArgs: true},
+	}
+}
+
+// ReconcilePaymentsArgs settles purchases whose payment webhook never arrived,
+// and purchase refunds whose outcome is unknown
+type ReconcilePaymentsArgs struct{}
+
+func (ReconcilePaymentsArgs) Kind() string {
+	return KindReconcilePayments
+}
+
+func (ReconcilePaymentsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: 3}
+}
+
+// ReconcilePayoutsArgs settles payouts whose transfer outcome is unknown
+type ReconcilePayoutsArgs struct{}
+
+func (ReconcilePayoutsArgs) Kind() string {
+	return KindReconcilePayouts
+}
+
+func (ReconcilePayoutsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: 3}
+}
+
+// RunGiftSchedulesArgs sends the gifts of the schedules that are due
+type RunGiftSchedulesArgs struct{}
+
+func (RunGiftSchedulesArgs) Kind() string {
+	return KindRunGiftSchedules
+}
+
+// InsertOpts does not retry, as the next periodic run picks up whatever a
+// failed one left due
+func (RunGiftSchedulesArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: 1}
+}
+
+// RefreshGiftStatsArgs brings the leaderboard and statistics aggregates up to date
+type RefreshGiftStatsArgs struct{}
+
+func (RefreshGiftStatsArgs) Kind() string {
+	return KindRefreshGiftStats
+}
+
+func (RefreshGiftStatsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{MaxAttempts: 1}
+}
+
+// EmailArgs sends a notification email about an event to an account
+type EmailArgs struct {
+	Notification string          `json:"notification"`
+	RecipientID  string          `json:"recipient_id"`
+	EventID      string          `json:"event_id"`
+	Data         json.RawMessage `json:"data"`
+}
+
+func (EmailArgs) Kind() string {
+	return KindEmail
+}
+
+// InsertOpts keeps an event from being emailed twice to the same account
+func (EmailArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		MaxAttempts: 8,
+		UniqueOpts:  river.UniqueOpts{ByArgs: true},
+	}
+}
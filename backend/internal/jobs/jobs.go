@@ -0,0 +1,177 @@
+// Package jobs runs background work on a queue kept in Postgres, so jobs
+// survive restarts and are retried with backoff until they succeed or run
+// out of attempts, at which point they are kept as dead jobs for inspection
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// stopTimeout bounds how long running jobs may take to finish on shutdown
+// before they are cancelled; cancelled jobs are retried later
+const stopTimeout = 30 * time.Second
+
+// Client enqueues jobs and, when it is a worker, works them
+type Client struct {
+	river  *river.Client[pgx.Tx]
+	worker bool
+}
+
+// NewClient builds a job queue client on pool. A worker client fetches jobs
+// for the given workers and schedules the periodic jobs; any other client
+// only enqueues jobs.
+func NewClient(ctx context.Context, pool *pgxpool.Pool, workers *river.Workers, periodic []*river.PeriodicJob, cfg config.JobsConfig, worker bool) (*Client, error) {
+	riverConfig := &river.Config{
+		Logger:       logging.FromContext(ctx),
+		Workers:      workers,
+		ErrorHandler: errorHandler{},
+	}
+	if worker {
+		riverConfig.Queues = map[string]river.QueueConfig{
+			river.QueueDefault: {MaxWorkers: cfg.Concurrency},
+		}
+		riverConfig.PeriodicJobs = periodic
+	}
+
+	client, err := river.NewClient(riverpgxv5.New(pool), riverConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{river: client, worker: worker}, nil
+}
+
+// Enqueue adds jobs to the queue. Jobs whose kind is unique by arguments are
+// skipped when an identical one is already queued or recently completed.
+func (c *Client) Enqueue(ctx context.Context, args ...river.JobArgs) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	params := make([]river.InsertManyParams, len(args))
+	for i, arg := range args {
+		params[i] = river.InsertManyParams{Args: arg}
+	}
+	_, err := c.river.InsertMany(ctx, params)
+	return err
+}
+
+// Run works jobs until ctx is cancelled, then lets running jobs finish. It
+// returns immediately for clients that only enqueue.
+func (c *Client) Run(ctx context.Context) error {
+	if !c.worker {
+		return nil
+	}
+
+	events, unsubscribe := c.river.Subscribe(river.EventKindJobCompleted, river.EventKindJobFailed, river.EventKindJobCancelled)
+	defer unsubscribe()
+
+	// Running jobs keep the logger in ctx but are not cancelled with it
+	if err := c.river.Start(context.WithoutCancel(ctx)); err != nil {
+		return err
+	}
+
+	logger := logging.FromContext(ctx)
+	for {
+		select {
+		case event := <-events:
+			observe(logger, event)
+		case <-ctx.Done():
+			stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stopTimeout)
+			defer cancel()
+			if err := c.river.Stop(stopCtx); err != nil {
+				logger.Warn("Jobs did not finish in time, cancelling them", "error", err)
+				return c.river.StopAndCancel(context.WithoutCancel(ctx))
+			}
+			return nil
+		}
+	}
+}
+
+func (c *Client) ListDeadJobs(ctx context.Context, limit int) ([]domain.Job, error) {
+	params := river.NewJobListParams().
+		States(rivertype.JobStateDiscarded).
+		OrderBy(river.JobListOrderByTime, river.SortOrderDesc).
+		First(limit)
+
+	result, err := c.river.JobList(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, len(result.Jobs))
+	for i, row := range result.Jobs {
+		jobs[i] = toJob(row)
+	}
+	return jobs, nil
+}
+
+func (c *Client) RetryJob(ctx context.Context, id int64) (*domain.Job, error) {
+	row, err := c.river.JobRetry(ctx, id)
+	if errors.Is(err, river.ErrNotFound) {
+		return nil, domain.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	job := toJob(row)
+	return &job, nil
+}
+
+func toJob(row *rivertype.JobRow) domain.Job {
+	job := domain.Job{
+		ID:          row.ID,
+		Kind:        row.Kind,
+		State:       string(row.State),
+		Attempt:     row.Attempt,
+		MaxAttempts: row.MaxAttempts,
+		Args:        row.EncodedArgs,
+		CreatedAt:   row.CreatedAt,
+		FinalizedAt: row.FinalizedAt,
+	}
+	for _, attemptErr := range row.Errors {
+		job.Errors = append(job.Errors, attemptErr.Error)
+	}
+	return job
+}
+
+// observe records the outcome of a job run
+func observe(logger *slog.Logger, event *river.Event) {
+	outcome := string(event.Job.State)
+	if event.Kind == river.EventKindJobFailed && event.Job.State != rivertype.JobStateDiscarded {
+		outcome = "retried"
+	}
+	metrics.JobsFinished.WithLabelValues(event.Job.Kind, outcome).Inc()
+	if event.JobStats != nil {
+		metrics.JobDuration.WithLabelValues(event.Job.Kind).Observe(event.JobStats.RunDuration.Seconds())
+	}
+
+	if event.Job.State == rivertype.JobStateDiscarded {
+		logger.Warn("Job discarded after its last attempt", "job_id", event.Job.ID, "kind", event.Job.Kind, "attempts", event.Job.Attempt)
+	}
+}
+
+// errorHandler logs failed attempts; retrying is left to the job's schedule
+type errorHandler struct{}
+
+func (errorHandler) HandleError(ctx context.Context, job *rivertype.JobRow, err error) *river.ErrorHandlerResult {
+	logging.FromContext(ctx).Error("Job attempt failed", "job_id", job.ID, "kind", job.Kind, "attempt", job.Attempt, "error", err)
+	return nil
+}
+
+func (errorHandler) HandlePanic(ctx context.Context, job *rivertype.JobRow, panicVal any, trace string) *river.ErrorHandlerResult {
+	logging.FromContext(ctx).Error("Job attempt panicked", "job_id", job.ID, "kind", job.Kind, "attempt", job.Attempt, "panic", panicVal, "trace", trace)
+	return nil
+}
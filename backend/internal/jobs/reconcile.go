@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/riverqueue/river"
+)
+
+const (
+	reconcileInterval = 15 * time.Minute
+	// reconcileAfter leaves the payment webhook time to arrive, and a refund
+	// request time to finish, before the provider is asked
+	reconcileAfter = time.Hour
+	reconcileBatch = 100
+)
+
+// ReconcilePaymentsWorker asks the payment provider about purchases and
+// purchase refunds left pending
+type ReconcilePaymentsWorker struct {
+	river.WorkerDefaults[ReconcilePaymentsArgs]
+	payments domain.PaymentService
+	refunds  domain.RefundService
+}
+
+func NewReconcilePaymentsWorker(payments domain.PaymentService, refunds domain.RefundService) *ReconcilePaymentsWorker {
+	return &ReconcilePaymentsWorker{payments: payments, refunds: refunds}
+}
+
+func (w *ReconcilePaymentsWorker) Work(ctx context.Context, _ *river.Job[ReconcilePaymentsArgs]) error {
+	before := time.Now().Add(-reconcileAfter)
+	settled, err := w.payments.ReconcilePurchases(ctx, before, reconcileBatch)
+	if settled > 0 {
+		logging.FromContext(ctx).Info("Reconciled pending purchases", "count", settled)
+	}
+	if err != nil {
+		return err
+	}
+
+	settled, err = w.refunds.ReconcileRefunds(ctx, before, reconcileBatch)
+	if settled > 0 {
+		logging.FromContext(ctx).Info("Reconciled pending refunds", "count", settled)
+	}
+	return err
+}
+
+// ReconcilePaymentsSchedule runs the reconciliation periodically on whichever worker leads the queue
+func ReconcilePaymentsSchedule() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(reconcileInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return ReconcilePaymentsArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// ReconcilePayoutsWorker asks the payout provider about payouts left processing
+type ReconcilePayoutsWorker struct {
+	river.WorkerDefaults[ReconcilePayoutsArgs]
+	payouts domain.PayoutService
+}
+
+func NewReconcilePayoutsWorker(payouts domain.PayoutService) *ReconcilePayoutsWorker {
+	return &ReconcilePayoutsWorker{payouts: payouts}
+}
+
+func (w *ReconcilePayoutsWorker) Work(ctx context.Context, _ *river.Job[ReconcilePayoutsArgs]) error {
+	settled, err := w.payouts.ReconcilePayouts(ctx, time.Now().Add(-reconcileAfter), reconcileBatch)
+	if settled > 0 {
+		logging.FromContext(ctx).Info("Reconciled processing payouts", "count", settled)
+	}
+	return err
+}
+
+// ReconcilePayoutsSchedule runs the payout reconciliation alongside the payment one
+func ReconcilePayoutsSchedule() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(reconcileInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return ReconcilePayoutsArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
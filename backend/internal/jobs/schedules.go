@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/riverqueue/river"
+)
+
+const (
+	scheduleInterval = time.Minute
+	scheduleBatch    = 100
+)
+
+// RunGiftSchedulesWorker sends the gifts of the schedules that are due
+type RunGiftSchedulesWorker struct {
+	river.WorkerDefaults[RunGiftSchedulesArgs]
+	schedules domain.GiftScheduleService
+}
+
+func NewRunGiftSchedulesWorker(schedules domain.GiftScheduleService) *RunGiftSchedulesWorker {
+	return &RunGiftSchedulesWorker{schedules: schedules}
+}
+
+// Work runs due schedules in batches until none are left, so a backlog built
+// up while the workers were down is cleared in one go
+func (w *RunGiftSchedulesWorker) Work(ctx context.Context, _ *river.Job[RunGiftSchedulesArgs]) error {
+	now := time.Now()
+	for {
+		ran, err := w.schedules.RunDueSchedules(ctx, now, scheduleBatch)
+		if ran > 0 {
+			logging.FromContext(ctx).Info("Ran gift schedules", "count", ran)
+		}
+		if err != nil || ran < scheduleBatch {
+			return err
+		}
+	}
+}
+
+// RunGiftSchedulesSchedule checks for due schedules every minute, and on
+// start to catch up after downtime
+func RunGiftSchedulesSchedule() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(scheduleInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return RunGiftSchedulesArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"tokentide/internal/domain"
+
+	"github.com/riverqueue/river"
+)
+
+const statsInterval = 5 * time.Minute
+
+// RefreshGiftStatsWorker recomputes the recent gift aggregates from the ledger
+type RefreshGiftStatsWorker struct {
+	river.WorkerDefaults[RefreshGiftStatsArgs]
+	analytics domain.AnalyticsService
+}
+
+func NewRefreshGiftStatsWorker(analytics domain.AnalyticsService) *RefreshGiftStatsWorker {
+	return &RefreshGiftStatsWorker{analytics: analytics}
+}
+
+func (w *RefreshGiftStatsWorker) Work(ctx context.Context, _ *river.Job[RefreshGiftStatsArgs]) error {
+	return w.analytics.RefreshStats(ctx)
+}
+
+// RefreshGiftStatsSchedule refreshes the aggregates every five minutes
+func RefreshGiftStatsSchedule() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(statsInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return RefreshGiftStatsArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
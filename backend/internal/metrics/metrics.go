@@ -0,0 +1,130 @@
+// Package metrics holds the Prometheus collectors exposed on /metrics
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Registry holds every collector of the service, plus Go runtime and process metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency, by operation and table.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Failed database queries, by operation and table.",
+	}, []string{"operation", "table"})
+
+	GiftsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "gifts_created_total",
+		Help: "Gifts added to the catalog.",
+	})
+
+	GiftsSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "gifts_sent_total",
+		Help: "Gifts paid for from a wallet.",
+	})
+
+	TokensTransferred = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tokens_transferred_total",
+		Help: "Tokens moved between wallets by gift sends.",
+	})
+
+	TokensPurchased = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tokens_purchased_total",
+		Help: "Tokens credited by completed purchases.",
+	})
+
+	Refunds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "refunds_total",
+		Help: "Refunds, by kind and outcome: succeeded or failed.",
+	}, []string{"kind", "outcome"})
+
+	Payouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payouts_total",
+		Help: "Payouts, by the state they reached: requested, paid, failed or rejected.",
+	}, []string{"status"})
+
+	RealtimeConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "realtime_connections",
+		Help: "Open WebSocket connections.",
+	})
+
+	OutboxEventsPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_events_published_total",
+		Help: "Domain events relayed from the outbox to the broker.",
+	})
+
+	OutboxRelayErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_relay_errors_total",
+		Help: "Failed attempts to relay a batch of outbox events.",
+	})
+
+	WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "webhook_delivery_attempts_total",
+		Help: "Webhook delivery attempts, by resulting delivery status.",
+	}, []string{"status"})
+
+	EmailsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "emails_total",
+		Help: "Notification emails, by notification and outcome: sent, failed or opted_out.",
+	}, []string{"notification", "outcome"})
+
+	JobsFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobs_finished_total",
+		Help: "Background job runs, by kind and outcome: completed, retried, discarded or cancelled.",
+	}, []string{"kind", "outcome"})
+
+	JobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "job_duration_seconds",
+		Help:    "Background job run time, by kind.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"kind"})
+
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Requests rejected by a rate limit, by limit.",
+	}, []string{"limit"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		DBQueryDuration,
+		DBQueryErrors,
+		GiftsCreated,
+		GiftsSent,
+		TokensTransferred,
+		TokensPurchased,
+		Refunds,
+		Payouts,
+		RateLimited,
+		RealtimeConnections,
+		OutboxEventsPublished,
+		OutboxRelayErrors,
+		WebhookDeliveries,
+		EmailsSent,
+		JobsFinished,
+		JobDuration,
+	)
+}
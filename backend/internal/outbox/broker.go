@@ -0,0 +1,163 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/pkg/config"
+	"tokentide/pkg/logging"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/segmentio/kafka-go"
+)
+
+// NewBroker connects to the broker selected in cfg. Without one, events are
+// only logged, so the outbox is still drained.
+func NewBroker(ctx context.Context, cfg config.OutboxConfig) (domain.EventBroker, error) {
+	switch cfg.Broker {
+	case config.BrokerNATS:
+		return NewNATS(ctx, cfg.BrokerURL, cfg.SubjectPrefix)
+	case config.BrokerKafka:
+		return NewKafka(strings.Split(cfg.BrokerURL, ","), cfg.SubjectPrefix), nil
+	default:
+		return LogBroker{}, nil
+	}
+}
+
+// Fanout publishes to every broker in turn; a batch fails if any of them fails
+type Fanout []domain.EventBroker
+
+func (f Fanout) Publish(ctx context.Context, events []domain.Event) error {
+	for _, broker := range f {
+		if err := broker.Publish(ctx, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Fanout) Close() error {
+	var errs []error
+	for _, broker := range f {
+		errs = append(errs, broker.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// subject names the NATS subject or Kafka topic of an event, e.g. tokentide.gift.created
+func subject(prefix string, event domain.Event) string {
+	return prefix + "." + event.Type
+}
+
+// NATS publishes events to a JetStream stream, using the event ID for deduplication
+type NATS struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+// NewNATS connects to the server at url and makes sure a stream captures
+// every subject under prefix
+func NewNATS(ctx context.Context, url, prefix string) (*NATS, error) {
+	conn, err := nats.Connect(url, nats.Name("tokentide"))
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	stream := strings.ToUpper(strings.ReplaceAll(prefix, ".", "_"))
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       stream,
+		Subjects:   []string{prefix + ".>"},
+		Duplicates: 10 * time.Minute,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("create nats stream %s: %w", stream, err)
+	}
+	return &NATS{conn: conn, js: js, prefix: prefix}, nil
+}
+
+func (n *NATS) Publish(ctx context.Context, events []domain.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := n.js.Publish(ctx, subject(n.prefix, event), payload, jetstream.WithMsgID(event.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *NATS) Close() error {
+	return n.conn.Drain()
+}
+
+// Kafka publishes events to one topic per event type, keyed by aggregate so
+// the events of an aggregate stay in order
+type Kafka struct {
+	writer *kafka.Writer
+	prefix string
+}
+
+func NewKafka(brokers []string, prefix string) *Kafka {
+	return &Kafka{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		prefix: prefix,
+	}
+}
+
+func (k *Kafka) Publish(ctx context.Context, events []domain.Event) error {
+	messages := make([]kafka.Message, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages[i] = kafka.Message{
+			Topic: subject(k.prefix, event),
+			Key:   []byte(event.AggregateID),
+			Value: payload,
+			Headers: []kafka.Header{
+				{Key: "event_id", Value: []byte(event.ID)},
+				{Key: "event_type", Value: []byte(event.Type)},
+			},
+		}
+	}
+	return k.writer.WriteMessages(ctx, messages...)
+}
+
+func (k *Kafka) Close() error {
+	return k.writer.Close()
+}
+
+// LogBroker logs events at debug level instead of publishing them
+type LogBroker struct{}
+
+func (LogBroker) Publish(ctx context.Context, events []domain.Event) error {
+	logger := logging.FromContext(ctx)
+	for _, event := range events {
+		logger.Debug("Outbox event", "event_id", event.ID, "type", event.Type, "aggregate_id", event.AggregateID)
+	}
+	return nil
+}
+
+func (LogBroker) Close() error {
+	return nil
+}
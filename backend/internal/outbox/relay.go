@@ -0,0 +1,70 @@
+// Package outbox relays the events recorded in the outbox table to the
+// message broker
+package outbox
+
+import (
+	"context"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/pkg/logging"
+)
+
+const (
+	pollInterval = time.Second
+	batchSize    = 100
+	// retention is how long published events are kept for inspection
+	retention = 7 * 24 * time.Hour
+)
+
+// Relay polls the outbox and publishes pending events in the order they occurred
+type Relay struct {
+	repo   domain.OutboxRepository
+	broker domain.EventBroker
+}
+
+func NewRelay(repo domain.OutboxRepository, broker domain.EventBroker) *Relay {
+	return &Relay{repo: repo, broker: broker}
+}
+
+// Run relays events until ctx is cancelled. Events that fail to publish stay
+// in the outbox and are retried on the next poll.
+func (r *Relay) Run(ctx context.Context) {
+	logger := logging.FromContext(ctx)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	purged := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Drain the backlog before waiting for the next tick
+		for {
+			n, err := r.repo.Relay(ctx, batchSize, r.broker.Publish)
+			if err != nil {
+				if ctx.Err() == nil {
+					metrics.OutboxRelayErrors.Inc()
+					logger.Error("Could not relay outbox events", "error", err)
+				}
+				break
+			}
+			metrics.OutboxEventsPublished.Add(float64(n))
+			if n < batchSize {
+				break
+			}
+		}
+
+		if time.Since(purged) >= time.Hour {
+			if n, err := r.repo.Purge(ctx, time.Now().Add(-retention)); err != nil {
+				logger.Error("Could not purge published outbox events", "error", err)
+			} else if n > 0 {
+				logger.Info("Purged published outbox events", "count", n)
+			}
+			purged = time.Now()
+		}
+	}
+}
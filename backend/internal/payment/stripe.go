@@ -0,0 +1,190 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"tokentide/internal/domain"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/client"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+// StripeProvider takes payments through Stripe Checkout
+type StripeProvider struct {
+	api           *client.API
+	webhookSecret string
+	successURL    string
+	cancelURL     string
+}
+
+func NewStripeProvider(secretKey, webhookSecret, successURL, cancelURL string) domain.PaymentProvider {
+	api := &client.API{}
+	api.Init(secretKey, nil)
+
+	return &StripeProvider{
+		api:           api,
+		webhookSecret: webhookSecret,
+		successURL:    successURL,
+		cancelURL:     cancelURL,
+	}
+}
+
+func (p *StripeProvider) Name() string {
+	return "stripe"
+}
+
+func (p *StripeProvider) CreateCheckout(ctx context.Context, purchase domain.Purchase, pkg domain.TokenPackage) (*domain.CheckoutSession, error) {
+	params := &stripe.CheckoutSessionParams{
+		Mode:              stripe.String(string(stripe.CheckoutSessionModePayment)),
+		ClientReferenceID: stripe.String(purchase.ID),
+		SuccessURL:        stripe.String(p.successURL),
+		CancelURL:         stripe.String(p.cancelURL),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{{
+			Quantity: stripe.Int64(1),
+			PriceData: &stripe.CheckoutSessionLineItemPriceDataParams{
+				Currency:   stripe.String(strings.ToLower(purchase.Currency)),
+				UnitAmount: stripe.Int64(purchase.AmountMinor),
+				ProductData: &stripe.CheckoutSessionLineItemPriceDataProductDataParams{
+					Name: stripe.String(pkg.Name),
+				},
+			},
+		}},
+	}
+	params.Context = ctx
+	params.AddMetadata("purchase_id", purchase.ID)
+	params.SetIdempotencyKey("purchase-" + purchase.ID)
+
+	session, err := p.api.CheckoutSessions.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("create stripe checkout session: %w", err)
+	}
+
+	return &domain.CheckoutSession{ProviderRef: session.ID, URL: session.URL}, nil
+}
+
+func (p *StripeProvider) ParseWebhook(payload []byte, signature string) (*domain.PaymentEvent, error) {
+	event, err := webhook.ConstructEventWithOptions(payload, signature, p.webhookSecret, webhook.ConstructEventOptions{
+		IgnoreAPIVersionMismatch: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhook, err)
+	}
+
+	var status string
+	switch event.Type {
+	case stripe.EventTypeCheckoutSessionCompleted, stripe.EventTypeCheckoutSessionAsyncPaymentSucceeded:
+		status = domain.PurchaseSucceeded
+	case stripe.EventTypeCheckoutSessionAsyncPaymentFailed, stripe.EventTypeCheckoutSessionExpired:
+		status = domain.PurchaseFailed
+	default:
+		return nil, nil
+	}
+
+	var session stripe.CheckoutSession
+	if err := json.Unmarshal(event.Data.Raw, &session); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhook, err)
+	}
+
+	// Delayed payment methods complete the session before the money arrives
+	if event.Type == stripe.EventTypeCheckoutSessionCompleted && session.PaymentStatus != stripe.CheckoutSessionPaymentStatusPaid {
+		return nil, nil
+	}
+
+	return &domain.PaymentEvent{PurchaseID: session.ClientReferenceID, Status: status}, nil
+}
+
+func (p *StripeProvider) GetPaymentStatus(ctx context.Context, purchase domain.Purchase) (*domain.PaymentEvent, error) {
+	params := &stripe.CheckoutSessionParams{}
+	params.Context = ctx
+
+	session, err := p.api.CheckoutSessions.Get(purchase.ProviderRef, params)
+	if err != nil {
+		return nil, fmt.Errorf("get stripe checkout session: %w", err)
+	}
+
+	switch {
+	case session.PaymentStatus == stripe.CheckoutSessionPaymentStatusPaid:
+		return &domain.PaymentEvent{PurchaseID: purchase.ID, Status: domain.PurchaseSucceeded}, nil
+	case session.Status == stripe.CheckoutSessionStatusExpired:
+		return &domain.PaymentEvent{PurchaseID: purchase.ID, Status: domain.PurchaseFailed}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (p *StripeProvider) Refund(ctx context.Context, purchase domain.Purchase, refundID string) (string, error) {
+	sessionParams := &stripe.CheckoutSessionParams{}
+	sessionParams.Context = ctx
+
+	session, err := p.api.CheckoutSessions.Get(purchase.ProviderRef, sessionParams)
+	if err != nil {
+		return "", fmt.Errorf("get stripe checkout session: %w", stripeError(err))
+	}
+	if session.PaymentIntent == nil {
+		return "", fmt.Errorf("%w: stripe checkout session %s has no payment intent", domain.ErrPaymentRejected, session.ID)
+	}
+
+	// Idempotency keys expire after a day, so a retry first looks for the
+	// refund an earlier attempt created
+	refund, err := p.findRefund(ctx, session.PaymentIntent.ID, refundID)
+	if err != nil {
+		return "", err
+	}
+	if refund == nil {
+		params := &stripe.RefundParams{PaymentIntent: stripe.String(session.PaymentIntent.ID)}
+		params.Context = ctx
+		params.AddMetadata("purchase_id", purchase.ID)
+		params.AddMetadata("refund_id", refundID)
+		params.SetIdempotencyKey("refund-" + refundID)
+
+		if refund, err = p.api.Refunds.New(params); err != nil {
+			return "", fmt.Errorf("create stripe refund: %w", stripeError(err))
+		}
+	}
+	if refund.Status == stripe.RefundStatusFailed || refund.Status == stripe.RefundStatusCanceled {
+		return "", fmt.Errorf("%w: stripe refund %s is %s", domain.ErrPaymentRejected, refund.ID, refund.Status)
+	}
+	return refund.ID, nil
+}
+
+// findRefund returns the refund of the payment intent created for refundID,
+// or nil when there is none
+func (p *StripeProvider) findRefund(ctx context.Context, paymentIntentID, refundID string) (*stripe.Refund, error) {
+	params := &stripe.RefundListParams{PaymentIntent: stripe.String(paymentIntentID)}
+	params.Context = ctx
+
+	refunds := p.api.Refunds.List(params)
+	for refunds.Next() {
+		if refund := refunds.Refund(); refund.Metadata["refund_id"] == refundID {
+			return refund, nil
+		}
+	}
+	if err := refunds.Err(); err != nil {
+		return nil, fmt.Errorf("list stripe refunds: %w", stripeError(err))
+	}
+	return nil, nil
+}
+
+// stripeError marks the errors of requests Stripe refused as
+// domain.ErrPaymentRejected. Conflicts, rate limits and server errors may
+// succeed when retried, and so does a request whose answer was lost.
+func stripeError(err error) error {
+	var stripeErr *stripe.Error
+	if !errors.As(err, &stripeErr) {
+		return err
+	}
+	switch code := stripeErr.HTTPStatusCode; {
+	case code == http.StatusConflict || code == http.StatusTooManyRequests:
+		return err
+	case code >= 400 && code < 500:
+		return fmt.Errorf("%w: %v", domain.ErrPaymentRejected, err)
+	default:
+		return err
+	}
+}
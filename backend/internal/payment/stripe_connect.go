@@ -0,0 +1,123 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tokentide/internal/domain"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/client"
+)
+
+// StripeConnect pays artists through Stripe Connect Express accounts, which
+// Stripe onboards and pays out to the artist's bank
+type StripeConnect struct {
+	api        *client.API
+	returnURL  string
+	refreshURL string
+}
+
+// NewStripeConnect returns a payout provider; artists land on returnURL after
+// onboarding, and on refreshURL when their link expired
+func NewStripeConnect(secretKey, returnURL, refreshURL string) domain.PayoutProvider {
+	api := &client.API{}
+	api.Init(secretKey, nil)
+
+	return &StripeConnect{api: api, returnURL: returnURL, refreshURL: refreshURL}
+}
+
+func (p *StripeConnect) Name() string {
+	return "stripe"
+}
+
+func (p *StripeConnect) CreateAccount(ctx context.Context, artist domain.Artist) (string, error) {
+	params := &stripe.AccountParams{
+		Type:  stripe.String(string(stripe.AccountTypeExpress)),
+		Email: stripe.String(artist.Email),
+		Capabilities: &stripe.AccountCapabilitiesParams{
+			Transfers: &stripe.AccountCapabilitiesTransfersParams{Requested: stripe.Bool(true)},
+		},
+	}
+	params.Context = ctx
+	params.AddMetadata("artist_id", artist.ID)
+	params.SetIdempotencyKey("account-" + artist.ID)
+
+	account, err := p.api.Accounts.New(params)
+	if err != nil {
+		return "", fmt.Errorf("create stripe account: %w", err)
+	}
+	return account.ID, nil
+}
+
+func (p *StripeConnect) OnboardingURL(ctx context.Context, accountID string) (string, error) {
+	params := &stripe.AccountLinkParams{
+		Account:    stripe.String(accountID),
+		Type:       stripe.String(string(stripe.AccountLinkTypeAccountOnboarding)),
+		ReturnURL:  stripe.String(p.returnURL),
+		RefreshURL: stripe.String(p.refreshURL),
+	}
+	params.Context = ctx
+
+	link, err := p.api.AccountLinks.New(params)
+	if err != nil {
+		return "", fmt.Errorf("create stripe account link: %w", err)
+	}
+	return link.URL, nil
+}
+
+func (p *StripeConnect) AccountReady(ctx context.Context, accountID string) (bool, error) {
+	params := &stripe.AccountParams{}
+	params.Context = ctx
+
+	account, err := p.api.Accounts.GetByID(accountID, params)
+	if err != nil {
+		return false, fmt.Errorf("get stripe account: %w", err)
+	}
+	return account.PayoutsEnabled, nil
+}
+
+func (p *StripeConnect) Transfer(ctx context.Context, payout domain.Payout, accountID string) (string, error) {
+	// Idempotency keys expire after a day, so a retry first looks for the
+	// transfer an earlier attempt made
+	group := "payout-" + payout.ID
+	transfer, err := p.findTransfer(ctx, group)
+	if err != nil {
+		return "", err
+	}
+	if transfer != nil {
+		return transfer.ID, nil
+	}
+
+	params := &stripe.TransferParams{
+		Amount:        stripe.Int64(payout.AmountMinor),
+		Currency:      stripe.String(strings.ToLower(payout.Currency)),
+		Destination:   stripe.String(accountID),
+		TransferGroup: stripe.String(group),
+	}
+	params.Context = ctx
+	params.AddMetadata("payout_id", payout.ID)
+	params.SetIdempotencyKey(group)
+
+	transfer, err = p.api.Transfers.New(params)
+	if err != nil {
+		return "", fmt.Errorf("create stripe transfer: %w", stripeError(err))
+	}
+	return transfer.ID, nil
+}
+
+// findTransfer returns the transfer made in group, or nil when there is none
+func (p *StripeConnect) findTransfer(ctx context.Context, group string) (*stripe.Transfer, error) {
+	params := &stripe.TransferListParams{TransferGroup: stripe.String(group)}
+	params.Context = ctx
+
+	transfers := p.api.Transfers.List(params)
+	if transfers.Next() {
+		return transfers.Transfer(), nil
+	}
+	if err := transfers.Err(); err != nil {
+		return nil, fmt.Errorf("list stripe transfers: %w", stripeError(err))
+	}
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+// Package ratelimit implements token bucket rate limits, kept in memory for a
+// single instance or in Redis when several instances share the limits
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"tokentide/pkg/config"
+)
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next token is available when not allowed
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token from the bucket identified by key
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Memory is a Limiter holding its buckets in process memory
+type Memory struct {
+	rate config.Rate
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+func NewMemory(rate config.Rate) *Memory {
+	return &Memory{rate: rate, buckets: make(map[string]*bucket), swept: time.Now()}
+}
+
+func (m *Memory) Allow(_ context.Context, key string) (Result, error) {
+	now := time.Now()
+	interval := m.rate.Per / time.Duration(m.rate.Requests)
+	capacity := float64(m.rate.Requests)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.updated))/float64(interval))
+	b.updated = now
+	if b.tokens < 1 {
+		return Result{RetryAfter: time.Duration((1 - b.tokens) * float64(interval))}, nil
+	}
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep drops buckets idle long enough to have refilled, at most once per period
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.swept) < m.rate.Per {
+		return
+	}
+	for key, b := range m.buckets {
+		if now.Sub(b.updated) >= m.rate.Per {
+			delete(m.buckets, key)
+		}
+	}
+	m.swept = now
+}
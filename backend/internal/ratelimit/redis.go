@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+	"tokentide/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "tokentide:ratelimit:"
+
+// takeToken refills and takes from a bucket atomically, using the Redis clock
+// so instances with drifting clocks agree. It returns whether the token was
+// taken, the tokens left and the microseconds until the next one.
+var takeToken = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + (now - updated) / interval)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * interval)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * interval / 1000))
+return {allowed, math.floor(tokens), retry}
+`)
+
+// Redis is a Limiter holding its buckets in Redis, shared by every instance
+type Redis struct {
+	client *redis.Client
+	rate   config.Rate
+}
+
+func NewRedis(client *redis.Client, rate config.Rate) *Redis {
+	return &Redis{client: client, rate: rate}
+}
+
+func (r *Redis) Allow(ctx context.Context, key string) (Result, error) {
+	interval := r.rate.Per.Microseconds() / int64(r.rate.Requests)
+	values, err := takeToken.Run(ctx, r.client, []string{keyPrefix + key}, r.rate.Requests, interval).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Package realtime fans business events out to connected clients
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+)
+
+// subscriberBuffer is how many messages a slow client may fall behind before
+// new ones are dropped for it
+const subscriberBuffer = 16
+
+// Message is the envelope of everything sent to a client
+type Message struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// Hub keeps one channel per artist and broadcasts events to every client
+// subscribed to it. It only reaches clients connected to this instance.
+type Hub struct {
+	mu       sync.Mutex
+	channels map[string]map[chan []byte]struct{}
+	closed   bool
+}
+
+func NewHub() *Hub {
+	return &Hub{channels: make(map[string]map[chan []byte]struct{})}
+}
+
+// Subscribe joins the channel of artistID. The returned channel is closed
+// once unsubscribe is called or the hub is closed.
+func (h *Hub) Subscribe(artistID string) (<-chan []byte, func()) {
+	messages := make(chan []byte, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(messages)
+		return messages, func() {}
+	}
+	if h.channels[artistID] == nil {
+		h.channels[artistID] = make(map[chan []byte]struct{})
+	}
+	h.channels[artistID][messages] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.channels[artistID][messages]; !ok {
+			return
+		}
+		delete(h.channels[artistID], messages)
+		if len(h.channels[artistID]) == 0 {
+			delete(h.channels, artistID)
+		}
+		close(messages)
+	}
+	return messages, unsubscribe
+}
+
+// PublishGiftSent notifies the receiving artist's channel
+func (h *Hub) PublishGiftSent(ctx context.Context, event domain.GiftSentEvent) {
+	h.broadcast(ctx, event.ArtistID, Message{Type: "gift.sent", Data: event})
+}
+
+func (h *Hub) broadcast(ctx context.Context, artistID string, msg Message) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		logging.FromContext(ctx).Error("Could not encode realtime message", "type", msg.Type, "error", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for messages := range h.channels[artistID] {
+		select {
+		case messages <- payload:
+		default:
+			logging.FromContext(ctx).Warn("Realtime client too slow, message dropped", "artist_id", artistID, "type", msg.Type)
+		}
+	}
+}
+
+// Close disconnects every subscriber
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for artistID, subscribers := range h.channels {
+		for messages := range subscribers {
+			close(messages)
+		}
+		delete(h.channels, artistID)
+	}
+	h.closed = true
+}
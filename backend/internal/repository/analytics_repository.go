@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepositoryImpl keeps the artist_gift_stats aggregates of the ledger
+type AnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) domain.AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{db: db}
+}
+
+// aggregateGifts sums the ledger entries of artists' wallets by sender and
+// UTC day: a gift send credits the artist, and its refund debits them with
+// the refund_id set. Both entries name the sender's wallet as counterparty.
+const aggregateGifts = `INSERT INTO artist_gift_stats (artist_id, sender_id, day, gifts, tokens)
+	SELECT artist.owner_id, sender.owner_id, (t.created_at AT TIME ZONE 'UTC')::date,
+		SUM(CASE WHEN t.type = 'credit' THEN 1 ELSE -1 END),
+		SUM(CASE WHEN t.type = 'credit' THEN t.amount ELSE -t.amount END)
+	FROM transactions t
+	JOIN wallets artist ON artist.id = t.wallet_id
+	JOIN wallets sender ON sender.id = t.counterparty_wallet_id
+	WHERE COALESCE(t.gift_id, '') <> '' AND t.created_at >= @since
+		AND ((t.type = 'credit' AND COALESCE(t.refund_id, '') = '') OR (t.type = 'debit' AND COALESCE(t.refund_id, '') <> ''))
+	GROUP BY 1, 2, 3`
+
+func (r *AnalyticsRepositoryImpl) RefreshStats(ctx context.Context, since time.Time) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day >= ?", since).Delete(&domain.GiftStats{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(aggregateGifts, map[string]any{"since": since}).Error
+	})
+}
+
+func (r *AnalyticsRepositoryImpl) HasStats(ctx context.Context) (bool, error) {
+	var rows []domain.GiftStats
+	err := conn(ctx, r.db).Limit(1).Find(&rows).Error
+	return len(rows) > 0, err
+}
+
+func (r *AnalyticsRepositoryImpl) TopGifters(ctx context.Context, artistID string, since time.Time, limit int) ([]domain.Gifter, error) {
+	gifters := []domain.Gifter{}
+	err := read(ctx, r.db).
+		Table("artist_gift_stats s").
+		Select("s.sender_id, a.name, SUM(s.gifts) AS gifts, SUM(s.tokens) AS tokens").
+		Joins("JOIN artists a ON a.id = s.sender_id AND a.deleted_at IS NULL").
+		Where("s.artist_id = ? AND s.day >= ?", artistID, since).
+		Group("s.sender_id, a.name").
+		Having("SUM(s.tokens) > 0").
+		Order("tokens DESC, gifts DESC, s.sender_id").
+		Limit(limit).
+		Scan(&gifters).Error
+	return gifters, err
+}
+
+func (r *AnalyticsRepositoryImpl) DailyStats(ctx context.Context, artistID string, since time.Time) ([]domain.DailyStats, error) {
+	var days []domain.DailyStats
+	err := read(ctx, r.db).
+		Model(&domain.GiftStats{}).
+		Select("day, SUM(gifts) AS gifts, SUM(tokens) AS tokens").
+		Where("artist_id = ? AND day >= ?", artistID, since).
+		Group("day").
+		Order("day").
+		Scan(&days).Error
+	return days, err
+}
+
+func (r *AnalyticsRepositoryImpl) CountGifters(ctx context.Context, artistID string, since time.Time) (int64, error) {
+	var count int64
+	err := read(ctx, r.db).
+		Model(&domain.GiftStats{}).
+		Where("artist_id = ? AND day >= ? AND gifts > 0", artistID, since).
+		Distinct("sender_id").
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) domain.APIKeyRepository {
+	return &APIKeyRepositoryImpl{db: db}
+}
+
+func (r *APIKeyRepositoryImpl) CreateAPIKey(ctx context.Context, key domain.APIKey) error {
+	return conn(ctx, r.db).Create(&key).Error
+}
+
+func (r *APIKeyRepositoryImpl) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *APIKeyRepositoryImpl) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return r.first(ctx, "key_hash = ?", hash)
+}
+
+func (r *APIKeyRepositoryImpl) first(ctx context.Context, query string, args ...any) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := conn(ctx, r.db).Where(query, args...).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) ListAPIKeys(ctx context.Context, artistID string) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+	err := conn(ctx, r.db).Where("artist_id = ?", artistID).Order("created_at DESC, id").Find(&keys).Error
+	return keys, err
+}
+
+func (r *APIKeyRepositoryImpl) RevokeAPIKey(ctx context.Context, id string, at time.Time) error {
+	result := conn(ctx, r.db).Model(&domain.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Revoking twice is harmless, but the key must exist
+		_, err := r.GetAPIKey(ctx, id)
+		return err
+	}
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	return conn(ctx, r.db).Model(&domain.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ArtistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewArtistRepository(db *gorm.DB) domain.ArtistRepository {
+	return &ArtistRepositoryImpl{db: db}
+}
+
+func (r *ArtistRepositoryImpl) CreateArtist(ctx context.Context, artist domain.Artist) error {
+	err := conn(ctx, r.db).Create(&artist).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrArtistExists
+	}
+	return err
+}
+
+func (r *ArtistRepositoryImpl) GetArtistByID(ctx context.Context, id string) (*domain.Artist, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *ArtistRepositoryImpl) GetArtistByEmail(ctx context.Context, email string) (*domain.Artist, error) {
+	return r.first(ctx, "email = ?", email)
+}
+
+func (r *ArtistRepositoryImpl) ListArtists(ctx context.Context, limit, offset int) ([]domain.Artist, int64, error) {
+	query := read(ctx, r.db).Model(&domain.Artist{})
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	artists := []domain.Artist{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&artists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return artists, total, nil
+}
+
+func (r *ArtistRepositoryImpl) UpdateRole(ctx context.Context, id string, role domain.Role) error {
+	result := conn(ctx, r.db).Model(&domain.Artist{}).Where("id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrArtistNotFound
+	}
+	return nil
+}
+
+func (r *ArtistRepositoryImpl) DeleteArtist(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&domain.Artist{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrArtistNotFound
+	}
+	return nil
+}
+
+func (r *ArtistRepositoryImpl) first(ctx context.Context, query string, args ...any) (*domain.Artist, error) {
+	var artist domain.Artist
+	err := conn(ctx, r.db).Where(query, args...).First(&artist).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrArtistNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
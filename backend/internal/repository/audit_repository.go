@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) domain.AuditRepository {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (r *AuditRepositoryImpl) RecordEntry(ctx context.Context, entry domain.AuditEntry) error {
+	return conn(ctx, r.db).Create(&entry).Error
+}
+
+func (r *AuditRepositoryImpl) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error) {
+	query := read(ctx, r.db).Model(&domain.AuditEntry{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entries := []domain.AuditEntry{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
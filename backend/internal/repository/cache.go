@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"time"
+
+	"tokentide/internal/cache"
+	"tokentide/pkg/logging"
+)
+
+// cached returns the value stored under key, loading and storing it on a miss.
+// Cache failures are logged and fall through to load, so a cache outage only
+// costs latency. Values are gob-encoded to keep fields hidden from JSON.
+// Reads inside a transaction bypass the cache, which must not hold rows that
+// may yet be rolled back.
+func cached[T any](ctx context.Context, c cache.Cache, key string, ttl time.Duration, load func() (*T, error)) (*T, error) {
+	if inTransaction(ctx) {
+		return load()
+	}
+	logger := logging.FromContext(ctx)
+
+	data, err := c.Get(ctx, key)
+	if err == nil {
+		var value T
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err == nil {
+			return &value, nil
+		}
+		logger.Warn("Could not decode cached value", "key", key, "error", err)
+	} else if !errors.Is(err, cache.ErrMiss) {
+		logger.Warn("Cache read failed", "key", key, "error", err)
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		logger.Warn("Could not encode value for cache", "key", key, "error", err)
+		return value, nil
+	}
+	if err := c.Set(ctx, key, buf.Bytes(), ttl); err != nil {
+		logger.Warn("Cache write failed", "key", key, "error", err)
+	}
+	return value, nil
+}
+
+// invalidate drops keys after a write; a failure is logged since the write
+// itself succeeded and the entry expires with its TTL anyway
+func invalidate(ctx context.Context, c cache.Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx).Error("Cache invalidation failed", "keys", keys, "error", err)
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/cache"
+	"tokentide/internal/domain"
+)
+
+// CachedArtistRepository serves GetArtistByID from the cache. Lookups by email
+// back logins and always read the database.
+type CachedArtistRepository struct {
+	domain.ArtistRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedArtistRepository(next domain.ArtistRepository, c cache.Cache, ttl time.Duration) domain.ArtistRepository {
+	return &CachedArtistRepository{ArtistRepository: next, cache: c, ttl: ttl}
+}
+
+func artistKey(id string) string {
+	return "artist:" + id
+}
+
+func (r *CachedArtistRepository) GetArtistByID(ctx context.Context, id string) (*domain.Artist, error) {
+	return cached(ctx, r.cache, artistKey(id), r.ttl, func() (*domain.Artist, error) {
+		return r.ArtistRepository.GetArtistByID(ctx, id)
+	})
+}
+
+func (r *CachedArtistRepository) UpdateRole(ctx context.Context, id string, role domain.Role) error {
+	if err := r.ArtistRepository.UpdateRole(ctx, id, role); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, artistKey(id))
+	return nil
+}
+
+func (r *CachedArtistRepository) DeleteArtist(ctx context.Context, id string) error {
+	if err := r.ArtistRepository.DeleteArtist(ctx, id); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, artistKey(id))
+	return nil
+}
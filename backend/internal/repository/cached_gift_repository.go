@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/cache"
+	"tokentide/internal/domain"
+)
+
+// CachedGiftRepository serves GetGiftByID from the cache and invalidates it on
+// writes; every other method goes straight to the wrapped repository
+type CachedGiftRepository struct {
+	domain.GiftRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedGiftRepository(next domain.GiftRepository, c cache.Cache, ttl time.Duration) domain.GiftRepository {
+	return &CachedGiftRepository{GiftRepository: next, cache: c, ttl: ttl}
+}
+
+func giftKey(id string) string {
+	return "gift:" + id
+}
+
+func (r *CachedGiftRepository) GetGiftByID(ctx context.Context, id string) (*domain.Gift, error) {
+	return cached(ctx, r.cache, giftKey(id), r.ttl, func() (*domain.Gift, error) {
+		return r.GiftRepository.GetGiftByID(ctx, id)
+	})
+}
+
+func (r *CachedGiftRepository) UpdateGift(ctx context.Context, gift domain.Gift) error {
+	if err := r.GiftRepository.UpdateGift(ctx, gift); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, giftKey(gift.ID))
+	return nil
+}
+
+func (r *CachedGiftRepository) SetGiftImage(ctx context.Context, id, key, url string) error {
+	if err := r.GiftRepository.SetGiftImage(ctx, id, key, url); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, giftKey(id))
+	return nil
+}
+
+func (r *CachedGiftRepository) DeleteGift(ctx context.Context, id string) error {
+	if err := r.GiftRepository.DeleteGift(ctx, id); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, giftKey(id))
+	return nil
+}
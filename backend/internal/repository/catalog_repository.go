@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CatalogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCatalogRepository(db *gorm.DB) domain.CatalogRepository {
+	return &CatalogRepositoryImpl{db: db}
+}
+
+func (r *CatalogRepositoryImpl) CreateCategory(ctx context.Context, category domain.Category) error {
+	err := conn(ctx, r.db).Create(&category).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrCategoryExists
+	}
+	return err
+}
+
+func (r *CatalogRepositoryImpl) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	var category domain.Category
+	err := conn(ctx, r.db).First(&category, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CatalogRepositoryImpl) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	categories := []domain.Category{}
+	err := read(ctx, r.db).Order("name, id").Find(&categories).Error
+	return categories, err
+}
+
+func (r *CatalogRepositoryImpl) UpdateCategory(ctx context.Context, category domain.Category) error {
+	result := conn(ctx, r.db).Model(&domain.Category{}).Where("id = ?", category.ID).Updates(map[string]any{
+		"name": category.Name,
+		"slug": category.Slug,
+	})
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return domain.ErrCategoryExists
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// DeleteCategory also unlinks it from every gift, through the foreign key cascade
+func (r *CatalogRepositoryImpl) DeleteCategory(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&domain.Category{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *CatalogRepositoryImpl) CreateTag(ctx context.Context, tag domain.Tag) error {
+	err := conn(ctx, r.db).Create(&tag).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrTagExists
+	}
+	return err
+}
+
+func (r *CatalogRepositoryImpl) GetTag(ctx context.Context, id string) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := conn(ctx, r.db).First(&tag, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *CatalogRepositoryImpl) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	tags := []domain.Tag{}
+	err := read(ctx, r.db).Order("name, id").Find(&tags).Error
+	return tags, err
+}
+
+func (r *CatalogRepositoryImpl) UpdateTag(ctx context.Context, tag domain.Tag) error {
+	result := conn(ctx, r.db).Model(&domain.Tag{}).Where("id = ?", tag.ID).Updates(map[string]any{
+		"name": tag.Name,
+		"slug": tag.Slug,
+	})
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return domain.ErrTagExists
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag also unlinks it from every gift, through the foreign key cascade
+func (r *CatalogRepositoryImpl) DeleteTag(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&domain.Tag{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
@@ -1,23 +1,257 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
 	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GiftRepositoryImpl struct {
-	// Define your database connection (e.g., GORM or SQL instance)
+	db *gorm.DB
+}
+
+func NewGiftRepository(db *gorm.DB) domain.GiftRepository {
+	return &GiftRepositoryImpl{db: db}
+}
+
+func (r *GiftRepositoryImpl) CreateGift(ctx context.Context, gift domain.Gift, events ...domain.Event) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&gift).Error; err != nil {
+			return err
+		}
+		if err := linkGift(tx, gift); err != nil {
+			return err
+		}
+		if err := recordPrice(tx, gift, gift.CreatedAt); err != nil {
+			return err
+		}
+		return recordEvents(tx, events)
+	})
+}
+
+func (r *GiftRepositoryImpl) GetGiftByID(ctx context.Context, id string) (*domain.Gift, error) {
+	var gift domain.Gift
+	err := preloadLinks(conn(ctx, r.db)).First(&gift, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrGiftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
 }
 
-func NewGiftRepository() domain.GiftRepository {
-	return &GiftRepositoryImpl{}
+// giftOrders maps the accepted sort values to ORDER BY clauses; id breaks ties
+// so pages are stable. Prices only compare within a currency, so gifts are
+// grouped by currency first.
+var giftOrders = map[string]string{
+	domain.GiftSortPrice:           "currency, price_minor, id",
+	"-" + domain.GiftSortPrice:     "currency, price_minor DESC, id",
+	domain.GiftSortCreatedAt:       "created_at, id",
+	"-" + domain.GiftSortCreatedAt: "created_at DESC, id",
 }
 
-func (r *GiftRepositoryImpl) CreateGift(gift domain.Gift) error {
-	// Simulate DB interaction here (e.g., inserting into the database)
+func (r *GiftRepositoryImpl) ListGifts(ctx context.Context, filter domain.GiftFilter) ([]domain.Gift, int64, error) {
+	query := read(ctx, r.db).Model(&domain.Gift{})
+	if filter.ArtistID != "" {
+		query = query.Where("artist_id = ?", filter.ArtistID)
+	}
+	if filter.CategoryID != "" {
+		query = query.Where("id IN (?)", r.db.Table("gift_categories").Select("gift_id").Where("category_id = ?", filter.CategoryID))
+	}
+	if filter.TagID != "" {
+		query = query.Where("id IN (?)", r.db.Table("gift_tags").Select("gift_id").Where("tag_id = ?", filter.TagID))
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.MinPrice != nil {
+		query = query.Where("price_minor >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where("price_minor <= ?", *filter.MaxPrice)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order, ok := giftOrders[filter.Sort]
+	if !ok {
+		order = giftOrders["-"+domain.GiftSortCreatedAt]
+	}
+
+	gifts := []domain.Gift{}
+	err := preloadLinks(query.Session(&gorm.Session{})).
+		Order(order).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&gifts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return gifts, total, nil
+}
+
+func (r *GiftRepositoryImpl) UpdateGift(ctx context.Context, gift domain.Gift) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current domain.Gift
+		err := tx.Select("price_minor", "currency", "sold", "version").First(&current, "id = ?", gift.ID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrGiftNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if current.Version != gift.Version {
+			return domain.ErrGiftVersionConflict
+		}
+		if gift.Quantity != nil && *gift.Quantity < current.Sold {
+			return fmt.Errorf("%w: quantity must not be below the %d already sold", domain.ErrInvalidGift, current.Sold)
+		}
+
+		// The update is a compare-and-swap on what was read above: the version
+		// condition keeps it from overwriting an edit committed since, and the
+		// sold condition rejects a quantity that sends, which keep selling
+		// while the gift is edited, have since gone past
+		query := tx.Model(&domain.Gift{}).Where("id = ? AND version = ?", gift.ID, gift.Version)
+		if gift.Quantity != nil {
+			query = query.Where("sold <= ?", *gift.Quantity)
+		}
+		result := query.Updates(map[string]any{
+			"name":          gift.Name,
+			"description":   gift.Description,
+			"price_minor":   gift.PriceMinor,
+			"currency":      gift.Currency,
+			"quantity":      gift.Quantity,
+			"per_fan_limit": gift.PerFanLimit,
+			"version":       gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrGiftVersionConflict
+		}
+		if gift.PriceMinor != current.PriceMinor || gift.Currency != current.Currency {
+			if err := recordPrice(tx, gift, time.Now()); err != nil {
+				return err
+			}
+		}
+		return linkGift(tx, gift)
+	})
+}
+
+func (r *GiftRepositoryImpl) SetGiftImage(ctx context.Context, id, key, url string) error {
+	result := conn(ctx, r.db).Model(&domain.Gift{}).Where("id = ?", id).Updates(map[string]any{
+		"image_key": key,
+		"image_url": url,
+		"version":   gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrGiftNotFound
+	}
+	return nil
+}
+
+func (r *GiftRepositoryImpl) DeleteGift(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Delete(&domain.Gift{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrGiftNotFound
+	}
+	return nil
+}
+
+func (r *GiftRepositoryImpl) ListPriceHistory(ctx context.Context, giftID string) ([]domain.GiftPrice, error) {
+	prices := []domain.GiftPrice{}
+	err := read(ctx, r.db).
+		Where("gift_id IN (?)", r.db.Table("gifts").Select("id").Where("id = ? AND deleted_at IS NULL", giftID)).
+		Order("effective_from, id").
+		Find(&prices).Error
+	return prices, err
+}
+
+// recordPrice adds the gift's price to its price history
+func recordPrice(tx *gorm.DB, gift domain.Gift, effectiveFrom time.Time) error {
+	return tx.Create(&domain.GiftPrice{
+		ID:            uuid.NewString(),
+		GiftID:        gift.ID,
+		PriceMinor:    gift.PriceMinor,
+		Currency:      gift.Currency,
+		EffectiveFrom: effectiveFrom,
+	}).Error
+}
+
+// preloadLinks loads the categories and tags of the gifts queried
+func preloadLinks(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Categories", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name") })
+}
+
+// linkGift replaces the categories and tags of a gift, leaving either
+// unchanged when it is nil
+func linkGift(tx *gorm.DB, gift domain.Gift) error {
+	if gift.Categories != nil {
+		ids := make([]string, len(gift.Categories))
+		for i, category := range gift.Categories {
+			ids[i] = category.ID
+		}
+		if err := replaceLinks(tx, "gift_categories", "category_id", &domain.Category{}, gift.ID, ids, domain.ErrCategoryNotFound); err != nil {
+			return err
+		}
+	}
+	if gift.Tags != nil {
+		ids := make([]string, len(gift.Tags))
+		for i, tag := range gift.Tags {
+			ids[i] = tag.ID
+		}
+		if err := replaceLinks(tx, "gift_tags", "tag_id", &domain.Tag{}, gift.ID, ids, domain.ErrTagNotFound); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *GiftRepositoryImpl) GetGiftByID(id string) (*domain.Gift, error) {
-	// Simulate DB interaction here (e.g., fetching from the database)
-	return &domain.Gift{ID: id, Name: "Gift Example", Price: 10.0}, nil
+// replaceLinks points the rows of a gift in a join table at ids, failing with
+// errNotFound unless every id exists in model's table
+func replaceLinks(tx *gorm.DB, table, column string, model any, giftID string, ids []string, errNotFound error) error {
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) > 0 {
+		var found int64
+		if err := tx.Model(model).Where("id IN ?", ids).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(ids)) {
+			return errNotFound
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM "+table+" WHERE gift_id = ?", giftID).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]map[string]any, len(ids))
+	for i, id := range ids {
+		rows[i] = map[string]any{"gift_id": giftID, column: id}
+	}
+	return tx.Table(table).Create(rows).Error
 }
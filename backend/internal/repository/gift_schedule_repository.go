@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type GiftScheduleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewGiftScheduleRepository(db *gorm.DB) domain.GiftScheduleRepository {
+	return &GiftScheduleRepositoryImpl{db: db}
+}
+
+func (r *GiftScheduleRepositoryImpl) CreateSchedule(ctx context.Context, schedule domain.GiftSchedule) error {
+	return conn(ctx, r.db).Create(&schedule).Error
+}
+
+func (r *GiftScheduleRepositoryImpl) GetSchedule(ctx context.Context, id string) (*domain.GiftSchedule, error) {
+	var schedule domain.GiftSchedule
+	err := conn(ctx, r.db).First(&schedule, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrGiftScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *GiftScheduleRepositoryImpl) ListSchedules(ctx context.Context, senderID string, limit, offset int) ([]domain.GiftSchedule, int64, error) {
+	query := conn(ctx, r.db).Model(&domain.GiftSchedule{}).Where("sender_id = ?", senderID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	schedules := []domain.GiftSchedule{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return schedules, total, nil
+}
+
+func (r *GiftScheduleRepositoryImpl) CancelSchedule(ctx context.Context, id string) error {
+	result := conn(ctx, r.db).Model(&domain.GiftSchedule{}).
+		Where("id = ? AND status = ?", id, domain.ScheduleActive).
+		Updates(map[string]any{"status": domain.ScheduleCancelled, "updated_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetSchedule(ctx, id); err != nil {
+			return err
+		}
+		return domain.ErrGiftScheduleInactive
+	}
+	return nil
+}
+
+func (r *GiftScheduleRepositoryImpl) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.GiftSchedule, error) {
+	var schedules []domain.GiftSchedule
+	err := conn(ctx, r.db).
+		Where("status = ? AND next_run_at <= ?", domain.ScheduleActive, now).
+		Order("next_run_at, id").
+		Limit(limit).
+		Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *GiftScheduleRepositoryImpl) ClaimRun(ctx context.Context, id string, due, next time.Time, status string) (bool, error) {
+	// Matching on the due time makes the claim a compare-and-swap, so each
+	// run is claimed once even with several workers
+	result := conn(ctx, r.db).Model(&domain.GiftSchedule{}).
+		Where("id = ? AND status = ? AND next_run_at = ?", id, domain.ScheduleActive, due).
+		Updates(map[string]any{"next_run_at": next, "status": status, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GiftScheduleRepositoryImpl) RecordRun(ctx context.Context, id string, ranAt time.Time, runErr string, stop bool) error {
+	updates := map[string]any{
+		"last_run_at": ranAt,
+		"last_error":  runErr,
+		"updated_at":  time.Now(),
+	}
+	if runErr == "" {
+		updates["sent_count"] = gorm.Expr("sent_count + 1")
+	}
+	if stop {
+		updates["status"] = domain.ScheduleFailed
+	}
+	return conn(ctx, r.db).Model(&domain.GiftSchedule{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) domain.IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{db: db}
+}
+
+func (r *IdempotencyRepositoryImpl) Reserve(ctx context.Context, record domain.IdempotencyRecord, expiry time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	db := conn(ctx, r.db)
+
+	// Expired keys may be reused
+	err := db.Where("key = ? AND created_at < ?", record.Key, time.Now().Add(-expiry)).
+		Delete(&domain.IdempotencyRecord{}).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return &record, true, nil
+	}
+
+	var existing domain.IdempotencyRecord
+	if err := db.First(&existing, "key = ?", record.Key).Error; err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+func (r *IdempotencyRepositoryImpl) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	return conn(ctx, r.db).Model(&domain.IdempotencyRecord{}).Where("key = ?", key).Updates(map[string]any{
+		"completed":    true,
+		"status_code":  statusCode,
+		"content_type": contentType,
+		"body":         body,
+	}).Error
+}
+
+func (r *IdempotencyRepositoryImpl) Release(ctx context.Context, key string) error {
+	return conn(ctx, r.db).Delete(&domain.IdempotencyRecord{}, "key = ?", key).Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type IncidentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIncidentRepository(db *gorm.DB) domain.IncidentRepository {
+	return &IncidentRepositoryImpl{db: db}
+}
+
+func (r *IncidentRepositoryImpl) CreateIncident(ctx context.Context, incident domain.Incident) error {
+	return conn(ctx, r.db).Create(&incident).Error
+}
+
+func (r *IncidentRepositoryImpl) GetIncidentByID(ctx context.Context, id string) (*domain.Incident, error) {
+	var incident domain.Incident
+	err := conn(ctx, r.db).Preload("Updates", orderUpdates).First(&incident, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+func (r *IncidentRepositoryImpl) UpdateIncident(ctx context.Context, incident domain.Incident, update domain.IncidentUpdate) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Incident{}).Where("id = ?", incident.ID).Updates(map[string]any{
+			"status":      incident.Status,
+			"impact":      incident.Impact,
+			"resolved_at": incident.ResolvedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		update.IncidentID = incident.ID
+		return tx.Create(&update).Error
+	})
+}
+
+func (r *IncidentRepositoryImpl) ListIncidents(ctx context.Context, resolvedSince time.Time) ([]domain.Incident, error) {
+	var incidents []domain.Incident
+	err := conn(ctx, r.db).Preload("Updates", orderUpdates).
+		Where("resolved_at IS NULL OR resolved_at > ?", resolvedSince).
+		Order("started_at DESC").
+		Find(&incidents).Error
+	return incidents, err
+}
+
+func orderUpdates(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
+}
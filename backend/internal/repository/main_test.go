@@ -0,0 +1,32 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"tokentide/internal/repository/pgtest"
+
+	"gorm.io/gorm"
+)
+
+// db is the migrated database every test in the package shares
+var db *gorm.DB
+
+func TestMain(m *testing.M) {
+	database, err := pgtest.Start(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "start test database:", err)
+		os.Exit(1)
+	}
+	db = database.DB
+
+	code := m.Run()
+	if err := database.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "stop test database:", err)
+	}
+	os.Exit(code)
+}
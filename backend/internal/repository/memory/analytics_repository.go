@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+// AnalyticsRepository keeps the gift aggregates of the store's ledger
+type AnalyticsRepository struct {
+	store *Store
+}
+
+func NewAnalyticsRepository(store *Store) domain.AnalyticsRepository {
+	return &AnalyticsRepository{store: store}
+}
+
+// RefreshStats sums the ledger entries of artists' wallets by sender and UTC
+// day: a gift send credits the artist, and its refund debits them with the
+// refund ID set. Both entries name the sender's wallet as counterparty.
+func (r *AnalyticsRepository) RefreshStats(ctx context.Context, since time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for key := range t.stats {
+			if !key.day.Before(since) {
+				delete(t.stats, key)
+			}
+		}
+
+		for _, entry := range t.transactions {
+			if entry.GiftID == "" || entry.CreatedAt.Before(since) {
+				continue
+			}
+			var gifts, tokens int64
+			switch {
+			case entry.Type == domain.TransactionCredit && entry.RefundID == "":
+				gifts, tokens = 1, entry.Amount
+			case entry.Type == domain.TransactionDebit && entry.RefundID != "":
+				gifts, tokens = -1, -entry.Amount
+			default:
+				continue
+			}
+			artist, ok := t.wallets[entry.WalletID]
+			if !ok {
+				continue
+			}
+			sender, ok := t.wallets[entry.CounterpartyWalletID]
+			if !ok {
+				continue
+			}
+
+			key := statsKey{artistID: artist.OwnerID, senderID: sender.OwnerID, day: day(entry.CreatedAt)}
+			stats := t.stats[key]
+			stats.ArtistID, stats.SenderID, stats.Day = key.artistID, key.senderID, key.day
+			stats.Gifts += gifts
+			stats.Tokens += tokens
+			t.stats[key] = stats
+		}
+		return nil
+	})
+}
+
+func (r *AnalyticsRepository) HasStats(ctx context.Context) (bool, error) {
+	has := false
+	err := r.store.read(ctx, func(t *tables) error {
+		has = len(t.stats) > 0
+		return nil
+	})
+	return has, err
+}
+
+func (r *AnalyticsRepository) TopGifters(ctx context.Context, artistID string, since time.Time, limit int) ([]domain.Gifter, error) {
+	gifters := []domain.Gifter{}
+	err := r.store.read(ctx, func(t *tables) error {
+		bySender := map[string]domain.Gifter{}
+		for _, stats := range t.artistStats(artistID, since) {
+			sender, ok := t.artists[stats.SenderID]
+			if !ok || sender.DeletedAt.Valid {
+				continue
+			}
+			gifter := bySender[stats.SenderID]
+			gifter.SenderID, gifter.Name = sender.ID, sender.Name
+			gifter.Gifts += stats.Gifts
+			gifter.Tokens += stats.Tokens
+			bySender[stats.SenderID] = gifter
+		}
+		for _, gifter := range bySender {
+			if gifter.Tokens > 0 {
+				gifters = append(gifters, gifter)
+			}
+		}
+		slices.SortFunc(gifters, func(a, b domain.Gifter) int {
+			return cmp.Or(cmp.Compare(b.Tokens, a.Tokens), cmp.Compare(b.Gifts, a.Gifts), cmp.Compare(a.SenderID, b.SenderID))
+		})
+		return nil
+	})
+	return page(gifters, limit, 0), err
+}
+
+func (r *AnalyticsRepository) DailyStats(ctx context.Context, artistID string, since time.Time) ([]domain.DailyStats, error) {
+	var days []domain.DailyStats
+	err := r.store.read(ctx, func(t *tables) error {
+		byDay := map[time.Time]domain.DailyStats{}
+		for _, stats := range t.artistStats(artistID, since) {
+			daily := byDay[stats.Day]
+			daily.Day = stats.Day
+			daily.Gifts += stats.Gifts
+			daily.Tokens += stats.Tokens
+			byDay[stats.Day] = daily
+		}
+		days = slices.SortedFunc(maps.Values(byDay), func(a, b domain.DailyStats) int { return a.Day.Compare(b.Day) })
+		return nil
+	})
+	return days, err
+}
+
+func (r *AnalyticsRepository) CountGifters(ctx context.Context, artistID string, since time.Time) (int64, error) {
+	var count int64
+	err := r.store.read(ctx, func(t *tables) error {
+		senders := map[string]bool{}
+		for _, stats := range t.artistStats(artistID, since) {
+			if stats.Gifts > 0 {
+				senders[stats.SenderID] = true
+			}
+		}
+		count = int64(len(senders))
+		return nil
+	})
+	return count, err
+}
+
+// artistStats returns the artist's aggregates of the days from since on
+func (t *tables) artistStats(artistID string, since time.Time) []domain.GiftStats {
+	var found []domain.GiftStats
+	for key, stats := range t.stats {
+		if key.artistID == artistID && !key.day.Before(since) {
+			found = append(found, stats)
+		}
+	}
+	return found
+}
+
+// day truncates at to its UTC day, as the date column does
+func day(at time.Time) time.Time {
+	year, month, d := at.UTC().Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type APIKeyRepository struct {
+	store *Store
+}
+
+func NewAPIKeyRepository(store *Store) domain.APIKeyRepository {
+	return &APIKeyRepository{store: store}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) error {
+	return r.store.write(ctx, func(t *tables) error {
+		key.Key = ""
+		return insert(t.apiKeys, key.ID, key)
+	})
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	return r.first(ctx, func(key domain.APIKey) bool { return key.ID == id })
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	return r.first(ctx, func(key domain.APIKey) bool { return key.KeyHash == hash })
+}
+
+func (r *APIKeyRepository) first(ctx context.Context, match func(domain.APIKey) bool) (*domain.APIKey, error) {
+	var key *domain.APIKey
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, stored := range t.apiKeys {
+			if match(stored) {
+				key = &stored
+				return nil
+			}
+		}
+		return domain.ErrAPIKeyNotFound
+	})
+	return key, err
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, artistID string) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	err := r.store.read(ctx, func(t *tables) error {
+		keys = rows(t.apiKeys,
+			func(key domain.APIKey) bool { return key.ArtistID == artistID },
+			func(a, b domain.APIKey) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return keys, err
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id string, at time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		key, ok := t.apiKeys[id]
+		if !ok {
+			return domain.ErrAPIKeyNotFound
+		}
+		// Revoking twice is harmless
+		if key.RevokedAt == nil {
+			key.RevokedAt = &at
+			t.apiKeys[id] = key
+		}
+		return nil
+	})
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id string, at time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if key, ok := t.apiKeys[id]; ok {
+			key.LastUsedAt = &at
+			t.apiKeys[id] = key
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ArtistRepository struct {
+	store *Store
+}
+
+func NewArtistRepository(store *Store) domain.ArtistRepository {
+	return &ArtistRepository{store: store}
+}
+
+func (r *ArtistRepository) CreateArtist(ctx context.Context, artist domain.Artist) error {
+	return r.store.write(ctx, func(t *tables) error {
+		// A deleted account releases its email for a new signup
+		if _, err := t.artist(func(existing domain.Artist) bool { return existing.Email == artist.Email }); err == nil {
+			return domain.ErrArtistExists
+		}
+		return insert(t.artists, artist.ID, artist)
+	})
+}
+
+func (r *ArtistRepository) GetArtistByID(ctx context.Context, id string) (*domain.Artist, error) {
+	return r.first(ctx, func(artist domain.Artist) bool { return artist.ID == id })
+}
+
+func (r *ArtistRepository) GetArtistByEmail(ctx context.Context, email string) (*domain.Artist, error) {
+	return r.first(ctx, func(artist domain.Artist) bool { return artist.Email == email })
+}
+
+func (r *ArtistRepository) ListArtists(ctx context.Context, limit, offset int) ([]domain.Artist, int64, error) {
+	var artists []domain.Artist
+	err := r.store.read(ctx, func(t *tables) error {
+		artists = rows(t.artists,
+			func(artist domain.Artist) bool { return !artist.DeletedAt.Valid },
+			func(a, b domain.Artist) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(artists, limit, offset), int64(len(artists)), err
+}
+
+func (r *ArtistRepository) UpdateRole(ctx context.Context, id string, role domain.Role) error {
+	return r.store.write(ctx, func(t *tables) error {
+		artist, err := t.artist(func(artist domain.Artist) bool { return artist.ID == id })
+		if err != nil {
+			return err
+		}
+		artist.Role = role
+		t.artists[id] = artist
+		return nil
+	})
+}
+
+func (r *ArtistRepository) DeleteArtist(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		artist, err := t.artist(func(artist domain.Artist) bool { return artist.ID == id })
+		if err != nil {
+			return err
+		}
+		artist.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		t.artists[id] = artist
+		return nil
+	})
+}
+
+func (r *ArtistRepository) first(ctx context.Context, match func(domain.Artist) bool) (*domain.Artist, error) {
+	var artist domain.Artist
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		artist, err = t.artist(match)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+// artist returns the account that is not deleted and matches
+func (t *tables) artist(match func(domain.Artist) bool) (domain.Artist, error) {
+	for _, artist := range t.artists {
+		if !artist.DeletedAt.Valid && match(artist) {
+			return artist, nil
+		}
+	}
+	return domain.Artist{}, domain.ErrArtistNotFound
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type AuditRepository struct {
+	store *Store
+}
+
+func NewAuditRepository(store *Store) domain.AuditRepository {
+	return &AuditRepository{store: store}
+}
+
+func (r *AuditRepository) RecordEntry(ctx context.Context, entry domain.AuditEntry) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.audit, entry.ID, entry)
+	})
+}
+
+func (r *AuditRepository) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error) {
+	var entries []domain.AuditEntry
+	err := r.store.read(ctx, func(t *tables) error {
+		entries = rows(t.audit,
+			func(entry domain.AuditEntry) bool {
+				return (filter.EntityType == "" || entry.EntityType == filter.EntityType) &&
+					(filter.EntityID == "" || entry.EntityID == filter.EntityID) &&
+					(filter.ActorID == "" || entry.ActorID == filter.ActorID)
+			},
+			func(a, b domain.AuditEntry) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(entries, filter.Limit, filter.Offset), int64(len(entries)), err
+}
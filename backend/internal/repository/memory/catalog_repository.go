@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"tokentide/internal/domain"
+)
+
+type CatalogRepository struct {
+	store *Store
+}
+
+func NewCatalogRepository(store *Store) domain.CatalogRepository {
+	return &CatalogRepository{store: store}
+}
+
+func (r *CatalogRepository) CreateCategory(ctx context.Context, category domain.Category) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, existing := range t.categories {
+			if existing.Slug == category.Slug {
+				return domain.ErrCategoryExists
+			}
+		}
+		return insert(t.categories, category.ID, category)
+	})
+}
+
+func (r *CatalogRepository) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	var category domain.Category
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if category, ok = t.categories[id]; !ok {
+			return domain.ErrCategoryNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CatalogRepository) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	var categories []domain.Category
+	err := r.store.read(ctx, func(t *tables) error {
+		categories = rows(t.categories, nil, func(a, b domain.Category) int {
+			return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		return nil
+	})
+	return categories, err
+}
+
+func (r *CatalogRepository) UpdateCategory(ctx context.Context, category domain.Category) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.categories[category.ID]
+		if !ok {
+			return domain.ErrCategoryNotFound
+		}
+		for _, existing := range t.categories {
+			if existing.ID != category.ID && existing.Slug == category.Slug {
+				return domain.ErrCategoryExists
+			}
+		}
+		stored.Name = category.Name
+		stored.Slug = category.Slug
+		t.categories[category.ID] = stored
+		return nil
+	})
+}
+
+// DeleteCategory also unlinks it from every gift, as the foreign key cascade does
+func (r *CatalogRepository) DeleteCategory(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.categories[id]; !ok {
+			return domain.ErrCategoryNotFound
+		}
+		delete(t.categories, id)
+		unlink(t.giftCategories, id)
+		return nil
+	})
+}
+
+func (r *CatalogRepository) CreateTag(ctx context.Context, tag domain.Tag) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, existing := range t.tags {
+			if existing.Slug == tag.Slug {
+				return domain.ErrTagExists
+			}
+		}
+		return insert(t.tags, tag.ID, tag)
+	})
+}
+
+func (r *CatalogRepository) GetTag(ctx context.Context, id string) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if tag, ok = t.tags[id]; !ok {
+			return domain.ErrTagNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *CatalogRepository) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := r.store.read(ctx, func(t *tables) error {
+		tags = rows(t.tags, nil, func(a, b domain.Tag) int {
+			return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		return nil
+	})
+	return tags, err
+}
+
+func (r *CatalogRepository) UpdateTag(ctx context.Context, tag domain.Tag) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.tags[tag.ID]
+		if !ok {
+			return domain.ErrTagNotFound
+		}
+		for _, existing := range t.tags {
+			if existing.ID != tag.ID && existing.Slug == tag.Slug {
+				return domain.ErrTagExists
+			}
+		}
+		stored.Name = tag.Name
+		stored.Slug = tag.Slug
+		t.tags[tag.ID] = stored
+		return nil
+	})
+}
+
+// DeleteTag also unlinks it from every gift, as the foreign key cascade does
+func (r *CatalogRepository) DeleteTag(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.tags[id]; !ok {
+			return domain.ErrTagNotFound
+		}
+		delete(t.tags, id)
+		unlink(t.giftTags, id)
+		return nil
+	})
+}
+
+// unlink removes id from the links of every gift, replacing the lists changed
+func unlink(links map[string][]string, id string) {
+	for giftID, ids := range links {
+		if slices.Contains(ids, id) {
+			links[giftID] = slices.DeleteFunc(slices.Clone(ids), func(linked string) bool { return linked == id })
+		}
+	}
+}
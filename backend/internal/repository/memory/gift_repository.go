@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GiftRepository struct {
+	store *Store
+}
+
+func NewGiftRepository(store *Store) domain.GiftRepository {
+	return &GiftRepository{store: store}
+}
+
+func (r *GiftRepository) CreateGift(ctx context.Context, gift domain.Gift, events ...domain.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored := gift
+		stored.Categories, stored.Tags, stored.DisplayPrice = nil, nil, nil
+		if err := insert(t.gifts, gift.ID, stored); err != nil {
+			return err
+		}
+		if err := t.linkGift(gift); err != nil {
+			return err
+		}
+		t.recordPrice(gift, gift.CreatedAt)
+		return t.recordEvents(events)
+	})
+}
+
+func (r *GiftRepository) GetGiftByID(ctx context.Context, id string) (*domain.Gift, error) {
+	var gift domain.Gift
+	err := r.store.read(ctx, func(t *tables) error {
+		stored, ok := t.gifts[id]
+		if !ok || stored.DeletedAt.Valid {
+			return domain.ErrGiftNotFound
+		}
+		gift = t.withLinks(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+// giftOrders are the accepted sort values, as in the GORM repository; id
+// breaks ties so pages are stable, and prices compare within a currency
+var giftOrders = map[string]func(a, b domain.Gift) int{
+	domain.GiftSortPrice: func(a, b domain.Gift) int {
+		return cmp.Or(cmp.Compare(a.Currency, b.Currency), cmp.Compare(a.PriceMinor, b.PriceMinor), cmp.Compare(a.ID, b.ID))
+	},
+	"-" + domain.GiftSortPrice: func(a, b domain.Gift) int {
+		return cmp.Or(cmp.Compare(a.Currency, b.Currency), cmp.Compare(b.PriceMinor, a.PriceMinor), cmp.Compare(a.ID, b.ID))
+	},
+	domain.GiftSortCreatedAt: func(a, b domain.Gift) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	},
+	"-" + domain.GiftSortCreatedAt: func(a, b domain.Gift) int {
+		return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID)
+	},
+}
+
+func (r *GiftRepository) ListGifts(ctx context.Context, filter domain.GiftFilter) ([]domain.Gift, int64, error) {
+	order, ok := giftOrders[filter.Sort]
+	if !ok {
+		order = giftOrders["-"+domain.GiftSortCreatedAt]
+	}
+
+	var gifts []domain.Gift
+	err := r.store.read(ctx, func(t *tables) error {
+		gifts = rows(t.gifts, func(gift domain.Gift) bool {
+			return !gift.DeletedAt.Valid &&
+				(filter.ArtistID == "" || gift.ArtistID == filter.ArtistID) &&
+				(filter.CategoryID == "" || slices.Contains(t.giftCategories[gift.ID], filter.CategoryID)) &&
+				(filter.TagID == "" || slices.Contains(t.giftTags[gift.ID], filter.TagID)) &&
+				(filter.Currency == "" || gift.Currency == filter.Currency) &&
+				(filter.MinPrice == nil || gift.PriceMinor >= *filter.MinPrice) &&
+				(filter.MaxPrice == nil || gift.PriceMinor <= *filter.MaxPrice)
+		}, order)
+		for i, gift := range gifts {
+			gifts[i] = t.withLinks(gift)
+		}
+		return nil
+	})
+	return page(gifts, filter.Limit, filter.Offset), int64(len(gifts)), err
+}
+
+func (r *GiftRepository) UpdateGift(ctx context.Context, gift domain.Gift) error {
+	return r.store.write(ctx, func(t *tables) error {
+		current, ok := t.gifts[gift.ID]
+		if !ok || current.DeletedAt.Valid {
+			return domain.ErrGiftNotFound
+		}
+		if current.Version != gift.Version {
+			return domain.ErrGiftVersionConflict
+		}
+		if gift.Quantity != nil && *gift.Quantity < current.Sold {
+			return fmt.Errorf("%w: quantity must not be below the %d already sold", domain.ErrInvalidGift, current.Sold)
+		}
+
+		updated := current
+		updated.Name = gift.Name
+		updated.Description = gift.Description
+		updated.PriceMinor = gift.PriceMinor
+		updated.Currency = gift.Currency
+		updated.Quantity = gift.Quantity
+		updated.PerFanLimit = gift.PerFanLimit
+		updated.Version++
+		t.gifts[gift.ID] = updated
+		if gift.PriceMinor != current.PriceMinor || gift.Currency != current.Currency {
+			t.recordPrice(gift, time.Now())
+		}
+		return t.linkGift(gift)
+	})
+}
+
+func (r *GiftRepository) SetGiftImage(ctx context.Context, id, key, url string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		gift, ok := t.gifts[id]
+		if !ok || gift.DeletedAt.Valid {
+			return domain.ErrGiftNotFound
+		}
+		gift.ImageKey = key
+		gift.ImageURL = url
+		gift.Version++
+		t.gifts[id] = gift
+		return nil
+	})
+}
+
+func (r *GiftRepository) DeleteGift(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		gift, ok := t.gifts[id]
+		if !ok || gift.DeletedAt.Valid {
+			return domain.ErrGiftNotFound
+		}
+		gift.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		t.gifts[id] = gift
+		return nil
+	})
+}
+
+func (r *GiftRepository) ListPriceHistory(ctx context.Context, giftID string) ([]domain.GiftPrice, error) {
+	var prices []domain.GiftPrice
+	err := r.store.read(ctx, func(t *tables) error {
+		if gift, ok := t.gifts[giftID]; !ok || gift.DeletedAt.Valid {
+			return nil
+		}
+		prices = rows(t.prices,
+			func(price domain.GiftPrice) bool { return price.GiftID == giftID },
+			func(a, b domain.GiftPrice) int {
+				return cmp.Or(a.EffectiveFrom.Compare(b.EffectiveFrom), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return prices, err
+}
+
+// recordPrice adds the gift's price to its price history
+func (t *tables) recordPrice(gift domain.Gift, effectiveFrom time.Time) {
+	id := uuid.NewString()
+	t.prices[id] = domain.GiftPrice{
+		ID:            id,
+		GiftID:        gift.ID,
+		PriceMinor:    gift.PriceMinor,
+		Currency:      gift.Currency,
+		EffectiveFrom: effectiveFrom,
+	}
+}
+
+// withLinks returns the gift with its categories and tags, ordered by name
+func (t *tables) withLinks(gift domain.Gift) domain.Gift {
+	gift.Categories = []domain.Category{}
+	for _, id := range t.giftCategories[gift.ID] {
+		gift.Categories = append(gift.Categories, t.categories[id])
+	}
+	slices.SortFunc(gift.Categories, func(a, b domain.Category) int { return strings.Compare(a.Name, b.Name) })
+
+	gift.Tags = []domain.Tag{}
+	for _, id := range t.giftTags[gift.ID] {
+		gift.Tags = append(gift.Tags, t.tags[id])
+	}
+	slices.SortFunc(gift.Tags, func(a, b domain.Tag) int { return strings.Compare(a.Name, b.Name) })
+	return gift
+}
+
+// linkGift replaces the categories and tags of a gift, leaving either
+// unchanged when it is nil
+func (t *tables) linkGift(gift domain.Gift) error {
+	if gift.Categories != nil {
+		ids := make([]string, len(gift.Categories))
+		for i, category := range gift.Categories {
+			if _, ok := t.categories[category.ID]; !ok {
+				return domain.ErrCategoryNotFound
+			}
+			ids[i] = category.ID
+		}
+		slices.Sort(ids)
+		t.giftCategories[gift.ID] = slices.Compact(ids)
+	}
+	if gift.Tags != nil {
+		ids := make([]string, len(gift.Tags))
+		for i, tag := range gift.Tags {
+			if _, ok := t.tags[tag.ID]; !ok {
+				return domain.ErrTagNotFound
+			}
+			ids[i] = tag.ID
+		}
+		slices.Sort(ids)
+		t.giftTags[gift.ID] = slices.Compact(ids)
+	}
+	return nil
+}
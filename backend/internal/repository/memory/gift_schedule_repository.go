@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type GiftScheduleRepository struct {
+	store *Store
+}
+
+func NewGiftScheduleRepository(store *Store) domain.GiftScheduleRepository {
+	return &GiftScheduleRepository{store: store}
+}
+
+func (r *GiftScheduleRepository) CreateSchedule(ctx context.Context, schedule domain.GiftSchedule) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.schedules, schedule.ID, schedule)
+	})
+}
+
+func (r *GiftScheduleRepository) GetSchedule(ctx context.Context, id string) (*domain.GiftSchedule, error) {
+	var schedule domain.GiftSchedule
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if schedule, ok = t.schedules[id]; !ok {
+			return domain.ErrGiftScheduleNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *GiftScheduleRepository) ListSchedules(ctx context.Context, senderID string, limit, offset int) ([]domain.GiftSchedule, int64, error) {
+	var schedules []domain.GiftSchedule
+	err := r.store.read(ctx, func(t *tables) error {
+		schedules = rows(t.schedules,
+			func(schedule domain.GiftSchedule) bool { return schedule.SenderID == senderID },
+			func(a, b domain.GiftSchedule) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(schedules, limit, offset), int64(len(schedules)), err
+}
+
+func (r *GiftScheduleRepository) CancelSchedule(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		schedule, ok := t.schedules[id]
+		if !ok {
+			return domain.ErrGiftScheduleNotFound
+		}
+		if schedule.Status != domain.ScheduleActive {
+			return domain.ErrGiftScheduleInactive
+		}
+		schedule.Status = domain.ScheduleCancelled
+		schedule.UpdatedAt = time.Now()
+		t.schedules[id] = schedule
+		return nil
+	})
+}
+
+func (r *GiftScheduleRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]domain.GiftSchedule, error) {
+	var schedules []domain.GiftSchedule
+	err := r.store.read(ctx, func(t *tables) error {
+		schedules = rows(t.schedules,
+			func(schedule domain.GiftSchedule) bool {
+				return schedule.Status == domain.ScheduleActive && !schedule.NextRunAt.After(now)
+			},
+			func(a, b domain.GiftSchedule) int {
+				return cmp.Or(a.NextRunAt.Compare(b.NextRunAt), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return page(schedules, limit, 0), err
+}
+
+func (r *GiftScheduleRepository) ClaimRun(ctx context.Context, id string, due, next time.Time, status string) (bool, error) {
+	claimed := false
+	err := r.store.write(ctx, func(t *tables) error {
+		// Matching on the due time makes the claim a compare-and-swap, so each
+		// run is claimed once
+		schedule, ok := t.schedules[id]
+		if !ok || schedule.Status != domain.ScheduleActive || !schedule.NextRunAt.Equal(due) {
+			return nil
+		}
+		schedule.NextRunAt = next
+		schedule.Status = status
+		schedule.UpdatedAt = time.Now()
+		t.schedules[id] = schedule
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *GiftScheduleRepository) RecordRun(ctx context.Context, id string, ranAt time.Time, runErr string, stop bool) error {
+	return r.store.write(ctx, func(t *tables) error {
+		schedule, ok := t.schedules[id]
+		if !ok {
+			return nil
+		}
+		schedule.LastRunAt = &ranAt
+		schedule.LastError = runErr
+		schedule.UpdatedAt = time.Now()
+		if runErr == "" {
+			schedule.SentCount++
+		}
+		if stop {
+			schedule.Status = domain.ScheduleFailed
+		}
+		t.schedules[id] = schedule
+		return nil
+	})
+}
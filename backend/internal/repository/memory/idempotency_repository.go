@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type IdempotencyRepository struct {
+	store *Store
+}
+
+func NewIdempotencyRepository(store *Store) domain.IdempotencyRepository {
+	return &IdempotencyRepository{store: store}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record domain.IdempotencyRecord, expiry time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	var existing domain.IdempotencyRecord
+	reserved := false
+	err := r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.idempotency[record.Key]
+		// Expired keys may be reused
+		if !ok || stored.CreatedAt.Before(time.Now().Add(-expiry)) {
+			t.idempotency[record.Key] = record
+			reserved = true
+			return nil
+		}
+		existing = stored
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		return &record, true, nil
+	}
+	return &existing, false, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if record, ok := t.idempotency[key]; ok {
+			record.Completed = true
+			record.StatusCode = statusCode
+			record.ContentType = contentType
+			record.Body = body
+			t.idempotency[key] = record
+		}
+		return nil
+	})
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.idempotency, key)
+		return nil
+	})
+}
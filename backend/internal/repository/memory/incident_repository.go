@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type IncidentRepository struct {
+	store *Store
+}
+
+func NewIncidentRepository(store *Store) domain.IncidentRepository {
+	return &IncidentRepository{store: store}
+}
+
+func (r *IncidentRepository) CreateIncident(ctx context.Context, incident domain.Incident) error {
+	return r.store.write(ctx, func(t *tables) error {
+		updates := make([]domain.IncidentUpdate, len(incident.Updates))
+		for i, update := range incident.Updates {
+			updates[i] = r.number(update, incident.ID)
+		}
+		incident.Updates = updates
+		return insert(t.incidents, incident.ID, incident)
+	})
+}
+
+func (r *IncidentRepository) GetIncidentByID(ctx context.Context, id string) (*domain.Incident, error) {
+	var incident domain.Incident
+	err := r.store.read(ctx, func(t *tables) error {
+		stored, ok := t.incidents[id]
+		if !ok {
+			return domain.ErrIncidentNotFound
+		}
+		incident = withOrderedUpdates(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
+
+func (r *IncidentRepository) UpdateIncident(ctx context.Context, incident domain.Incident, update domain.IncidentUpdate) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.incidents[incident.ID]
+		if !ok {
+			return nil
+		}
+		stored.Status = incident.Status
+		stored.Impact = incident.Impact
+		stored.ResolvedAt = incident.ResolvedAt
+		stored.Updates = append(slices.Clone(stored.Updates), r.number(update, incident.ID))
+		t.incidents[incident.ID] = stored
+		return nil
+	})
+}
+
+func (r *IncidentRepository) ListIncidents(ctx context.Context, resolvedSince time.Time) ([]domain.Incident, error) {
+	var incidents []domain.Incident
+	err := r.store.read(ctx, func(t *tables) error {
+		incidents = rows(t.incidents,
+			func(incident domain.Incident) bool {
+				return incident.ResolvedAt == nil || incident.ResolvedAt.After(resolvedSince)
+			},
+			func(a, b domain.Incident) int { return b.StartedAt.Compare(a.StartedAt) })
+		for i, incident := range incidents {
+			incidents[i] = withOrderedUpdates(incident)
+		}
+		return nil
+	})
+	return incidents, err
+}
+
+// number gives a new update its key and links it to the incident
+func (r *IncidentRepository) number(update domain.IncidentUpdate, incidentID string) domain.IncidentUpdate {
+	r.store.nextID++
+	update.ID = r.store.nextID
+	update.IncidentID = incidentID
+	return update
+}
+
+// withOrderedUpdates returns the incident with its timeline oldest first
+func withOrderedUpdates(incident domain.Incident) domain.Incident {
+	incident.Updates = slices.Clone(incident.Updates)
+	slices.SortStableFunc(incident.Updates, func(a, b domain.IncidentUpdate) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return incident
+}
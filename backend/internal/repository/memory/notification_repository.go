@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type NotificationRepository struct {
+	store *Store
+}
+
+func NewNotificationRepository(store *Store) domain.NotificationRepository {
+	return &NotificationRepository{store: store}
+}
+
+func (r *NotificationRepository) GetPreferences(ctx context.Context, artistID string) (*domain.NotificationPreferences, error) {
+	preferences := domain.DefaultNotificationPreferences(artistID)
+	err := r.store.read(ctx, func(t *tables) error {
+		if saved, ok := t.preferences[artistID]; ok {
+			preferences = saved
+		}
+		return nil
+	})
+	return &preferences, err
+}
+
+func (r *NotificationRepository) SavePreferences(ctx context.Context, preferences domain.NotificationPreferences) error {
+	return r.store.write(ctx, func(t *tables) error {
+		t.preferences[preferences.ArtistID] = preferences
+		return nil
+	})
+}
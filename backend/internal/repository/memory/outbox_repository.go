@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type OutboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) domain.OutboxRepository {
+	return &OutboxRepository{store: store}
+}
+
+// Relay publishes without holding the store, so publish may use the other
+// repositories; the events are marked as being relayed meanwhile, which
+// concurrent relays skip as they would locked rows
+func (r *OutboxRepository) Relay(ctx context.Context, limit int, publish func(ctx context.Context, events []domain.Event) error) (int, error) {
+	var events []domain.Event
+	_ = r.store.read(ctx, func(t *tables) error {
+		events = page(rows(t.events,
+			func(event domain.Event) bool { return event.PublishedAt == nil && !r.store.relaying[event.ID] },
+			func(a, b domain.Event) int {
+				return cmp.Or(a.OccurredAt.Compare(b.OccurredAt), cmp.Compare(a.ID, b.ID))
+			}), limit, 0)
+		for _, event := range events {
+			r.store.relaying[event.ID] = true
+		}
+		return nil
+	})
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	err := publish(ctx, events)
+	_ = r.store.write(ctx, func(t *tables) error {
+		now := time.Now()
+		for _, event := range events {
+			delete(r.store.relaying, event.ID)
+			if stored, ok := t.events[event.ID]; ok && err == nil {
+				stored.PublishedAt = &now
+				t.events[event.ID] = stored
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (r *OutboxRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.store.write(ctx, func(t *tables) error {
+		for id, event := range t.events {
+			if event.PublishedAt != nil && event.PublishedAt.Before(before) {
+				delete(t.events, id)
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
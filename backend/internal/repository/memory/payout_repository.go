@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type PayoutRepository struct {
+	store *Store
+}
+
+func NewPayoutRepository(store *Store) domain.PayoutRepository {
+	return &PayoutRepository{store: store}
+}
+
+func (r *PayoutRepository) GetAccount(ctx context.Context, artistID string) (*domain.PayoutAccount, error) {
+	var account domain.PayoutAccount
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if account, ok = t.accounts[artistID]; !ok {
+			return domain.ErrPayoutAccountNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *PayoutRepository) SaveAccount(ctx context.Context, account domain.PayoutAccount) error {
+	return r.store.write(ctx, func(t *tables) error {
+		t.accounts[account.ArtistID] = account
+		return nil
+	})
+}
+
+func (r *PayoutRepository) DeleteAccount(ctx context.Context, artistID string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.accounts[artistID]; !ok {
+			return domain.ErrPayoutAccountNotFound
+		}
+		delete(t.accounts, artistID)
+		return nil
+	})
+}
+
+func (r *PayoutRepository) GetBalance(ctx context.Context, artistID, walletID string) (*domain.PayoutBalance, error) {
+	var balance domain.PayoutBalance
+	err := r.store.read(ctx, func(t *tables) error {
+		wallet, err := t.wallet(walletID)
+		if err != nil {
+			return err
+		}
+		balance = t.payoutBalance(artistID, wallet)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (r *PayoutRepository) RequestPayout(ctx context.Context, payout domain.Payout) error {
+	return r.store.write(ctx, func(t *tables) error {
+		wallet, err := t.wallet(payout.WalletID)
+		if err != nil {
+			return err
+		}
+		if payout.Tokens > t.payoutBalance(payout.ArtistID, wallet).Withdrawable {
+			return domain.ErrInsufficientEarnings
+		}
+
+		if err := insert(t.payouts, payout.ID, payout); err != nil {
+			return err
+		}
+		_, err = t.applyDelta(payout.WalletID, -payout.Tokens, ledgerRef{payoutID: payout.ID})
+		return err
+	})
+}
+
+func (r *PayoutRepository) StartPayout(ctx context.Context, id, reviewerID string) (*domain.Payout, error) {
+	var payout domain.Payout
+	err := r.store.write(ctx, func(t *tables) (err error) {
+		if payout, err = t.payout(id); err != nil {
+			return err
+		}
+		if payout.Status != domain.PayoutRequested {
+			return domain.ErrPayoutNotRequested
+		}
+
+		payout.Status = domain.PayoutProcessing
+		payout.ReviewedBy = reviewerID
+		payout.UpdatedAt = time.Now()
+		t.payouts[id] = payout
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+func (r *PayoutRepository) CompletePayout(ctx context.Context, id, providerRef string, events ...domain.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		payout, ok := t.payouts[id]
+		if !ok || payout.Status != domain.PayoutProcessing {
+			return domain.ErrPayoutNotFound
+		}
+
+		payout.Status = domain.PayoutPaid
+		payout.ProviderRef = providerRef
+		payout.UpdatedAt = time.Now()
+		t.payouts[id] = payout
+		return t.recordEvents(events)
+	})
+}
+
+func (r *PayoutRepository) FailPayout(ctx context.Context, id, reason string) error {
+	return r.returnTokens(ctx, id, domain.PayoutProcessing, func(payout *domain.Payout) {
+		payout.Status = domain.PayoutFailed
+		payout.FailureReason = reason
+	})
+}
+
+func (r *PayoutRepository) RejectPayout(ctx context.Context, id, reviewerID, reason string) error {
+	return r.returnTokens(ctx, id, domain.PayoutRequested, func(payout *domain.Payout) {
+		payout.Status = domain.PayoutRejected
+		payout.ReviewedBy = reviewerID
+		payout.FailureReason = reason
+	})
+}
+
+// returnTokens applies update to a payout in the from state and credits its
+// tokens back to the wallet they were taken from
+func (r *PayoutRepository) returnTokens(ctx context.Context, id, from string, update func(payout *domain.Payout)) error {
+	return r.store.write(ctx, func(t *tables) error {
+		payout, err := t.payout(id)
+		if err != nil {
+			return err
+		}
+		if payout.Status != from {
+			return domain.ErrPayoutNotRequested
+		}
+
+		update(&payout)
+		payout.UpdatedAt = time.Now()
+		t.payouts[id] = payout
+		_, err = t.applyDelta(payout.WalletID, payout.Tokens, ledgerRef{payoutID: payout.ID})
+		return err
+	})
+}
+
+func (r *PayoutRepository) ListProcessingPayouts(ctx context.Context, before time.Time, limit int) ([]domain.Payout, error) {
+	var payouts []domain.Payout
+	err := r.store.read(ctx, func(t *tables) error {
+		payouts = rows(t.payouts,
+			func(payout domain.Payout) bool {
+				return payout.Status == domain.PayoutProcessing && payout.UpdatedAt.Before(before)
+			},
+			func(a, b domain.Payout) int {
+				return cmp.Or(a.UpdatedAt.Compare(b.UpdatedAt), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return page(payouts, limit, 0), err
+}
+
+func (r *PayoutRepository) GetPayout(ctx context.Context, id string) (*domain.Payout, error) {
+	var payout domain.Payout
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		payout, err = t.payout(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+func (r *PayoutRepository) ListPayouts(ctx context.Context, filter domain.PayoutFilter) ([]domain.Payout, int64, error) {
+	var payouts []domain.Payout
+	err := r.store.read(ctx, func(t *tables) error {
+		payouts = rows(t.payouts,
+			func(payout domain.Payout) bool {
+				return (filter.ArtistID == "" || payout.ArtistID == filter.ArtistID) && (filter.Status == "" || payout.Status == filter.Status)
+			},
+			func(a, b domain.Payout) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(payouts, filter.Limit, filter.Offset), int64(len(payouts)), err
+}
+
+func (t *tables) payout(id string) (domain.Payout, error) {
+	payout, ok := t.payouts[id]
+	if !ok {
+		return domain.Payout{}, domain.ErrPayoutNotFound
+	}
+	return payout, nil
+}
+
+// payoutBalance computes an artist's earnings from the ledger: a gift send
+// credits the artist, and its refund debits them with the refund ID set
+func (t *tables) payoutBalance(artistID string, wallet domain.Wallet) domain.PayoutBalance {
+	var balance domain.PayoutBalance
+	for _, entry := range t.transactions {
+		if entry.WalletID != wallet.ID || entry.GiftID == "" {
+			continue
+		}
+		switch {
+		case entry.Type == domain.TransactionCredit && entry.RefundID == "":
+			balance.Earned += entry.Amount
+		case entry.Type == domain.TransactionDebit && entry.RefundID != "":
+			balance.Earned -= entry.Amount
+		}
+	}
+
+	for _, payout := range t.payouts {
+		if payout.ArtistID != artistID {
+			continue
+		}
+		switch payout.Status {
+		case domain.PayoutPaid:
+			balance.PaidOut += payout.Tokens
+		case domain.PayoutRequested, domain.PayoutProcessing:
+			balance.Pending += payout.Tokens
+		}
+	}
+
+	balance.Withdrawable = max(0, min(wallet.Balance, balance.Earned-balance.PaidOut-balance.Pending))
+	return balance
+}
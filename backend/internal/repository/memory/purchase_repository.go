@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type PurchaseRepository struct {
+	store *Store
+}
+
+func NewPurchaseRepository(store *Store) domain.PurchaseRepository {
+	return &PurchaseRepository{store: store}
+}
+
+func (r *PurchaseRepository) CreateTokenPackage(ctx context.Context, pkg domain.TokenPackage) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.packages, pkg.ID, pkg)
+	})
+}
+
+func (r *PurchaseRepository) GetTokenPackage(ctx context.Context, id string) (*domain.TokenPackage, error) {
+	var pkg domain.TokenPackage
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if pkg, ok = t.packages[id]; !ok {
+			return domain.ErrTokenPackageNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+func (r *PurchaseRepository) ListTokenPackages(ctx context.Context) ([]domain.TokenPackage, error) {
+	var packages []domain.TokenPackage
+	err := r.store.read(ctx, func(t *tables) error {
+		packages = rows(t.packages,
+			func(pkg domain.TokenPackage) bool { return pkg.Active },
+			func(a, b domain.TokenPackage) int {
+				return cmp.Or(cmp.Compare(a.AmountMinor, b.AmountMinor), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return packages, err
+}
+
+func (r *PurchaseRepository) CreatePurchase(ctx context.Context, purchase domain.Purchase) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.purchases, purchase.ID, purchase)
+	})
+}
+
+func (r *PurchaseRepository) GetPurchase(ctx context.Context, id string) (*domain.Purchase, error) {
+	var purchase domain.Purchase
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		purchase, err = t.purchase(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+func (r *PurchaseRepository) SetProviderRef(ctx context.Context, id, providerRef string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if purchase, ok := t.purchases[id]; ok {
+			purchase.ProviderRef = providerRef
+			t.purchases[id] = purchase
+		}
+		return nil
+	})
+}
+
+func (r *PurchaseRepository) CompletePurchase(ctx context.Context, id, walletID string, events ...domain.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		purchase, err := t.purchase(id)
+		if err != nil {
+			return err
+		}
+		// Providers retry webhooks, so a purchase may be completed more than
+		// once, even after it was refunded; only an open one is credited
+		if purchase.Status != domain.PurchasePending && purchase.Status != domain.PurchaseFailed {
+			return nil
+		}
+
+		purchase.Status = domain.PurchaseSucceeded
+		t.purchases[id] = purchase
+		if _, err := t.applyDelta(walletID, purchase.Tokens, ledgerRef{purchaseID: purchase.ID}); err != nil {
+			return err
+		}
+		return t.recordEvents(events)
+	})
+}
+
+func (r *PurchaseRepository) FailPurchase(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if purchase, ok := t.purchases[id]; ok && purchase.Status == domain.PurchasePending {
+			purchase.Status = domain.PurchaseFailed
+			t.purchases[id] = purchase
+		}
+		return nil
+	})
+}
+
+func (r *PurchaseRepository) ListPendingPurchases(ctx context.Context, before time.Time, limit int) ([]domain.Purchase, error) {
+	var purchases []domain.Purchase
+	err := r.store.read(ctx, func(t *tables) error {
+		purchases = rows(t.purchases,
+			func(purchase domain.Purchase) bool {
+				return purchase.Status == domain.PurchasePending && purchase.CreatedAt.Before(before) && purchase.ProviderRef != ""
+			},
+			func(a, b domain.Purchase) int {
+				return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return page(purchases, limit, 0), err
+}
+
+func (t *tables) purchase(id string) (domain.Purchase, error) {
+	purchase, ok := t.purchases[id]
+	if !ok {
+		return domain.Purchase{}, domain.ErrPurchaseNotFound
+	}
+	return purchase, nil
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type RefundRepository struct {
+	store *Store
+}
+
+func NewRefundRepository(store *Store) domain.RefundRepository {
+	return &RefundRepository{store: store}
+}
+
+func (r *RefundRepository) RefundGiftSend(ctx context.Context, refund domain.Refund, events ...domain.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		// Refunds are never deleted, so an existing one means this send was refunded
+		for _, existing := range t.refunds {
+			if existing.TransactionID == refund.TransactionID {
+				return domain.ErrAlreadyRefunded
+			}
+		}
+		if err := insert(t.refunds, refund.ID, refund); err != nil {
+			return err
+		}
+
+		if _, err := t.wallet(refund.WalletID); err != nil {
+			return err
+		}
+		artistRef := ledgerRef{counterpartyID: refund.WalletID, giftID: refund.GiftID, refundID: refund.ID}
+		if _, err := t.applyDelta(refund.CounterpartyWalletID, -refund.Tokens, artistRef); err != nil {
+			return err
+		}
+		senderRef := ledgerRef{counterpartyID: refund.CounterpartyWalletID, giftID: refund.GiftID, refundID: refund.ID}
+		if _, err := t.applyDelta(refund.WalletID, refund.Tokens, senderRef); err != nil {
+			return err
+		}
+		// The refunded send goes back into the gift's stock, even if it was deleted since
+		if gift, ok := t.gifts[refund.GiftID]; ok && gift.Sold > 0 {
+			gift.Sold--
+			t.gifts[gift.ID] = gift
+		}
+		return t.recordEvents(events)
+	})
+}
+
+func (r *RefundRepository) StartPurchaseRefund(ctx context.Context, refund domain.Refund) error {
+	return r.store.write(ctx, func(t *tables) error {
+		purchase, err := t.purchase(refund.PurchaseID)
+		if err != nil {
+			return err
+		}
+		switch purchase.Status {
+		case domain.PurchaseSucceeded:
+		case domain.PurchaseRefunded:
+			return domain.ErrAlreadyRefunded
+		default:
+			return domain.ErrNotRefundable
+		}
+
+		purchase.Status = domain.PurchaseRefunded
+		t.purchases[purchase.ID] = purchase
+		if err := insert(t.refunds, refund.ID, refund); err != nil {
+			return err
+		}
+		_, err = t.applyDelta(refund.WalletID, -refund.Tokens, ledgerRef{purchaseID: purchase.ID, refundID: refund.ID})
+		return err
+	})
+}
+
+func (r *RefundRepository) CompleteRefund(ctx context.Context, id, providerRef string, events ...domain.Event) error {
+	return r.store.write(ctx, func(t *tables) error {
+		refund, ok := t.refunds[id]
+		if !ok || refund.Status != domain.RefundPending {
+			return domain.ErrRefundNotFound
+		}
+
+		refund.Status = domain.RefundSucceeded
+		refund.ProviderRef = providerRef
+		refund.UpdatedAt = time.Now()
+		t.refunds[id] = refund
+		return t.recordEvents(events)
+	})
+}
+
+func (r *RefundRepository) FailPurchaseRefund(ctx context.Context, id, reason string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		refund, err := t.refund(id)
+		if err != nil {
+			return err
+		}
+		if refund.Status != domain.RefundPending {
+			return nil
+		}
+
+		refund.Status = domain.RefundFailed
+		refund.FailureReason = reason
+		refund.UpdatedAt = time.Now()
+		t.refunds[id] = refund
+		if purchase, ok := t.purchases[refund.PurchaseID]; ok && purchase.Status == domain.PurchaseRefunded {
+			purchase.Status = domain.PurchaseSucceeded
+			t.purchases[purchase.ID] = purchase
+		}
+		_, err = t.applyDelta(refund.WalletID, refund.Tokens, ledgerRef{purchaseID: refund.PurchaseID, refundID: refund.ID})
+		return err
+	})
+}
+
+func (r *RefundRepository) ListPendingRefunds(ctx context.Context, before time.Time, limit int) ([]domain.Refund, error) {
+	var refunds []domain.Refund
+	err := r.store.read(ctx, func(t *tables) error {
+		refunds = rows(t.refunds,
+			func(refund domain.Refund) bool {
+				return refund.Kind == domain.RefundPurchase && refund.Status == domain.RefundPending && refund.CreatedAt.Before(before)
+			},
+			func(a, b domain.Refund) int {
+				return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return page(refunds, limit, 0), err
+}
+
+func (r *RefundRepository) GetRefund(ctx context.Context, id string) (*domain.Refund, error) {
+	var refund domain.Refund
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		refund, err = t.refund(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *RefundRepository) ListRefunds(ctx context.Context, filter domain.RefundFilter) ([]domain.Refund, int64, error) {
+	var refunds []domain.Refund
+	err := r.store.read(ctx, func(t *tables) error {
+		refunds = rows(t.refunds,
+			func(refund domain.Refund) bool {
+				return (filter.Kind == "" || refund.Kind == filter.Kind) && (filter.Status == "" || refund.Status == filter.Status)
+			},
+			func(a, b domain.Refund) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(refunds, filter.Limit, filter.Offset), int64(len(refunds)), err
+}
+
+func (t *tables) refund(id string) (domain.Refund, error) {
+	refund, ok := t.refunds[id]
+	if !ok {
+		return domain.Refund{}, domain.ErrRefundNotFound
+	}
+	return refund, nil
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"tokentide/internal/domain"
+)
+
+// SearchRepository matches whole words, as the "simple" text search
+// configuration does, without stemming or Postgres' ranking
+type SearchRepository struct {
+	store *Store
+}
+
+func NewSearchRepository(store *Store) domain.SearchRepository {
+	return &SearchRepository{store: store}
+}
+
+// nameWeight and descriptionWeight rank a match in a name above one in a
+// description, as the search vectors' weights do
+const (
+	nameWeight        = 1.0
+	descriptionWeight = 0.4
+)
+
+func (r *SearchRepository) Search(ctx context.Context, query domain.SearchQuery) ([]domain.SearchResult, int64, error) {
+	alternatives := parseSearch(query.Text)
+	var results []domain.SearchResult
+	err := r.store.read(ctx, func(t *tables) error {
+		results = []domain.SearchResult{}
+		if query.Type == "" || query.Type == domain.SearchGift {
+			for _, gift := range t.gifts {
+				if gift.DeletedAt.Valid {
+					continue
+				}
+				if rank, ok := rankMatch(alternatives, words(gift.Name), words(gift.Description)); ok {
+					results = append(results, domain.SearchResult{Type: domain.SearchGift, ID: gift.ID, Name: gift.Name, Rank: rank})
+				}
+			}
+		}
+		// Only artists, not fans or admins, can be found
+		if query.Type == "" || query.Type == domain.SearchArtist {
+			for _, artist := range t.artists {
+				if artist.DeletedAt.Valid || artist.Role != domain.RoleArtist {
+					continue
+				}
+				if rank, ok := rankMatch(alternatives, words(artist.Name), nil); ok {
+					results = append(results, domain.SearchResult{Type: domain.SearchArtist, ID: artist.ID, Name: artist.Name, Rank: rank})
+				}
+			}
+		}
+		slices.SortFunc(results, func(a, b domain.SearchResult) int {
+			return cmp.Or(cmp.Compare(b.Rank, a.Rank), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+		})
+		return nil
+	})
+	return page(results, query.Limit, query.Offset), int64(len(results)), err
+}
+
+// searchTerm is a word or quoted phrase, which excluded terms must not match
+type searchTerm struct {
+	words    []string
+	excluded bool
+}
+
+// parseSearch splits web search syntax into alternatives separated by "or",
+// each matching when all its terms do
+func parseSearch(text string) [][]searchTerm {
+	var alternatives [][]searchTerm
+	var current []searchTerm
+	for i, part := range strings.Split(text, `"`) {
+		// Odd parts were quoted
+		if i%2 == 1 {
+			if phrase := words(part); len(phrase) > 0 {
+				current = append(current, searchTerm{words: phrase})
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			if strings.EqualFold(field, "or") {
+				if len(current) > 0 {
+					alternatives = append(alternatives, current)
+				}
+				current = nil
+				continue
+			}
+			excluded := strings.HasPrefix(field, "-")
+			for _, word := range words(field) {
+				current = append(current, searchTerm{words: []string{word}, excluded: excluded})
+			}
+		}
+	}
+	if len(current) > 0 {
+		alternatives = append(alternatives, current)
+	}
+	return alternatives
+}
+
+// rankMatch reports whether a document with the name and description words
+// matches any alternative, and ranks the best one
+func rankMatch(alternatives [][]searchTerm, name, description []string) (float64, bool) {
+	best, matched := 0.0, false
+	for _, terms := range alternatives {
+		rank, ok := 0.0, true
+		for _, term := range terms {
+			inName, inDescription := containsPhrase(name, term.words), containsPhrase(description, term.words)
+			if term.excluded == (inName || inDescription) {
+				ok = false
+				break
+			}
+			if term.excluded {
+				continue
+			}
+			if inName {
+				rank += nameWeight
+			} else {
+				rank += descriptionWeight
+			}
+		}
+		if ok {
+			best, matched = max(best, rank/float64(len(terms))), true
+		}
+	}
+	return best, matched
+}
+
+// words splits text into lowercase words
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsPhrase(document, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(document); i++ {
+		if slices.Equal(document[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
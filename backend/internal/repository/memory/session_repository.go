@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+)
+
+type SessionRepository struct {
+	store *Store
+}
+
+func NewSessionRepository(store *Store) domain.SessionRepository {
+	return &SessionRepository{store: store}
+}
+
+func (r *SessionRepository) CreateSession(ctx context.Context, session domain.Session) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.sessions, session.ID, session)
+	})
+}
+
+func (r *SessionRepository) GetSession(ctx context.Context, id string) (*domain.Session, error) {
+	return r.first(ctx, func(session domain.Session) bool { return session.ID == id })
+}
+
+func (r *SessionRepository) GetSessionByTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	return r.first(ctx, func(session domain.Session) bool {
+		return session.TokenHash == hash || session.PreviousTokenHash == hash
+	})
+}
+
+func (r *SessionRepository) first(ctx context.Context, match func(domain.Session) bool) (*domain.Session, error) {
+	var session *domain.Session
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, stored := range t.sessions {
+			if match(stored) {
+				session = &stored
+				return nil
+			}
+		}
+		return domain.ErrSessionNotFound
+	})
+	return session, err
+}
+
+func (r *SessionRepository) RotateSession(ctx context.Context, session domain.Session, oldHash string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.sessions[session.ID]
+		if !ok || stored.TokenHash != oldHash || stored.RevokedAt != nil {
+			return domain.ErrInvalidRefreshToken
+		}
+		stored.TokenHash = session.TokenHash
+		stored.PreviousTokenHash = oldHash
+		stored.UserAgent = session.UserAgent
+		stored.IP = session.IP
+		stored.LastUsedAt = session.LastUsedAt
+		stored.ExpiresAt = session.ExpiresAt
+		t.sessions[session.ID] = stored
+		return nil
+	})
+}
+
+func (r *SessionRepository) ListSessions(ctx context.Context, artistID string, now time.Time) ([]domain.Session, error) {
+	var sessions []domain.Session
+	err := r.store.read(ctx, func(t *tables) error {
+		sessions = rows(t.sessions,
+			func(session domain.Session) bool {
+				return session.ArtistID == artistID && session.RevokedAt == nil && session.ExpiresAt.After(now)
+			},
+			func(a, b domain.Session) int {
+				return cmp.Or(b.LastUsedAt.Compare(a.LastUsedAt), cmp.Compare(a.ID, b.ID))
+			})
+		return nil
+	})
+	return sessions, err
+}
+
+func (r *SessionRepository) RevokeSession(ctx context.Context, id string, at time.Time) error {
+	return r.store.write(ctx, func(t *tables) error {
+		session, ok := t.sessions[id]
+		if !ok {
+			return domain.ErrSessionNotFound
+		}
+		// Revoking twice is harmless
+		if session.RevokedAt == nil {
+			session.RevokedAt = &at
+			t.sessions[id] = session
+		}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type ShortLinkRepository struct {
+	store *Store
+}
+
+func NewShortLinkRepository(store *Store) domain.ShortLinkRepository {
+	return &ShortLinkRepository{store: store}
+}
+
+func (r *ShortLinkRepository) CreateShortLink(ctx context.Context, link domain.ShortLink) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, existing := range t.shortLinks {
+			if existing.Code == link.Code {
+				return domain.ErrShortLinkExists
+			}
+		}
+		return insert(t.shortLinks, link.ID, link)
+	})
+}
+
+func (r *ShortLinkRepository) GetShortLinkByCode(ctx context.Context, code string) (*domain.ShortLink, error) {
+	var link *domain.ShortLink
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, stored := range t.shortLinks {
+			if stored.Code == code {
+				link = &stored
+				return nil
+			}
+		}
+		return domain.ErrShortLinkNotFound
+	})
+	return link, err
+}
+
+func (r *ShortLinkRepository) RecordClick(ctx context.Context, click domain.ShortLinkClick) error {
+	return r.store.write(ctx, func(t *tables) error {
+		r.store.nextID++
+		click.ID = r.store.nextID
+		t.clicks[click.ID] = click
+		return nil
+	})
+}
+
+func (r *ShortLinkRepository) GetClickStats(ctx context.Context, linkID string) (*domain.ShortLinkStats, error) {
+	stats := &domain.ShortLinkStats{
+		ByReferrer: map[string]int64{},
+		ByCountry:  map[string]int64{},
+	}
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, click := range t.clicks {
+			if click.ShortLinkID != linkID {
+				continue
+			}
+			stats.TotalClicks++
+			stats.ByReferrer[click.Referrer]++
+			stats.ByCountry[click.Country]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,292 @@
+// Package memory implements the domain repositories in memory, for unit
+// tests of the services and handlers that need no database. The repositories
+// share one Store, so a transfer, a refund or a payout sees the wallets, the
+// ledger and the gifts the other repositories wrote, as they would in
+// Postgres. They follow the semantics of the GORM repositories in
+// internal/repository, which remain the reference.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store holds the tables of the in-memory repositories. Calls made outside
+// of a transaction run one at a time, each as if in its own database
+// transaction; see TxManager for those made within one.
+type Store struct {
+	// tx is held by every call outside of a transaction and by the whole of a
+	// transaction, which serializes them; mu guards the tables themselves
+	tx sync.Mutex
+	mu sync.Mutex
+	t  tables
+	// relaying are the outbox events being published, which other relays skip
+	relaying map[string]bool
+	// nextID numbers the rows with integer keys
+	nextID uint
+}
+
+// tables are the rows of the store by primary key. Rows are only ever
+// replaced, never changed in place, so a shallow copy of the maps is a
+// snapshot of the store.
+type tables struct {
+	artists        map[string]domain.Artist
+	wallets        map[string]domain.Wallet
+	transactions   map[string]domain.Transaction
+	gifts          map[string]domain.Gift
+	giftCategories map[string][]string
+	giftTags       map[string][]string
+	prices         map[string]domain.GiftPrice
+	categories     map[string]domain.Category
+	tags           map[string]domain.Tag
+	events         map[string]domain.Event
+	packages       map[string]domain.TokenPackage
+	purchases      map[string]domain.Purchase
+	refunds        map[string]domain.Refund
+	accounts       map[string]domain.PayoutAccount
+	payouts        map[string]domain.Payout
+	sessions       map[string]domain.Session
+	apiKeys        map[string]domain.APIKey
+	audit          map[string]domain.AuditEntry
+	idempotency    map[string]domain.IdempotencyRecord
+	preferences    map[string]domain.NotificationPreferences
+	webhooks       map[string]domain.Webhook
+	deliveries     map[string]domain.WebhookDelivery
+	schedules      map[string]domain.GiftSchedule
+	shortLinks     map[string]domain.ShortLink
+	clicks         map[uint]domain.ShortLinkClick
+	incidents      map[string]domain.Incident
+	stats          map[statsKey]domain.GiftStats
+}
+
+// statsKey is the primary key of the gift aggregates
+type statsKey struct {
+	artistID string
+	senderID string
+	day      time.Time
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{
+		t: tables{
+			artists:        map[string]domain.Artist{},
+			wallets:        map[string]domain.Wallet{},
+			transactions:   map[string]domain.Transaction{},
+			gifts:          map[string]domain.Gift{},
+			giftCategories: map[string][]string{},
+			giftTags:       map[string][]string{},
+			prices:         map[string]domain.GiftPrice{},
+			categories:     map[string]domain.Category{},
+			tags:           map[string]domain.Tag{},
+			events:         map[string]domain.Event{},
+			packages:       map[string]domain.TokenPackage{},
+			purchases:      map[string]domain.Purchase{},
+			refunds:        map[string]domain.Refund{},
+			accounts:       map[string]domain.PayoutAccount{},
+			payouts:        map[string]domain.Payout{},
+			sessions:       map[string]domain.Session{},
+			apiKeys:        map[string]domain.APIKey{},
+			audit:          map[string]domain.AuditEntry{},
+			idempotency:    map[string]domain.IdempotencyRecord{},
+			preferences:    map[string]domain.NotificationPreferences{},
+			webhooks:       map[string]domain.Webhook{},
+			deliveries:     map[string]domain.WebhookDelivery{},
+			schedules:      map[string]domain.GiftSchedule{},
+			shortLinks:     map[string]domain.ShortLink{},
+			clicks:         map[uint]domain.ShortLinkClick{},
+			incidents:      map[string]domain.Incident{},
+			stats:          map[statsKey]domain.GiftStats{},
+		},
+		relaying: map[string]bool{},
+	}
+}
+
+// Events returns the events recorded in the outbox, oldest first, for tests
+// to check what a change published
+func (s *Store) Events() []domain.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := slices.Collect(maps.Values(s.t.events))
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return cmp.Or(a.OccurredAt.Compare(b.OccurredAt), cmp.Compare(a.ID, b.ID))
+	})
+	return events
+}
+
+// lock takes the store for one repository call and returns the function
+// releasing it. A call within a transaction runs under the transaction's
+// hold on tx.
+func (s *Store) lock(ctx context.Context) func() {
+	inTx := inTransaction(ctx)
+	if !inTx {
+		s.tx.Lock()
+	}
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		if !inTx {
+			s.tx.Unlock()
+		}
+	}
+}
+
+// read runs fn over the tables
+func (s *Store) read(ctx context.Context, fn func(t *tables) error) error {
+	defer s.lock(ctx)()
+	return fn(&s.t)
+}
+
+// write runs fn over the tables and undoes its changes if it fails, as the
+// GORM repositories' own transactions roll back
+func (s *Store) write(ctx context.Context, fn func(t *tables) error) error {
+	defer s.lock(ctx)()
+	snapshot := s.t.clone()
+	if err := fn(&s.t); err != nil {
+		s.t = snapshot
+		return err
+	}
+	return nil
+}
+
+func (t *tables) clone() tables {
+	return tables{
+		artists:        maps.Clone(t.artists),
+		wallets:        maps.Clone(t.wallets),
+		transactions:   maps.Clone(t.transactions),
+		gifts:          maps.Clone(t.gifts),
+		giftCategories: maps.Clone(t.giftCategories),
+		giftTags:       maps.Clone(t.giftTags),
+		prices:         maps.Clone(t.prices),
+		categories:     maps.Clone(t.categories),
+		tags:           maps.Clone(t.tags),
+		events:         maps.Clone(t.events),
+		packages:       maps.Clone(t.packages),
+		purchases:      maps.Clone(t.purchases),
+		refunds:        maps.Clone(t.refunds),
+		accounts:       maps.Clone(t.accounts),
+		payouts:        maps.Clone(t.payouts),
+		sessions:       maps.Clone(t.sessions),
+		apiKeys:        maps.Clone(t.apiKeys),
+		audit:          maps.Clone(t.audit),
+		idempotency:    maps.Clone(t.idempotency),
+		preferences:    maps.Clone(t.preferences),
+		webhooks:       maps.Clone(t.webhooks),
+		deliveries:     maps.Clone(t.deliveries),
+		schedules:      maps.Clone(t.schedules),
+		shortLinks:     maps.Clone(t.shortLinks),
+		clicks:         maps.Clone(t.clicks),
+		incidents:      maps.Clone(t.incidents),
+		stats:          maps.Clone(t.stats),
+	}
+}
+
+// insert adds a row under a new key, failing like a primary key violation
+// when the key is taken
+func insert[K comparable, V any](table map[K]V, key K, row V) error {
+	if _, ok := table[key]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	table[key] = row
+	return nil
+}
+
+// rows returns the rows of table that match, ordered by compare
+func rows[K comparable, V any](table map[K]V, match func(V) bool, compare func(a, b V) int) []V {
+	found := []V{}
+	for _, row := range table {
+		if match == nil || match(row) {
+			found = append(found, row)
+		}
+	}
+	slices.SortFunc(found, compare)
+	return found
+}
+
+// page applies LIMIT and OFFSET as GORM does: a negative limit returns every
+// row from offset on
+func page[V any](rows []V, limit, offset int) []V {
+	if offset > 0 {
+		rows = rows[min(offset, len(rows)):]
+	}
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// newestFirst orders rows by creation time, newest first, then by ID, as the
+// GORM repositories' "created_at DESC, id"
+func newestFirst(aCreated, bCreated time.Time, aID, bID string) int {
+	return cmp.Or(bCreated.Compare(aCreated), cmp.Compare(aID, bID))
+}
+
+// ledgerRef links a ledger entry to what caused the balance change
+type ledgerRef struct {
+	counterpartyID string
+	giftID         string
+	purchaseID     string
+	refundID       string
+	payoutID       string
+}
+
+func (t *tables) wallet(id string) (domain.Wallet, error) {
+	wallet, ok := t.wallets[id]
+	if !ok {
+		return domain.Wallet{}, domain.ErrWalletNotFound
+	}
+	return wallet, nil
+}
+
+// applyDelta updates a wallet's balance and records it in the ledger
+func (t *tables) applyDelta(id string, delta int64, ref ledgerRef) (domain.Wallet, error) {
+	wallet, err := t.wallet(id)
+	if err != nil {
+		return domain.Wallet{}, err
+	}
+	if wallet.Balance+delta < 0 {
+		return domain.Wallet{}, domain.ErrInsufficientFunds
+	}
+
+	wallet.Balance += delta
+	t.wallets[id] = wallet
+
+	entry := domain.Transaction{
+		ID:                   uuid.NewString(),
+		WalletID:             wallet.ID,
+		Type:                 domain.TransactionCredit,
+		Amount:               delta,
+		BalanceAfter:         wallet.Balance,
+		CounterpartyWalletID: ref.counterpartyID,
+		GiftID:               ref.giftID,
+		PurchaseID:           ref.purchaseID,
+		RefundID:             ref.refundID,
+		PayoutID:             ref.payoutID,
+		CreatedAt:            time.Now(),
+	}
+	if delta < 0 {
+		entry.Type = domain.TransactionDebit
+		entry.Amount = -delta
+	}
+	t.transactions[entry.ID] = entry
+	return wallet, nil
+}
+
+// recordEvents writes events to the outbox
+func (t *tables) recordEvents(events []domain.Event) error {
+	for _, event := range events {
+		if err := insert(t.events, event.ID, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
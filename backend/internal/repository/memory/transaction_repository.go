@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type TransactionRepository struct {
+	store *Store
+}
+
+func NewTransactionRepository(store *Store) domain.TransactionRepository {
+	return &TransactionRepository{store: store}
+}
+
+func (r *TransactionRepository) ListTransactions(ctx context.Context, walletID string, limit, offset int) ([]domain.Transaction, int64, error) {
+	var entries []domain.Transaction
+	err := r.store.read(ctx, func(t *tables) error {
+		entries = rows(t.transactions,
+			func(entry domain.Transaction) bool { return entry.WalletID == walletID },
+			func(a, b domain.Transaction) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(entries, limit, offset), int64(len(entries)), err
+}
+
+func (r *TransactionRepository) GetTransaction(ctx context.Context, id string) (*domain.Transaction, error) {
+	var entry domain.Transaction
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if entry, ok = t.transactions[id]; !ok {
+			return domain.ErrTransactionNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
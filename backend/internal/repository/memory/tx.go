@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type txKey struct{}
+
+// TxManager runs units of work over a store. A transaction holds the store
+// for its whole duration, so it sees no other call's writes and none see its
+// own until it ends; when fn fails or panics the store is put back as it was.
+type TxManager struct {
+	store *Store
+}
+
+func NewTxManager(store *Store) domain.TxManager {
+	return &TxManager{store: store}
+}
+
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	m.store.tx.Lock()
+	defer m.store.tx.Unlock()
+
+	m.store.mu.Lock()
+	snapshot := m.store.t.clone()
+	m.store.mu.Unlock()
+
+	committed := false
+	defer func() {
+		if !committed {
+			m.store.mu.Lock()
+			m.store.t = snapshot
+			m.store.mu.Unlock()
+		}
+	}()
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(bool)
+	return ok
+}
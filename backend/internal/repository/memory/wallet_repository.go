@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+)
+
+type WalletRepository struct {
+	store *Store
+}
+
+func NewWalletRepository(store *Store) domain.WalletRepository {
+	return &WalletRepository{store: store}
+}
+
+func (r *WalletRepository) CreateWallet(ctx context.Context, wallet domain.Wallet) error {
+	return r.store.write(ctx, func(t *tables) error {
+		for _, existing := range t.wallets {
+			if existing.OwnerID == wallet.OwnerID {
+				return nil
+			}
+		}
+		return insert(t.wallets, wallet.ID, wallet)
+	})
+}
+
+func (r *WalletRepository) GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		wallet, err = t.wallet(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepository) GetWalletByOwner(ctx context.Context, ownerID string) (*domain.Wallet, error) {
+	var wallet *domain.Wallet
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, existing := range t.wallets {
+			if existing.OwnerID == ownerID {
+				wallet = &existing
+				return nil
+			}
+		}
+		return domain.ErrWalletNotFound
+	})
+	return wallet, err
+}
+
+func (r *WalletRepository) AdjustBalance(ctx context.Context, id string, delta int64) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+	err := r.store.write(ctx, func(t *tables) (err error) {
+		wallet, err = t.applyDelta(id, delta, ledgerRef{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepository) Transfer(ctx context.Context, fromID, toID string, amount int64, giftID string, events ...domain.Event) (*domain.Wallet, error) {
+	var from domain.Wallet
+	err := r.store.write(ctx, func(t *tables) error {
+		if _, err := t.wallet(toID); err != nil {
+			return err
+		}
+		if _, err := t.wallet(fromID); err != nil {
+			return err
+		}
+
+		if giftID != "" {
+			if err := t.claimGift(giftID, fromID); err != nil {
+				return err
+			}
+		}
+		var err error
+		if from, err = t.applyDelta(fromID, -amount, ledgerRef{counterpartyID: toID, giftID: giftID}); err != nil {
+			return err
+		}
+		if _, err := t.applyDelta(toID, amount, ledgerRef{counterpartyID: fromID, giftID: giftID}); err != nil {
+			return err
+		}
+		return t.recordEvents(events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &from, nil
+}
+
+// claimGift takes one of a gift's stock for a send from the wallet, enforcing
+// its per-fan limit
+func (t *tables) claimGift(giftID, fromID string) error {
+	gift, ok := t.gifts[giftID]
+	if !ok || gift.DeletedAt.Valid {
+		return domain.ErrGiftNotFound
+	}
+
+	if gift.PerFanLimit != nil {
+		// Sends are the sender's debits for the gift, and refunds credit them back
+		var sent int64
+		for _, entry := range t.transactions {
+			if entry.WalletID != fromID || entry.GiftID != giftID {
+				continue
+			}
+			switch {
+			case entry.Type == domain.TransactionDebit && entry.RefundID == "":
+				sent++
+			case entry.Type == domain.TransactionCredit && entry.RefundID != "":
+				sent--
+			}
+		}
+		if sent >= *gift.PerFanLimit {
+			return domain.ErrGiftLimitReached
+		}
+	}
+
+	if gift.Quantity != nil && gift.Sold >= *gift.Quantity {
+		return domain.ErrGiftSoldOut
+	}
+	gift.Sold++
+	t.gifts[giftID] = gift
+	return nil
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRepository struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) domain.WebhookRepository {
+	return &WebhookRepository{store: store}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook domain.Webhook) error {
+	return r.store.write(ctx, func(t *tables) error {
+		return insert(t.webhooks, webhook.ID, webhook)
+	})
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := r.store.read(ctx, func(t *tables) error {
+		var ok bool
+		if webhook, ok = t.webhooks[id]; !ok {
+			return domain.ErrWebhookNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context, artistID string) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := r.store.read(ctx, func(t *tables) error {
+		webhooks = t.artistWebhooks(artistID)
+		return nil
+	})
+	return webhooks, err
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	return r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.webhooks[id]; !ok {
+			return domain.ErrWebhookNotFound
+		}
+		for deliveryID, delivery := range t.deliveries {
+			if delivery.WebhookID == id {
+				delete(t.deliveries, deliveryID)
+			}
+		}
+		delete(t.webhooks, id)
+		return nil
+	})
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, artistID, eventID, eventType string, payload []byte) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.store.write(ctx, func(t *tables) error {
+		now := time.Now()
+		for _, webhook := range t.artistWebhooks(artistID) {
+			delivery := domain.WebhookDelivery{
+				ID:            uuid.NewString(),
+				WebhookID:     webhook.ID,
+				EventID:       eventID,
+				EventType:     eventType,
+				Payload:       payload,
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: &now,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			deliveries = append(deliveries, delivery)
+			if _, err := t.delivery(webhook.ID, eventID); err == nil {
+				continue
+			}
+			t.deliveries[delivery.ID] = delivery
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, webhookID, eventID string) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.store.read(ctx, func(t *tables) (err error) {
+		delivery, err = t.delivery(webhookID, eventID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return r.store.write(ctx, func(t *tables) error {
+		stored, ok := t.deliveries[delivery.ID]
+		if !ok {
+			return nil
+		}
+		stored.Status = delivery.Status
+		stored.Attempts = delivery.Attempts
+		stored.ResponseStatus = delivery.ResponseStatus
+		stored.LastError = delivery.LastError
+		stored.NextAttemptAt = delivery.NextAttemptAt
+		stored.UpdatedAt = delivery.UpdatedAt
+		t.deliveries[delivery.ID] = stored
+		return nil
+	})
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]domain.WebhookDelivery, int64, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.store.read(ctx, func(t *tables) error {
+		deliveries = rows(t.deliveries,
+			func(delivery domain.WebhookDelivery) bool { return delivery.WebhookID == webhookID },
+			func(a, b domain.WebhookDelivery) int { return newestFirst(a.CreatedAt, b.CreatedAt, a.ID, b.ID) })
+		return nil
+	})
+	return page(deliveries, limit, offset), int64(len(deliveries)), err
+}
+
+// artistWebhooks returns the artist's webhooks, oldest first
+func (t *tables) artistWebhooks(artistID string) []domain.Webhook {
+	return rows(t.webhooks,
+		func(webhook domain.Webhook) bool { return webhook.ArtistID == artistID },
+		func(a, b domain.Webhook) int {
+			return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+		})
+}
+
+func (t *tables) delivery(webhookID, eventID string) (domain.WebhookDelivery, error) {
+	for _, delivery := range t.deliveries {
+		if delivery.WebhookID == webhookID && delivery.EventID == eventID {
+			return delivery, nil
+		}
+	}
+	return domain.WebhookDelivery{}, domain.ErrWebhookDeliveryNotFound
+}
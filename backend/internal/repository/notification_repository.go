@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) domain.NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+func (r *NotificationRepositoryImpl) GetPreferences(ctx context.Context, artistID string) (*domain.NotificationPreferences, error) {
+	var preferences domain.NotificationPreferences
+	err := conn(ctx, r.db).First(&preferences, "artist_id = ?", artistID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		defaults := domain.DefaultNotificationPreferences(artistID)
+		return &defaults, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+func (r *NotificationRepositoryImpl) SavePreferences(ctx context.Context, preferences domain.NotificationPreferences) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "artist_id"}}, UpdateAll: true}).
+		Create(&preferences).Error
+}
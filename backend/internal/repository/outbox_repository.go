@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+func (r *OutboxRepositoryImpl) Relay(ctx context.Context, limit int, publish func(ctx context.Context, events []domain.Event) error) (int, error) {
+	var events []domain.Event
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("occurred_at, id").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		if err := publish(ctx, events); err != nil {
+			return err
+		}
+
+		ids := make([]string, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		return tx.Model(&domain.Event{}).Where("id IN ?", ids).Update("published_at", time.Now()).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (r *OutboxRepositoryImpl) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("published_at < ?", before).Delete(&domain.Event{})
+	return result.RowsAffected, result.Error
+}
+
+// recordEvents writes events to the outbox as part of tx
+func recordEvents(tx *gorm.DB, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(&events).Error
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPayoutRepository(db *gorm.DB) domain.PayoutRepository {
+	return &PayoutRepositoryImpl{db: db}
+}
+
+func (r *PayoutRepositoryImpl) GetAccount(ctx context.Context, artistID string) (*domain.PayoutAccount, error) {
+	var account domain.PayoutAccount
+	err := conn(ctx, r.db).First(&account, "artist_id = ?", artistID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPayoutAccountNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *PayoutRepositoryImpl) SaveAccount(ctx context.Context, account domain.PayoutAccount) error {
+	return conn(ctx, r.db).Save(&account).Error
+}
+
+func (r *PayoutRepositoryImpl) DeleteAccount(ctx context.Context, artistID string) error {
+	result := conn(ctx, r.db).Delete(&domain.PayoutAccount{}, "artist_id = ?", artistID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPayoutAccountNotFound
+	}
+	return nil
+}
+
+func (r *PayoutRepositoryImpl) GetBalance(ctx context.Context, artistID, walletID string) (*domain.PayoutBalance, error) {
+	db := conn(ctx, r.db)
+	var wallet domain.Wallet
+	err := db.First(&wallet, "id = ?", walletID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return payoutBalance(db, artistID, &wallet)
+}
+
+func (r *PayoutRepositoryImpl) RequestPayout(ctx context.Context, payout domain.Payout) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The wallet lock also serializes the artist's concurrent requests, so
+		// the same earnings cannot be withdrawn twice
+		wallets, err := lockWallets(tx, payout.WalletID)
+		if err != nil {
+			return err
+		}
+		balance, err := payoutBalance(tx, payout.ArtistID, wallets[payout.WalletID])
+		if err != nil {
+			return err
+		}
+		if payout.Tokens > balance.Withdrawable {
+			return domain.ErrInsufficientEarnings
+		}
+
+		if err := tx.Create(&payout).Error; err != nil {
+			return err
+		}
+		return applyDelta(tx, wallets[payout.WalletID], -payout.Tokens, ledgerRef{payoutID: payout.ID})
+	})
+}
+
+func (r *PayoutRepositoryImpl) StartPayout(ctx context.Context, id, reviewerID string) (*domain.Payout, error) {
+	db := conn(ctx, r.db)
+	result := db.Model(&domain.Payout{}).
+		Where("id = ? AND status = ?", id, domain.PayoutRequested).
+		Updates(map[string]any{
+			"status":      domain.PayoutProcessing,
+			"reviewed_by": reviewerID,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	payout, err := getPayout(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrPayoutNotRequested
+	}
+	return payout, nil
+}
+
+func (r *PayoutRepositoryImpl) CompletePayout(ctx context.Context, id, providerRef string, events ...domain.Event) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Payout{}).
+			Where("id = ? AND status = ?", id, domain.PayoutProcessing).
+			Updates(map[string]any{
+				"status":       domain.PayoutPaid,
+				"provider_ref": providerRef,
+				"updated_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrPayoutNotFound
+		}
+		return recordEvents(tx, events)
+	})
+}
+
+func (r *PayoutRepositoryImpl) FailPayout(ctx context.Context, id, reason string) error {
+	return r.returnTokens(ctx, id, domain.PayoutProcessing, map[string]any{
+		"status":         domain.PayoutFailed,
+		"failure_reason": reason,
+	})
+}
+
+func (r *PayoutRepositoryImpl) RejectPayout(ctx context.Context, id, reviewerID, reason string) error {
+	return r.returnTokens(ctx, id, domain.PayoutRequested, map[string]any{
+		"status":         domain.PayoutRejected,
+		"reviewed_by":    reviewerID,
+		"failure_reason": reason,
+	})
+}
+
+// returnTokens applies updates to a payout in the from state and credits its
+// tokens back to the wallet they were taken from
+func (r *PayoutRepositoryImpl) returnTokens(ctx context.Context, id, from string, updates map[string]any) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		payout, err := getPayout(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+		if err != nil {
+			return err
+		}
+		if payout.Status != from {
+			return domain.ErrPayoutNotRequested
+		}
+
+		updates["updated_at"] = time.Now()
+		if err := tx.Model(payout).Updates(updates).Error; err != nil {
+			return err
+		}
+		wallets, err := lockWallets(tx, payout.WalletID)
+		if err != nil {
+			return err
+		}
+		return applyDelta(tx, wallets[payout.WalletID], payout.Tokens, ledgerRef{payoutID: payout.ID})
+	})
+}
+
+func (r *PayoutRepositoryImpl) ListProcessingPayouts(ctx context.Context, before time.Time, limit int) ([]domain.Payout, error) {
+	var payouts []domain.Payout
+	err := conn(ctx, r.db).
+		Where("status = ? AND updated_at < ?", domain.PayoutProcessing, before).
+		Order("updated_at, id").
+		Limit(limit).
+		Find(&payouts).Error
+	return payouts, err
+}
+
+func (r *PayoutRepositoryImpl) GetPayout(ctx context.Context, id string) (*domain.Payout, error) {
+	return getPayout(conn(ctx, r.db), id)
+}
+
+func (r *PayoutRepositoryImpl) ListPayouts(ctx context.Context, filter domain.PayoutFilter) ([]domain.Payout, int64, error) {
+	query := conn(ctx, r.db).Model(&domain.Payout{})
+	if filter.ArtistID != "" {
+		query = query.Where("artist_id = ?", filter.ArtistID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	payouts := []domain.Payout{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&payouts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return payouts, total, nil
+}
+
+func getPayout(db *gorm.DB, id string) (*domain.Payout, error) {
+	var payout domain.Payout
+	err := db.First(&payout, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPayoutNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+// earnedTokens sums a wallet's gift earnings from the ledger: a gift send
+// credits the artist, and its refund debits them with the refund_id set
+const earnedTokens = `SELECT COALESCE(SUM(CASE WHEN type = 'credit' THEN amount ELSE -amount END), 0)
+	FROM transactions
+	WHERE wallet_id = ? AND COALESCE(gift_id, '') <> ''
+		AND ((type = 'credit' AND COALESCE(refund_id, '') = '') OR (type = 'debit' AND COALESCE(refund_id, '') <> ''))`
+
+// payoutBalance computes an artist's earnings. What was earned but spent
+// since, e.g. on gifts to other artists, is not withdrawable.
+func payoutBalance(db *gorm.DB, artistID string, wallet *domain.Wallet) (*domain.PayoutBalance, error) {
+	var balance domain.PayoutBalance
+	if err := db.Raw(earnedTokens, wallet.ID).Scan(&balance.Earned).Error; err != nil {
+		return nil, err
+	}
+
+	var totals []struct {
+		Status string
+		Tokens int64
+	}
+	err := db.Model(&domain.Payout{}).
+		Select("status, SUM(tokens) AS tokens").
+		Where("artist_id = ? AND status IN ?", artistID, []string{domain.PayoutRequested, domain.PayoutProcessing, domain.PayoutPaid}).
+		Group("status").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, total := range totals {
+		if total.Status == domain.PayoutPaid {
+			balance.PaidOut += total.Tokens
+		} else {
+			balance.Pending += total.Tokens
+		}
+	}
+
+	balance.Withdrawable = max(0, min(wallet.Balance, balance.Earned-balance.PaidOut-balance.Pending))
+	return &balance, nil
+}
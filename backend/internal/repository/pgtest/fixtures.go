@@ -0,0 +1,98 @@
+//go:build integration
+
+package pgtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// The fixtures are written through the repositories, so they hold what the
+// application would have written. Every row gets a fresh ID, which keeps
+// tests sharing a database from seeing each other's rows.
+
+// Artist creates an account with the role
+func Artist(t testing.TB, db *gorm.DB, role domain.Role) domain.Artist {
+	t.Helper()
+	id := uuid.NewString()
+	artist := domain.Artist{
+		ID:           id,
+		Name:         "Artist " + id[:8],
+		Email:        id + "@example.test",
+		PasswordHash: "not-a-hash",
+		Role:         role,
+		CreatedAt:    time.Now(),
+	}
+	if err := repository.NewArtistRepository(db).CreateArtist(context.Background(), artist); err != nil {
+		t.Fatalf("create artist: %v", err)
+	}
+	return artist
+}
+
+// Wallet creates the owner's wallet, credited with tokens
+func Wallet(t testing.TB, db *gorm.DB, ownerID string, tokens int64) domain.Wallet {
+	t.Helper()
+	ctx := context.Background()
+	wallets := repository.NewWalletRepository(db)
+	wallet := domain.Wallet{ID: uuid.NewString(), OwnerID: ownerID, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := wallets.CreateWallet(ctx, wallet); err != nil {
+		t.Fatalf("create wallet: %v", err)
+	}
+	if tokens > 0 {
+		credited, err := wallets.AdjustBalance(ctx, wallet.ID, tokens)
+		if err != nil {
+			t.Fatalf("credit wallet: %v", err)
+		}
+		wallet = *credited
+	}
+	return wallet
+}
+
+// Gift creates a gift of the artist from the fields set on gift, pricing it
+// at one dollar unless a price is given
+func Gift(t testing.TB, db *gorm.DB, artistID string, gift domain.Gift) domain.Gift {
+	t.Helper()
+	gift.ID = uuid.NewString()
+	gift.ArtistID = artistID
+	gift.CreatedAt = time.Now()
+	gift.Version = 1
+	if gift.Name == "" {
+		gift.Name = "Gift " + gift.ID[:8]
+	}
+	if gift.PriceMinor == 0 {
+		gift.PriceMinor, gift.Currency = 100, "USD"
+	}
+	if err := repository.NewGiftRepository(db).CreateGift(context.Background(), gift); err != nil {
+		t.Fatalf("create gift: %v", err)
+	}
+	return gift
+}
+
+// Purchase creates a pending purchase of tokens by the buyer
+func Purchase(t testing.TB, db *gorm.DB, buyerID string, tokens int64) domain.Purchase {
+	t.Helper()
+	purchase := domain.Purchase{
+		ID:          uuid.NewString(),
+		BuyerID:     buyerID,
+		PackageID:   uuid.NewString(),
+		Tokens:      tokens,
+		AmountMinor: tokens,
+		Currency:    "USD",
+		Provider:    "test",
+		ProviderRef: "cs_" + uuid.NewString(),
+		Status:      domain.PurchasePending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := repository.NewPurchaseRepository(db).CreatePurchase(context.Background(), purchase); err != nil {
+		t.Fatalf("create purchase: %v", err)
+	}
+	return purchase
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPurchaseRepository(db *gorm.DB) domain.PurchaseRepository {
+	return &PurchaseRepositoryImpl{db: db}
+}
+
+func (r *PurchaseRepositoryImpl) CreateTokenPackage(ctx context.Context, pkg domain.TokenPackage) error {
+	return conn(ctx, r.db).Create(&pkg).Error
+}
+
+func (r *PurchaseRepositoryImpl) GetTokenPackage(ctx context.Context, id string) (*domain.TokenPackage, error) {
+	var pkg domain.TokenPackage
+	err := conn(ctx, r.db).First(&pkg, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTokenPackageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+func (r *PurchaseRepositoryImpl) ListTokenPackages(ctx context.Context) ([]domain.TokenPackage, error) {
+	packages := []domain.TokenPackage{}
+	err := conn(ctx, r.db).Where("active = ?", true).Order("amount_minor").Find(&packages).Error
+	return packages, err
+}
+
+func (r *PurchaseRepositoryImpl) CreatePurchase(ctx context.Context, purchase domain.Purchase) error {
+	return conn(ctx, r.db).Create(&purchase).Error
+}
+
+func (r *PurchaseRepositoryImpl) GetPurchase(ctx context.Context, id string) (*domain.Purchase, error) {
+	return getPurchase(conn(ctx, r.db), id)
+}
+
+func (r *PurchaseRepositoryImpl) SetProviderRef(ctx context.Context, id, providerRef string) error {
+	return conn(ctx, r.db).Model(&domain.Purchase{}).Where("id = ?", id).Update("provider_ref", providerRef).Error
+}
+
+func (r *PurchaseRepositoryImpl) CompletePurchase(ctx context.Context, id, walletID string, events ...domain.Event) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		purchase, err := getPurchase(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+		if err != nil {
+			return err
+		}
+		// Providers retry webhooks, so a purchase may be completed more than
+		// once, even after it was refunded; only an open one is credited
+		result := tx.Model(purchase).
+			Where("status IN ?", []string{domain.PurchasePending, domain.PurchaseFailed}).
+			Update("status", domain.PurchaseSucceeded)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		wallets, err := lockWallets(tx, walletID)
+		if err != nil {
+			return err
+		}
+		if err := applyDelta(tx, wallets[walletID], purchase.Tokens, ledgerRef{purchaseID: purchase.ID}); err != nil {
+			return err
+		}
+		return recordEvents(tx, events)
+	})
+}
+
+func (r *PurchaseRepositoryImpl) FailPurchase(ctx context.Context, id string) error {
+	return conn(ctx, r.db).Model(&domain.Purchase{}).
+		Where("id = ? AND status = ?", id, domain.PurchasePending).
+		Update("status", domain.PurchaseFailed).Error
+}
+
+func (r *PurchaseRepositoryImpl) ListPendingPurchases(ctx context.Context, before time.Time, limit int) ([]domain.Purchase, error) {
+	var purchases []domain.Purchase
+	err := conn(ctx, r.db).
+		Where("status = ? AND created_at < ? AND provider_ref <> ''", domain.PurchasePending, before).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&purchases).Error
+	return purchases, err
+}
+
+func getPurchase(db *gorm.DB, id string) (*domain.Purchase, error) {
+	var purchase domain.Purchase
+	err := db.First(&purchase, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPurchaseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository"
+	"tokentide/internal/repository/pgtest"
+)
+
+func TestConcurrentCompletionsCreditPurchaseOnce(t *testing.T) {
+	ctx := context.Background()
+	purchases := repository.NewPurchaseRepository(db)
+	buyer := pgtest.Artist(t, db, domain.RoleFan)
+	wallet := pgtest.Wallet(t, db, buyer.ID, 0)
+	purchase := pgtest.Purchase(t, db, buyer.ID, 500)
+
+	// Redelivered webhooks racing to complete the same purchase
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := purchases.CompletePurchase(ctx, purchase.ID, wallet.ID); err != nil {
+				t.Errorf("CompletePurchase: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertBalance(t, wallet.ID, 500)
+	stored, err := purchases.GetPurchase(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("GetPurchase: %v", err)
+	}
+	if stored.Status != domain.PurchaseSucceeded {
+		t.Errorf("status = %s, want %s", stored.Status, domain.PurchaseSucceeded)
+	}
+}
+
+func TestCompletePurchaseTransitions(t *testing.T) {
+	for _, tc := range []struct {
+		from       string
+		wantStatus string
+		credited   bool
+	}{
+		{from: domain.PurchasePending, wantStatus: domain.PurchaseSucceeded, credited: true},
+		{from: domain.PurchaseFailed, wantStatus: domain.PurchaseSucceeded, credited: true},
+		{from: domain.PurchaseSucceeded, wantStatus: domain.PurchaseSucceeded},
+		{from: domain.PurchaseRefunded, wantStatus: domain.PurchaseRefunded},
+	} {
+		t.Run(tc.from, func(t *testing.T) {
+			ctx := context.Background()
+			purchases := repository.NewPurchaseRepository(db)
+			buyer := pgtest.Artist(t, db, domain.RoleFan)
+			wallet := pgtest.Wallet(t, db, buyer.ID, 0)
+			purchase := pgtest.Purchase(t, db, buyer.ID, 500)
+			err := db.Model(&domain.Purchase{}).Where("id = ?", purchase.ID).Update("status", tc.from).Error
+			if err != nil {
+				t.Fatalf("set status: %v", err)
+			}
+
+			if err := purchases.CompletePurchase(ctx, purchase.ID, wallet.ID); err != nil {
+				t.Fatalf("CompletePurchase: %v", err)
+			}
+
+			stored, err := purchases.GetPurchase(ctx, purchase.ID)
+			if err != nil {
+				t.Fatalf("GetPurchase: %v", err)
+			}
+			if stored.Status != tc.wantStatus {
+				t.Errorf("status = %s, want %s", stored.Status, tc.wantStatus)
+			}
+			want := int64(0)
+			if tc.credited {
+				want = 500
+			}
+			assertBalance(t, wallet.ID, want)
+		})
+	}
+}
+
+// assertBalance checks a wallet's balance and that its ledger adds up to it
+func assertBalance(t *testing.T, walletID string, want int64) {
+	t.Helper()
+	wallet, err := repository.NewWalletRepository(db).GetWalletByID(context.Background(), walletID)
+	if err != nil {
+		t.Fatalf("GetWalletByID: %v", err)
+	}
+	if wallet.Balance != want {
+		t.Errorf("balance = %d, want %d", wallet.Balance, want)
+	}
+
+	var ledger int64
+	err = db.Model(&domain.Transaction{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE -amount END), 0)", domain.TransactionCredit).
+		Where("wallet_id = ?", walletID).
+		Scan(&ledger).Error
+	if err != nil {
+		t.Fatalf("sum ledger: %v", err)
+	}
+	if ledger != want {
+		t.Errorf("ledger total = %d, want %d", ledger, want)
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) domain.RefundRepository {
+	return &RefundRepositoryImpl{db: db}
+}
+
+func (r *RefundRepositoryImpl) RefundGiftSend(ctx context.Context, refund domain.Refund, events ...domain.Event) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Refunds are never deleted, so an existing one means this send was refunded
+		var refunded int64
+		if err := tx.Model(&domain.Refund{}).Where("transaction_id = ?", refund.TransactionID).Count(&refunded).Error; err != nil {
+			return err
+		}
+		if refunded > 0 {
+			return domain.ErrAlreadyRefunded
+		}
+		if err := tx.Create(&refund).Error; err != nil {
+			return err
+		}
+
+		wallets, err := lockWallets(tx, refund.WalletID, refund.CounterpartyWalletID)
+		if err != nil {
+			return err
+		}
+		artistRef := ledgerRef{counterpartyID: refund.WalletID, giftID: refund.GiftID, refundID: refund.ID}
+		if err := applyDelta(tx, wallets[refund.CounterpartyWalletID], -refund.Tokens, artistRef); err != nil {
+			return err
+		}
+		senderRef := ledgerRef{counterpartyID: refund.CounterpartyWalletID, giftID: refund.GiftID, refundID: refund.ID}
+		if err := applyDelta(tx, wallets[refund.WalletID], refund.Tokens, senderRef); err != nil {
+			return err
+		}
+		// The refunded send goes back into the gift's stock, even if it was deleted since
+		err = tx.Unscoped().Model(&domain.Gift{}).
+			Where("id = ? AND sold > 0", refund.GiftID).
+			Update("sold", gorm.Expr("sold - 1")).Error
+		if err != nil {
+			return err
+		}
+		return recordEvents(tx, events)
+	})
+	// The unique index catches a concurrent refund of the same send
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrAlreadyRefunded
+	}
+	return err
+}
+
+func (r *RefundRepositoryImpl) StartPurchaseRefund(ctx context.Context, refund domain.Refund) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		purchase, err := getPurchase(tx.Clauses(clause.Locking{Strength: "UPDATE"}), refund.PurchaseID)
+		if err != nil {
+			return err
+		}
+		switch purchase.Status {
+		case domain.PurchaseSucceeded:
+		case domain.PurchaseRefunded:
+			return domain.ErrAlreadyRefunded
+		default:
+			return domain.ErrNotRefundable
+		}
+
+		if err := tx.Model(purchase).Update("status", domain.PurchaseRefunded).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&refund).Error; err != nil {
+			return err
+		}
+
+		wallets, err := lockWallets(tx, refund.WalletID)
+		if err != nil {
+			return err
+		}
+		return applyDelta(tx, wallets[refund.WalletID], -refund.Tokens, ledgerRef{purchaseID: purchase.ID, refundID: refund.ID})
+	})
+}
+
+func (r *RefundRepositoryImpl) CompleteRefund(ctx context.Context, id, providerRef string, events ...domain.Event) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Refund{}).
+			Where("id = ? AND status = ?", id, domain.RefundPending).
+			Updates(map[string]any{
+				"status":       domain.RefundSucceeded,
+				"provider_ref": providerRef,
+				"updated_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRefundNotFound
+		}
+		return recordEvents(tx, events)
+	})
+}
+
+func (r *RefundRepositoryImpl) FailPurchaseRefund(ctx context.Context, id, reason string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		refund, err := getRefund(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+		if err != nil {
+			return err
+		}
+		if refund.Status != domain.RefundPending {
+			return nil
+		}
+
+		err = tx.Model(refund).Updates(map[string]any{
+			"status":         domain.RefundFailed,
+			"failure_reason": reason,
+			"updated_at":     time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&domain.Purchase{}).
+			Where("id = ? AND status = ?", refund.PurchaseID, domain.PurchaseRefunded).
+			Update("status", domain.PurchaseSucceeded).Error
+		if err != nil {
+			return err
+		}
+
+		wallets, err := lockWallets(tx, refund.WalletID)
+		if err != nil {
+			return err
+		}
+		return applyDelta(tx, wallets[refund.WalletID], refund.Tokens, ledgerRef{purchaseID: refund.PurchaseID, refundID: refund.ID})
+	})
+}
+
+func (r *RefundRepositoryImpl) ListPendingRefunds(ctx context.Context, before time.Time, limit int) ([]domain.Refund, error) {
+	var refunds []domain.Refund
+	err := conn(ctx, r.db).
+		Where("kind = ? AND status = ? AND created_at < ?", domain.RefundPurchase, domain.RefundPending, before).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *RefundRepositoryImpl) GetRefund(ctx context.Context, id string) (*domain.Refund, error) {
+	return getRefund(conn(ctx, r.db), id)
+}
+
+func (r *RefundRepositoryImpl) ListRefunds(ctx context.Context, filter domain.RefundFilter) ([]domain.Refund, int64, error) {
+	query := conn(ctx, r.db).Model(&domain.Refund{})
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	refunds := []domain.Refund{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&refunds).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return refunds, total, nil
+}
+
+func getRefund(db *gorm.DB, id string) (*domain.Refund, error) {
+	var refund domain.Refund
+	err := db.First(&refund, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRefundNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
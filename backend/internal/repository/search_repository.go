@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// SearchRepositoryImpl searches the search_vector columns Postgres keeps up
+// to date on gifts and artists
+type SearchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) domain.SearchRepository {
+	return &SearchRepositoryImpl{db: db}
+}
+
+// searchSources are the queries matching each kind of result; only artists,
+// not fans or admins, can be found
+var searchSources = map[string]string{
+	domain.SearchGift: `SELECT 'gift' AS type, id, name, ts_rank(search_vector, query) AS rank
+		FROM gifts, websearch_to_tsquery('simple', @text) query
+		WHERE search_vector @@ query AND deleted_at IS NULL`,
+	domain.SearchArtist: `SELECT 'artist' AS type, id, name, ts_rank(search_vector, query) AS rank
+		FROM artists, websearch_to_tsquery('simple', @text) query
+		WHERE search_vector @@ query AND role = 'artist' AND deleted_at IS NULL`,
+}
+
+func (r *SearchRepositoryImpl) Search(ctx context.Context, query domain.SearchQuery) ([]domain.SearchResult, int64, error) {
+	matches := searchSources[domain.SearchGift] + " UNION ALL " + searchSources[domain.SearchArtist]
+	if source, ok := searchSources[query.Type]; ok {
+		matches = source
+	}
+	args := map[string]any{"text": query.Text, "limit": query.Limit, "offset": query.Offset}
+
+	var total int64
+	if err := read(ctx, r.db).Raw("SELECT count(*) FROM ("+matches+") matches", args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := []domain.SearchResult{}
+	err := read(ctx, r.db).
+		Raw("SELECT * FROM ("+matches+") matches ORDER BY rank DESC, name, id LIMIT @limit OFFSET @offset", args).
+		Scan(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) domain.SessionRepository {
+	return &SessionRepositoryImpl{db: db}
+}
+
+func (r *SessionRepositoryImpl) CreateSession(ctx context.Context, session domain.Session) error {
+	return conn(ctx, r.db).Create(&session).Error
+}
+
+func (r *SessionRepositoryImpl) GetSession(ctx context.Context, id string) (*domain.Session, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *SessionRepositoryImpl) GetSessionByTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	return r.first(ctx, "token_hash = ? OR previous_token_hash = ?", hash, hash)
+}
+
+func (r *SessionRepositoryImpl) first(ctx context.Context, query string, args ...any) (*domain.Session, error) {
+	var session domain.Session
+	err := conn(ctx, r.db).Where(query, args...).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepositoryImpl) RotateSession(ctx context.Context, session domain.Session, oldHash string) error {
+	// Conditional on the old token, so of two refreshes racing with the same
+	// token only one wins
+	result := conn(ctx, r.db).Model(&domain.Session{}).
+		Where("id = ? AND token_hash = ? AND revoked_at IS NULL", session.ID, oldHash).
+		Updates(map[string]any{
+			"token_hash":          session.TokenHash,
+			"previous_token_hash": oldHash,
+			"user_agent":          session.UserAgent,
+			"ip":                  session.IP,
+			"last_used_at":        session.LastUsedAt,
+			"expires_at":          session.ExpiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+func (r *SessionRepositoryImpl) ListSessions(ctx context.Context, artistID string, now time.Time) ([]domain.Session, error) {
+	sessions := []domain.Session{}
+	err := conn(ctx, r.db).
+		Where("artist_id = ? AND revoked_at IS NULL AND expires_at > ?", artistID, now).
+		Order("last_used_at DESC, id").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *SessionRepositoryImpl) RevokeSession(ctx context.Context, id string, at time.Time) error {
+	result := conn(ctx, r.db).Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Revoking twice is harmless, but the session must exist
+		_, err := r.GetSession(ctx, id)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ShortLinkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewShortLinkRepository(db *gorm.DB) domain.ShortLinkRepository {
+	return &ShortLinkRepositoryImpl{db: db}
+}
+
+func (r *ShortLinkRepositoryImpl) CreateShortLink(ctx context.Context, link domain.ShortLink) error {
+	err := conn(ctx, r.db).Create(&link).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrShortLinkExists
+	}
+	return err
+}
+
+func (r *ShortLinkRepositoryImpl) GetShortLinkByCode(ctx context.Context, code string) (*domain.ShortLink, error) {
+	var link domain.ShortLink
+	err := conn(ctx, r.db).Where("code = ?", code).First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrShortLinkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ShortLinkRepositoryImpl) RecordClick(ctx context.Context, click domain.ShortLinkClick) error {
+	return conn(ctx, r.db).Create(&click).Error
+}
+
+type clickCount struct {
+	Label string
+	Count int64
+}
+
+func (r *ShortLinkRepositoryImpl) GetClickStats(ctx context.Context, linkID string) (*domain.ShortLinkStats, error) {
+	stats := &domain.ShortLinkStats{
+		ByReferrer: map[string]int64{},
+		ByCountry:  map[string]int64{},
+	}
+
+	clicks := conn(ctx, r.db).Model(&domain.ShortLinkClick{}).Where("short_link_id = ?", linkID)
+	if err := clicks.Session(&gorm.Session{}).Count(&stats.TotalClicks).Error; err != nil {
+		return nil, err
+	}
+
+	for column, into := range map[string]map[string]int64{"referrer": stats.ByReferrer, "country": stats.ByCountry} {
+		var counts []clickCount
+		err := clicks.Session(&gorm.Session{}).
+			Select(column + " AS label, COUNT(*) AS count").
+			Group(column).
+			Scan(&counts).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range counts {
+			into[c.Label] = c.Count
+		}
+	}
+
+	return stats, nil
+}
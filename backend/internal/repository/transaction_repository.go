@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) domain.TransactionRepository {
+	return &TransactionRepositoryImpl{db: db}
+}
+
+func (r *TransactionRepositoryImpl) ListTransactions(ctx context.Context, walletID string, limit, offset int) ([]domain.Transaction, int64, error) {
+	query := conn(ctx, r.db).Model(&domain.Transaction{}).Where("wallet_id = ?", walletID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	transactions := []domain.Transaction{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return transactions, total, nil
+}
+
+func (r *TransactionRepositoryImpl) GetTransaction(ctx context.Context, id string) (*domain.Transaction, error) {
+	var entry domain.Transaction
+	err := conn(ctx, r.db).First(&entry, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ledgerRef links a ledger entry to what caused the balance change
+type ledgerRef struct {
+	counterpartyID string
+	giftID         string
+	purchaseID     string
+	refundID       string
+	payoutID       string
+}
+
+// recordTransaction appends a ledger entry for a balance change already applied to wallet
+func recordTransaction(tx *gorm.DB, wallet *domain.Wallet, delta int64, ref ledgerRef) error {
+	entry := domain.Transaction{
+		ID:                   uuid.NewString(),
+		WalletID:             wallet.ID,
+		Type:                 domain.TransactionCredit,
+		Amount:               delta,
+		BalanceAfter:         wallet.Balance,
+		CounterpartyWalletID: ref.counterpartyID,
+		GiftID:               ref.giftID,
+		PurchaseID:           ref.purchaseID,
+		RefundID:             ref.refundID,
+		PayoutID:             ref.payoutID,
+		CreatedAt:            time.Now(),
+	}
+	if delta < 0 {
+		entry.Type = domain.TransactionDebit
+		entry.Amount = -delta
+	}
+
+	return tx.Create(&entry).Error
+}
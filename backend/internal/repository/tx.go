@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/dbresolver"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type TxManagerImpl struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) domain.TxManager {
+	return &TxManagerImpl{db: db}
+}
+
+func (m *TxManagerImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction ctx carries, or db outside of one. Every
+// repository query goes through it so services can group them in a unit of
+// work; a repository's own Transaction becomes a savepoint inside one.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// read is conn for listing and search queries, which tolerate replication
+// lag and are served by a read replica when one is configured. Queries whose
+// result must reflect a write just made stay on conn.
+func read(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return dbresolver.Read(conn(ctx, db))
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"tokentide/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WalletRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) domain.WalletRepository {
+	return &WalletRepositoryImpl{db: db}
+}
+
+func (r *WalletRepositoryImpl) CreateWallet(ctx context.Context, wallet domain.Wallet) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "owner_id"}}, DoNothing: true}).
+		Create(&wallet).Error
+}
+
+func (r *WalletRepositoryImpl) GetWalletByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	return r.first(conn(ctx, r.db), "id = ?", id)
+}
+
+func (r *WalletRepositoryImpl) GetWalletByOwner(ctx context.Context, ownerID string) (*domain.Wallet, error) {
+	return r.first(conn(ctx, r.db), "owner_id = ?", ownerID)
+}
+
+func (r *WalletRepositoryImpl) AdjustBalance(ctx context.Context, id string, delta int64) (*domain.Wallet, error) {
+	var wallet *domain.Wallet
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		wallets, err := lockWallets(tx, id)
+		if err != nil {
+			return err
+		}
+
+		wallet = wallets[id]
+		return applyDelta(tx, wallet, delta, ledgerRef{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (r *WalletRepositoryImpl) Transfer(ctx context.Context, fromID, toID string, amount int64, giftID string, events ...domain.Event) (*domain.Wallet, error) {
+	var from *domain.Wallet
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		wallets, err := lockWallets(tx, fromID, toID)
+		if err != nil {
+			return err
+		}
+
+		from = wallets[fromID]
+		if giftID != "" {
+			if err := claimGift(tx, giftID, fromID); err != nil {
+				return err
+			}
+		}
+		if err := applyDelta(tx, from, -amount, ledgerRef{counterpartyID: toID, giftID: giftID}); err != nil {
+			return err
+		}
+		if err := applyDelta(tx, wallets[toID], amount, ledgerRef{counterpartyID: fromID, giftID: giftID}); err != nil {
+			return err
+		}
+		return recordEvents(tx, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return from, nil
+}
+
+func (r *WalletRepositoryImpl) first(db *gorm.DB, query string, args ...any) (*domain.Wallet, error) {
+	var wallet domain.Wallet
+	err := db.Where(query, args...).First(&wallet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// lockWallets selects the wallets FOR UPDATE in ID order, so concurrent
+// transfers between the same wallets cannot deadlock
+func lockWallets(tx *gorm.DB, ids ...string) (map[string]*domain.Wallet, error) {
+	var rows []domain.Wallet
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	wallets := make(map[string]*domain.Wallet, len(rows))
+	for i := range rows {
+		wallets[rows[i].ID] = &rows[i]
+	}
+	for _, id := range ids {
+		if _, ok := wallets[id]; !ok {
+			return nil, domain.ErrWalletNotFound
+		}
+	}
+	return wallets, nil
+}
+
+// claimGift takes one of a gift's stock for a send from the wallet, enforcing
+// its per-fan limit. Callers hold the sender's wallet lock, so the sender's
+// concurrent sends cannot both pass the limit, and the conditional update
+// keeps concurrent senders from selling more than the quantity.
+func claimGift(tx *gorm.DB, giftID, fromID string) error {
+	var gift domain.Gift
+	err := tx.Select("id", "per_fan_limit").First(&gift, "id = ?", giftID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrGiftNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if gift.PerFanLimit != nil {
+		// Sends are the sender's debits for the gift, and refunds credit them back
+		var sent int64
+		err := tx.Model(&domain.Transaction{}).
+			Select("COALESCE(SUM(CASE WHEN type = ? THEN 1 ELSE -1 END), 0)", domain.TransactionDebit).
+			Where("wallet_id = ? AND gift_id = ?", fromID, giftID).
+			Where("(type = ? AND COALESCE(refund_id, '') = '') OR (type = ? AND COALESCE(refund_id, '') <> '')", domain.TransactionDebit, domain.TransactionCredit).
+			Scan(&sent).Error
+		if err != nil {
+			return err
+		}
+		if sent >= *gift.PerFanLimit {
+			return domain.ErrGiftLimitReached
+		}
+	}
+
+	result := tx.Model(&domain.Gift{}).
+		Where("id = ? AND (quantity IS NULL OR sold < quantity)", giftID).
+		Update("sold", gorm.Expr("sold + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrGiftSoldOut
+	}
+	return nil
+}
+
+// applyDelta updates a locked wallet's balance and records it in the ledger
+func applyDelta(tx *gorm.DB, wallet *domain.Wallet, delta int64, ref ledgerRef) error {
+	if wallet.Balance+delta < 0 {
+		return domain.ErrInsufficientFunds
+	}
+
+	wallet.Balance += delta
+	if err := tx.Model(wallet).Update("balance", wallet.Balance).Error; err != nil {
+		return err
+	}
+	return recordTransaction(tx, wallet, delta, ref)
+}
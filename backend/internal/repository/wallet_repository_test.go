@@ -0,0 +1,142 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository"
+	"tokentide/internal/repository/pgtest"
+)
+
+func TestConcurrentSendsDoNotOversellGift(t *testing.T) {
+	ctx := context.Background()
+	wallets := repository.NewWalletRepository(db)
+	artist := pgtest.Artist(t, db, domain.RoleArtist)
+	artistWallet := pgtest.Wallet(t, db, artist.ID, 0)
+	quantity := int64(3)
+	gift := pgtest.Gift(t, db, artist.ID, domain.Gift{Quantity: &quantity})
+
+	const fans = 10
+	errs := make([]error, fans)
+	var wg sync.WaitGroup
+	for i := range fans {
+		fan := pgtest.Wallet(t, db, pgtest.Artist(t, db, domain.RoleFan).ID, gift.PriceMinor)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = wallets.Transfer(ctx, fan.ID, artistWallet.ID, gift.PriceMinor, gift.ID)
+		}()
+	}
+	wg.Wait()
+
+	sent := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			sent++
+		case !errors.Is(err, domain.ErrGiftSoldOut):
+			t.Errorf("Transfer error = %v, want nil or %v", err, domain.ErrGiftSoldOut)
+		}
+	}
+	if sent != int(quantity) {
+		t.Errorf("sends = %d, want %d", sent, quantity)
+	}
+
+	stored, err := repository.NewGiftRepository(db).GetGiftByID(ctx, gift.ID)
+	if err != nil {
+		t.Fatalf("GetGiftByID: %v", err)
+	}
+	if stored.Sold != quantity {
+		t.Errorf("sold = %d, want %d", stored.Sold, quantity)
+	}
+	credited, err := wallets.GetWalletByID(ctx, artistWallet.ID)
+	if err != nil {
+		t.Fatalf("GetWalletByID: %v", err)
+	}
+	if credited.Balance != quantity*gift.PriceMinor {
+		t.Errorf("artist balance = %d, want %d", credited.Balance, quantity*gift.PriceMinor)
+	}
+}
+
+func TestConcurrentSendsRespectPerFanLimit(t *testing.T) {
+	ctx := context.Background()
+	wallets := repository.NewWalletRepository(db)
+	artist := pgtest.Artist(t, db, domain.RoleArtist)
+	artistWallet := pgtest.Wallet(t, db, artist.ID, 0)
+	limit := int64(2)
+	gift := pgtest.Gift(t, db, artist.ID, domain.Gift{PerFanLimit: &limit})
+	fan := pgtest.Wallet(t, db, pgtest.Artist(t, db, domain.RoleFan).ID, 10*gift.PriceMinor)
+
+	const attempts = 6
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = wallets.Transfer(ctx, fan.ID, artistWallet.ID, gift.PriceMinor, gift.ID)
+		}()
+	}
+	wg.Wait()
+
+	sent := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			sent++
+		case !errors.Is(err, domain.ErrGiftLimitReached):
+			t.Errorf("Transfer error = %v, want nil or %v", err, domain.ErrGiftLimitReached)
+		}
+	}
+	if sent != int(limit) {
+		t.Errorf("sends = %d, want %d", sent, limit)
+	}
+}
+
+func TestOpposingTransfersDoNotDeadlock(t *testing.T) {
+	ctx := context.Background()
+	wallets := repository.NewWalletRepository(db)
+	a := pgtest.Wallet(t, db, pgtest.Artist(t, db, domain.RoleFan).ID, 1000)
+	b := pgtest.Wallet(t, db, pgtest.Artist(t, db, domain.RoleFan).ID, 1000)
+
+	// Each transfer locks both wallets; locking them in ID order keeps
+	// transfers in opposite directions from waiting on each other
+	const rounds = 20
+	errs := make(chan error, 2*rounds)
+	var wg sync.WaitGroup
+	for range rounds {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := wallets.Transfer(ctx, a.ID, b.ID, 10, "")
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := wallets.Transfer(ctx, b.ID, a.ID, 10, "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Transfer: %v", err)
+		}
+	}
+	for _, id := range []string{a.ID, b.ID} {
+		wallet, err := wallets.GetWalletByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetWalletByID: %v", err)
+		}
+		if wallet.Balance != 1000 {
+			t.Errorf("wallet %s balance = %d, want 1000", id, wallet.Balance)
+		}
+	}
+}
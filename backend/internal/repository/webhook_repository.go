@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tokentide/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) domain.WebhookRepository {
+	return &WebhookRepositoryImpl{db: db}
+}
+
+func (r *WebhookRepositoryImpl) CreateWebhook(ctx context.Context, webhook domain.Webhook) error {
+	return conn(ctx, r.db).Create(&webhook).Error
+}
+
+func (r *WebhookRepositoryImpl) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := conn(ctx, r.db).First(&webhook, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepositoryImpl) ListWebhooks(ctx context.Context, artistID string) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+	err := conn(ctx, r.db).Where("artist_id = ?", artistID).Order("created_at, id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepositoryImpl) DeleteWebhook(ctx context.Context, id string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Webhook{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepositoryImpl) EnqueueDeliveries(ctx context.Context, artistID, eventID, eventType string, payload []byte) ([]domain.WebhookDelivery, error) {
+	webhooks, err := r.ListWebhooks(ctx, artistID)
+	if err != nil || len(webhooks) == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := make([]domain.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = domain.WebhookDelivery{
+			ID:            uuid.NewString(),
+			WebhookID:     webhook.ID,
+			EventID:       eventID,
+			EventType:     eventType,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+	}
+	err = conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}}, DoNothing: true}).
+		Create(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepositoryImpl) GetDelivery(ctx context.Context, webhookID, eventID string) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := conn(ctx, r.db).First(&delivery, "webhook_id = ? AND event_id = ?", webhookID, eventID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepositoryImpl) UpdateDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	return conn(ctx, r.db).Model(&delivery).Select(
+		"status", "attempts", "response_status", "last_error", "next_attempt_at", "updated_at",
+	).Updates(&delivery).Error
+}
+
+func (r *WebhookRepositoryImpl) ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]domain.WebhookDelivery, int64, error) {
+	query := conn(ctx, r.db).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	deliveries := []domain.WebhookDelivery{}
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
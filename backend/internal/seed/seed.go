@@ -0,0 +1,168 @@
+// Package seed fills a development database with demo accounts, gifts and
+// wallets, so the API and the frontend have something to show.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository"
+	"tokentide/internal/service"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Password signs in every seeded account
+const Password = "tokentide-demo"
+
+type account struct {
+	name  string
+	email string
+	role  domain.Role
+	// tokens the account's wallet is credited with
+	tokens int64
+}
+
+type gift struct {
+	name        string
+	description string
+	priceMinor  int64
+	currency    string
+	artist      string
+	category    string
+	tags        []string
+	quantity    int64
+}
+
+var accounts = []account{
+	{name: "Luna Vega", email: "luna@demo.tokentide.dev", role: domain.RoleArtist},
+	{name: "The Midnight Owls", email: "owls@demo.tokentide.dev", role: domain.RoleArtist},
+	{name: "Kenji Mori", email: "kenji@demo.tokentide.dev", role: domain.RoleArtist},
+	{name: "Ada Fan", email: "ada@demo.tokentide.dev", role: domain.RoleFan, tokens: 5000},
+	{name: "Ben Fan", email: "ben@demo.tokentide.dev", role: domain.RoleFan, tokens: 1200},
+	{name: "Cleo Fan", email: "cleo@demo.tokentide.dev", role: domain.RoleFan, tokens: 300},
+}
+
+var categories = []string{"Flowers", "Music", "Food & Drink", "Collectibles"}
+
+var tags = []string{"Valentines", "Birthday", "Tour", "Limited"}
+
+var gifts = []gift{
+	{name: "Red Rose", description: "A single red rose thrown on stage", priceMinor: 199, currency: "USD", artist: "luna@demo.tokentide.dev", category: "Flowers", tags: []string{"Valentines"}},
+	{name: "Bouquet", description: "A dozen roses for the encore", priceMinor: 1999, currency: "USD", artist: "luna@demo.tokentide.dev", category: "Flowers", tags: []string{"Valentines", "Birthday"}},
+	{name: "Signed Setlist", description: "Tonight's setlist, signed by the band", priceMinor: 4900, currency: "EUR", artist: "owls@demo.tokentide.dev", category: "Collectibles", tags: []string{"Tour", "Limited"}, quantity: 50},
+	{name: "Encore Request", description: "Pick the song for the encore", priceMinor: 999, currency: "EUR", artist: "owls@demo.tokentide.dev", category: "Music", tags: []string{"Tour"}},
+	{name: "Coffee", description: "Keep the artist awake for the next session", priceMinor: 350, currency: "USD", artist: "kenji@demo.tokentide.dev", category: "Food & Drink"},
+	{name: "Birthday Cake", description: "A cake with candles for the stream", priceMinor: 2500, currency: "USD", artist: "kenji@demo.tokentide.dev", category: "Food & Drink", tags: []string{"Birthday"}},
+	{name: "Vinyl Test Press", description: "One of the first pressings of the new album", priceMinor: 12000, currency: "GBP", artist: "kenji@demo.tokentide.dev", category: "Collectibles", tags: []string{"Limited"}, quantity: 10},
+}
+
+// Run seeds the database in one transaction. It does nothing when the demo
+// accounts already exist, so it is safe to run again.
+func Run(ctx context.Context, db *gorm.DB) error {
+	artists := repository.NewArtistRepository(db)
+	_, err := artists.GetArtistByEmail(ctx, accounts[0].email)
+	if err == nil {
+		logging.FromContext(ctx).Info("Database already seeded")
+		return nil
+	}
+	if !errors.Is(err, domain.ErrArtistNotFound) {
+		return err
+	}
+
+	return repository.NewTxManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		ids, err := seedAccounts(ctx, artists, repository.NewWalletRepository(db))
+		if err != nil {
+			return err
+		}
+		return seedGifts(ctx, ids, service.NewCatalogService(repository.NewCatalogRepository(db)), service.NewGiftService(repository.NewGiftRepository(db), nil))
+	})
+}
+
+// seedAccounts creates the accounts and their wallets and returns the account
+// IDs by email
+func seedAccounts(ctx context.Context, artists domain.ArtistRepository, wallets domain.WalletRepository) (map[string]string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(accounts))
+	for _, a := range accounts {
+		now := time.Now()
+		artist := domain.Artist{
+			ID:           uuid.NewString(),
+			Name:         a.name,
+			Email:        a.email,
+			PasswordHash: string(hash),
+			Role:         a.role,
+			CreatedAt:    now,
+		}
+		if err := artists.CreateArtist(ctx, artist); err != nil {
+			return nil, fmt.Errorf("account %s: %w", a.email, err)
+		}
+		ids[a.email] = artist.ID
+
+		wallet := domain.Wallet{ID: uuid.NewString(), OwnerID: artist.ID, CreatedAt: now, UpdatedAt: now}
+		if err := wallets.CreateWallet(ctx, wallet); err != nil {
+			return nil, fmt.Errorf("wallet of %s: %w", a.email, err)
+		}
+		// Credited like an admin would, so the ledger explains the balance
+		if a.tokens > 0 {
+			if _, err := wallets.AdjustBalance(ctx, wallet.ID, a.tokens); err != nil {
+				return nil, fmt.Errorf("wallet of %s: %w", a.email, err)
+			}
+		}
+	}
+	logging.FromContext(ctx).Info("Seeded accounts", "count", len(accounts), "password", Password)
+	return ids, nil
+}
+
+// seedGifts creates the categories, tags and gifts of the artists in ids
+func seedGifts(ctx context.Context, ids map[string]string, catalog domain.CatalogService, giftService domain.GiftService) error {
+	categoryIDs := make(map[string]string, len(categories))
+	for _, name := range categories {
+		category, err := catalog.CreateCategory(ctx, domain.Category{Name: name})
+		if err != nil {
+			return fmt.Errorf("category %s: %w", name, err)
+		}
+		categoryIDs[name] = category.ID
+	}
+	tagIDs := make(map[string]string, len(tags))
+	for _, name := range tags {
+		tag, err := catalog.CreateTag(ctx, domain.Tag{Name: name})
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", name, err)
+		}
+		tagIDs[name] = tag.ID
+	}
+
+	for _, g := range gifts {
+		created := domain.Gift{
+			Name:        g.name,
+			Description: g.description,
+			PriceMinor:  g.priceMinor,
+			Currency:    g.currency,
+			ArtistID:    ids[g.artist],
+			Categories:  []domain.Category{{ID: categoryIDs[g.category]}},
+			Tags:        []domain.Tag{},
+		}
+		for _, name := range g.tags {
+			created.Tags = append(created.Tags, domain.Tag{ID: tagIDs[name]})
+		}
+		if g.quantity > 0 {
+			created.Quantity = &g.quantity
+		}
+		if _, err := giftService.CreateGift(ctx, created); err != nil {
+			return fmt.Errorf("gift %s: %w", g.name, err)
+		}
+	}
+	logging.FromContext(ctx).Info("Seeded gifts", "categories", len(categories), "tags", len(tags), "gifts", len(gifts))
+	return nil
+}
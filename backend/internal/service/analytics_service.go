@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+)
+
+// periodDays are the number of UTC days, today included, of each leaderboard period
+var periodDays = map[string]int{
+	domain.PeriodDay:   1,
+	domain.PeriodWeek:  7,
+	domain.PeriodMonth: 30,
+}
+
+type AnalyticsServiceImpl struct {
+	repo    domain.AnalyticsRepository
+	artists domain.ArtistRepository
+}
+
+func NewAnalyticsService(repo domain.AnalyticsRepository, artists domain.ArtistRepository) domain.AnalyticsService {
+	return &AnalyticsServiceImpl{repo: repo, artists: artists}
+}
+
+func (s *AnalyticsServiceImpl) GetLeaderboard(ctx context.Context, artistID, period string, limit int) ([]domain.Gifter, error) {
+	days, ok := periodDays[period]
+	if !ok {
+		return nil, domain.ErrInvalidPeriod
+	}
+	if _, err := s.artists.GetArtistByID(ctx, artistID); err != nil {
+		return nil, err
+	}
+	return s.repo.TopGifters(ctx, artistID, firstDay(time.Now(), days), limit)
+}
+
+func (s *AnalyticsServiceImpl) GetArtistStats(ctx context.Context, artistID string, days int) (*domain.ArtistStats, error) {
+	if _, err := s.artists.GetArtistByID(ctx, artistID); err != nil {
+		return nil, err
+	}
+
+	since := firstDay(time.Now(), days)
+	daily, err := s.repo.DailyStats(ctx, artistID, since)
+	if err != nil {
+		return nil, err
+	}
+	gifters, err := s.repo.CountGifters(ctx, artistID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in the days without gifts so the series has one point per day
+	stats := &domain.ArtistStats{ArtistID: artistID, Days: days, Gifters: gifters, Daily: make([]domain.DailyStats, days)}
+	byDay := make(map[time.Time]domain.DailyStats, len(daily))
+	for _, day := range daily {
+		byDay[day.Day.UTC().Truncate(24*time.Hour)] = day
+	}
+	for i := range stats.Daily {
+		day := since.AddDate(0, 0, i)
+		stats.Daily[i] = domain.DailyStats{Day: day, Gifts: byDay[day].Gifts, Tokens: byDay[day].Tokens}
+		stats.Gifts += stats.Daily[i].Gifts
+		stats.Tokens += stats.Daily[i].Tokens
+	}
+	return stats, nil
+}
+
+// RefreshStats recomputes yesterday and today, which covers every entry made
+// since the previous refresh, or every day on the first refresh
+func (s *AnalyticsServiceImpl) RefreshStats(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.RefreshStats")
+	defer tracing.End(span, &err)
+
+	since := firstDay(time.Now(), 2)
+	computed, err := s.repo.HasStats(ctx)
+	if err != nil {
+		return err
+	}
+	if !computed {
+		since = time.Time{}
+	}
+	if err := s.repo.RefreshStats(ctx, since); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Debug("Gift stats refreshed", "since", since)
+	return nil
+}
+
+// firstDay returns the start of the first of the last days UTC days, today included
+func firstDay(now time.Time, days int) time.Time {
+	return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyPrefixLen is how much of a key is kept in the clear to recognize it by
+	apiKeyPrefixLen = len(domain.APIKeyPrefix) + 8
+	// apiKeyTouchInterval limits how often a key's last use is written
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyServiceImpl struct {
+	repo    domain.APIKeyRepository
+	artists domain.ArtistRepository
+}
+
+func NewAPIKeyService(repo domain.APIKeyRepository, artists domain.ArtistRepository) domain.APIKeyService {
+	return &APIKeyServiceImpl{repo: repo, artists: artists}
+}
+
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, artistID, name string, scopes []string, expiresAt *time.Time) (*domain.APIKey, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(domain.APIKeyScopes, scope) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidAPIKeyScope, scope)
+		}
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, domain.ErrAPIKeyExpiry
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	plain := domain.APIKeyPrefix + hex.EncodeToString(secret)
+
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+	key := domain.APIKey{
+		ID:        uuid.NewString(),
+		ArtistID:  artistID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    plain[:apiKeyPrefixLen],
+		KeyHash:   hashToken(plain),
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("API key created", "api_key_id", key.ID, "artist_id", artistID, "scopes", key.Scopes)
+
+	key.Key = plain
+	return &key, nil
+}
+
+func (s *APIKeyServiceImpl) GetAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	return s.repo.GetAPIKey(ctx, id)
+}
+
+func (s *APIKeyServiceImpl) ListAPIKeys(ctx context.Context, artistID string) ([]domain.APIKey, error) {
+	return s.repo.ListAPIKeys(ctx, artistID)
+}
+
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	if err := s.repo.RevokeAPIKey(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("API key revoked", "api_key_id", id)
+	return s.repo.GetAPIKey(ctx, id)
+}
+
+func (s *APIKeyServiceImpl) VerifyAPIKey(ctx context.Context, plain string) (domain.Identity, error) {
+	if !strings.HasPrefix(plain, domain.APIKeyPrefix) {
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+	key, err := s.repo.GetAPIKeyByHash(ctx, hashToken(plain))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return domain.Identity{}, err
+	}
+	now := time.Now()
+	if !key.Active(now) {
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+
+	// The key acts with the owner's current role, and dies with the account
+	artist, err := s.artists.GetArtistByID(ctx, key.ArtistID)
+	if errors.Is(err, domain.ErrArtistNotFound) {
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return domain.Identity{}, err
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.repo.TouchAPIKey(ctx, key.ID, now); err != nil {
+			logging.FromContext(ctx).Warn("Failed to record API key use", "api_key_id", key.ID, "error", err)
+		}
+	}
+	return domain.Identity{ArtistID: artist.ID, Role: artist.Role, APIKeyID: key.ID, Scopes: key.Scopes}, nil
+}
+
+// hashToken returns the stored form of an API key or refresh token. They are
+// long and random, so a fast hash is enough, and lets them be looked up by it.
+func hashToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
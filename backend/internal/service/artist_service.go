@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLength = 8
+
+type ArtistServiceImpl struct {
+	repo     domain.ArtistRepository
+	sessions domain.SessionService
+	tx       domain.TxManager
+}
+
+func NewArtistService(repo domain.ArtistRepository, sessions domain.SessionService, tx domain.TxManager) domain.ArtistService {
+	return &ArtistServiceImpl{repo: repo, sessions: sessions, tx: tx}
+}
+
+func (s *ArtistServiceImpl) Register(ctx context.Context, name, email, password string, role domain.Role, device domain.Device) (*domain.Artist, *domain.TokenPair, error) {
+	if role == "" {
+		role = domain.RoleArtist
+	}
+	if role != domain.RoleFan && role != domain.RoleArtist {
+		return nil, nil, fmt.Errorf("%w: role must be fan or artist", domain.ErrInvalidArtist)
+	}
+
+	artist, err := newArtist(name, email, password, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The account and its first session are created together, so a signup
+	// that fails to sign in can be retried with the same email
+	var tokens *domain.TokenPair
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateArtist(ctx, artist); err != nil {
+			return err
+		}
+		tokens, err = s.sessions.StartSession(ctx, artist, device)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	logging.FromContext(ctx).Info("Artist registered", "artist_id", artist.ID, "role", role)
+	return &artist, tokens, nil
+}
+
+// CreateAdmin creates an admin account without signing it in
+func (s *ArtistServiceImpl) CreateAdmin(ctx context.Context, name, email, password string) (*domain.Artist, error) {
+	artist, err := newArtist(name, email, password, domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateArtist(ctx, artist); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Admin created", "artist_id", artist.ID)
+	return &artist, nil
+}
+
+func (s *ArtistServiceImpl) Login(ctx context.Context, email, password string, device domain.Device) (*domain.Artist, *domain.TokenPair, error) {
+	artist, err := s.repo.GetArtistByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, domain.ErrArtistNotFound) {
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(artist.PasswordHash), []byte(password)); err != nil {
+		logging.FromContext(ctx).Warn("Login failed: wrong password", "artist_id", artist.ID)
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	tokens, err := s.sessions.StartSession(ctx, *artist, device)
+	if err != nil {
+		return nil, nil, err
+	}
+	return artist, tokens, nil
+}
+
+func (s *ArtistServiceImpl) GetArtistByID(ctx context.Context, id string) (*domain.Artist, error) {
+	return s.repo.GetArtistByID(ctx, id)
+}
+
+func (s *ArtistServiceImpl) ListArtists(ctx context.Context, limit, offset int) ([]domain.Artist, int64, error) {
+	return s.repo.ListArtists(ctx, limit, offset)
+}
+
+// SetRole changes an account's role. Access tokens already issued keep the
+// old role until they expire; refreshed ones carry the new one.
+func (s *ArtistServiceImpl) SetRole(ctx context.Context, id string, role domain.Role) (*domain.Artist, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: role must be fan, artist or admin", domain.ErrInvalidArtist)
+	}
+
+	if err := s.repo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Role changed", "artist_id", id, "role", role)
+	return s.repo.GetArtistByID(ctx, id)
+}
+
+// DeleteArtist soft deletes an account. Its sessions can no longer be
+// refreshed, but access tokens already issued stay valid until they expire.
+func (s *ArtistServiceImpl) DeleteArtist(ctx context.Context, id string) error {
+	if err := s.repo.DeleteArtist(ctx, id); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Artist deleted", "artist_id", id)
+	return nil
+}
+
+// newArtist validates a new account's details and hashes its password
+func newArtist(name, email, password string, role domain.Role) (domain.Artist, error) {
+	name = strings.TrimSpace(name)
+	email = normalizeEmail(email)
+	if name == "" {
+		return domain.Artist{}, fmt.Errorf("%w: name is required", domain.ErrInvalidArtist)
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return domain.Artist{}, fmt.Errorf("%w: email is not valid", domain.ErrInvalidArtist)
+	}
+	if len(password) < minPasswordLength {
+		return domain.Artist{}, fmt.Errorf("%w: password must be at least %d characters", domain.ErrInvalidArtist, minPasswordLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return domain.Artist{}, err
+	}
+	return domain.Artist{
+		ID:           uuid.NewString(),
+		Name:         name,
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         role,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
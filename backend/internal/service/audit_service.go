@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+type AuditServiceImpl struct {
+	repo domain.AuditRepository
+}
+
+func NewAuditService(repo domain.AuditRepository) domain.AuditService {
+	return &AuditServiceImpl{repo: repo}
+}
+
+func (s *AuditServiceImpl) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, int64, error) {
+	return s.repo.ListEntries(ctx, filter)
+}
+
+// auditor records changes made through the audited service decorators
+type auditor struct {
+	repo domain.AuditRepository
+}
+
+// record logs the fields that differ between before and after, either of
+// which is nil for created or deleted entities. The change is already made,
+// so a failure to record it is logged rather than returned.
+func (a auditor) record(ctx context.Context, action, entityType, entityID string, before, after any) {
+	logger := logging.FromContext(ctx)
+	changes, err := diffFields(before, after)
+	if err != nil {
+		logger.Error("Could not diff audited change", "entity_type", entityType, "entity_id", entityID, "error", err)
+		return
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := domain.AuditEntry{
+		ID:         uuid.NewString(),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		CreatedAt:  time.Now(),
+	}
+	if identity, ok := domain.IdentityFromContext(ctx); ok {
+		entry.ActorID = identity.ArtistID
+	}
+	if err := a.repo.RecordEntry(context.WithoutCancel(ctx), entry); err != nil {
+		logger.Error("Could not record audit entry", "entity_type", entityType, "entity_id", entityID, "error", err)
+	}
+}
+
+// diffFields compares the JSON fields of two versions of an entity, so fields
+// hidden from clients, such as password hashes, are never recorded
+func diffFields(before, after any) (map[string]domain.AuditChange, error) {
+	old, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	current, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]domain.AuditChange{}
+	for field, value := range current {
+		if !reflect.DeepEqual(old[field], value) {
+			changes[field] = domain.AuditChange{Old: old[field], New: value}
+		}
+	}
+	for field, value := range old {
+		if _, ok := current[field]; !ok {
+			changes[field] = domain.AuditChange{Old: value}
+		}
+	}
+	return changes, nil
+}
+
+// jsonFields returns the fields of v as it is rendered to clients, nil for a nil v
+func jsonFields(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// AuditedGiftService records every change made to gifts in the audit log
+type AuditedGiftService struct {
+	domain.GiftService
+	audit auditor
+}
+
+func NewAuditedGiftService(next domain.GiftService, repo domain.AuditRepository) domain.GiftService {
+	return &AuditedGiftService{GiftService: next, audit: auditor{repo: repo}}
+}
+
+func (s *AuditedGiftService) CreateGift(ctx context.Context, gift domain.Gift) (*domain.Gift, error) {
+	created, err := s.GiftService.CreateGift(ctx, gift)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.record(ctx, domain.AuditCreated, domain.AuditGift, created.ID, nil, created)
+	return created, nil
+}
+
+func (s *AuditedGiftService) UpdateGift(ctx context.Context, id string, gift domain.Gift) (*domain.Gift, error) {
+	before, err := s.GiftService.GetGiftByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.GiftService.UpdateGift(ctx, id, gift)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.record(ctx, domain.AuditUpdated, domain.AuditGift, id, before, updated)
+	return updated, nil
+}
+
+func (s *AuditedGiftService) SetGiftImage(ctx context.Context, id string, upload []byte) (*domain.Gift, error) {
+	before, err := s.GiftService.GetGiftByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.GiftService.SetGiftImage(ctx, id, upload)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.record(ctx, domain.AuditUpdated, domain.AuditGift, id, before, updated)
+	return updated, nil
+}
+
+func (s *AuditedGiftService) DeleteGift(ctx context.Context, id string) error {
+	before, err := s.GiftService.GetGiftByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.GiftService.DeleteGift(ctx, id); err != nil {
+		return err
+	}
+	s.audit.record(ctx, domain.AuditDeleted, domain.AuditGift, id, before, nil)
+	return nil
+}
+
+// AuditedArtistService records every change made to accounts in the audit log
+type AuditedArtistService struct {
+	domain.ArtistService
+	audit auditor
+}
+
+func NewAuditedArtistService(next domain.ArtistService, repo domain.AuditRepository) domain.ArtistService {
+	return &AuditedArtistService{ArtistService: next, audit: auditor{repo: repo}}
+}
+
+func (s *AuditedArtistService) Register(ctx context.Context, name, email, password string, role domain.Role, device domain.Device) (*domain.Artist, *domain.TokenPair, error) {
+	artist, tokens, err := s.ArtistService.Register(ctx, name, email, password, role, device)
+	if err != nil {
+		return nil, nil, err
+	}
+	// A signup is made by the account it creates
+	ctx = domain.WithIdentity(ctx, domain.Identity{ArtistID: artist.ID, Role: artist.Role})
+	s.audit.record(ctx, domain.AuditCreated, domain.AuditArtist, artist.ID, nil, artist)
+	return artist, tokens, nil
+}
+
+func (s *AuditedArtistService) CreateAdmin(ctx context.Context, name, email, password string) (*domain.Artist, error) {
+	artist, err := s.ArtistService.CreateAdmin(ctx, name, email, password)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.record(ctx, domain.AuditCreated, domain.AuditArtist, artist.ID, nil, artist)
+	return artist, nil
+}
+
+func (s *AuditedArtistService) SetRole(ctx context.Context, id string, role domain.Role) (*domain.Artist, error) {
+	before, err := s.ArtistService.GetArtistByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.ArtistService.SetRole(ctx, id, role)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.record(ctx, domain.AuditUpdated, domain.AuditArtist, id, before, updated)
+	return updated, nil
+}
+
+func (s *AuditedArtistService) DeleteArtist(ctx context.Context, id string) error {
+	before, err := s.ArtistService.GetArtistByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.ArtistService.DeleteArtist(ctx, id); err != nil {
+		return err
+	}
+	s.audit.record(ctx, domain.AuditDeleted, domain.AuditArtist, id, before, nil)
+	return nil
+}
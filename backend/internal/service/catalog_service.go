@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+// slugSeparators are the runs of characters replaced by "-" in derived slugs
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+type CatalogServiceImpl struct {
+	repo domain.CatalogRepository
+}
+
+func NewCatalogService(repo domain.CatalogRepository) domain.CatalogService {
+	return &CatalogServiceImpl{repo: repo}
+}
+
+func (s *CatalogServiceImpl) CreateCategory(ctx context.Context, category domain.Category) (*domain.Category, error) {
+	slug, err := makeSlug(category.Slug, category.Name)
+	if err != nil {
+		return nil, err
+	}
+	category.ID = uuid.NewString()
+	category.Name = strings.TrimSpace(category.Name)
+	category.Slug = slug
+	category.CreatedAt = time.Now()
+
+	if err := s.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Category created", "category_id", category.ID, "slug", category.Slug)
+	return &category, nil
+}
+
+func (s *CatalogServiceImpl) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	return s.repo.ListCategories(ctx)
+}
+
+func (s *CatalogServiceImpl) UpdateCategory(ctx context.Context, id string, category domain.Category) (*domain.Category, error) {
+	slug, err := makeSlug(category.Slug, category.Name)
+	if err != nil {
+		return nil, err
+	}
+	category.ID = id
+	category.Name = strings.TrimSpace(category.Name)
+	category.Slug = slug
+
+	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Category updated", "category_id", id)
+	return s.repo.GetCategory(ctx, id)
+}
+
+func (s *CatalogServiceImpl) DeleteCategory(ctx context.Context, id string) error {
+	if err := s.repo.DeleteCategory(ctx, id); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Category deleted", "category_id", id)
+	return nil
+}
+
+func (s *CatalogServiceImpl) CreateTag(ctx context.Context, tag domain.Tag) (*domain.Tag, error) {
+	slug, err := makeSlug(tag.Slug, tag.Name)
+	if err != nil {
+		return nil, err
+	}
+	tag.ID = uuid.NewString()
+	tag.Name = strings.TrimSpace(tag.Name)
+	tag.Slug = slug
+	tag.CreatedAt = time.Now()
+
+	if err := s.repo.CreateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Tag created", "tag_id", tag.ID, "slug", tag.Slug)
+	return &tag, nil
+}
+
+func (s *CatalogServiceImpl) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	return s.repo.ListTags(ctx)
+}
+
+func (s *CatalogServiceImpl) UpdateTag(ctx context.Context, id string, tag domain.Tag) (*domain.Tag, error) {
+	slug, err := makeSlug(tag.Slug, tag.Name)
+	if err != nil {
+		return nil, err
+	}
+	tag.ID = id
+	tag.Name = strings.TrimSpace(tag.Name)
+	tag.Slug = slug
+
+	if err := s.repo.UpdateTag(ctx, tag); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Tag updated", "tag_id", id)
+	return s.repo.GetTag(ctx, id)
+}
+
+func (s *CatalogServiceImpl) DeleteTag(ctx context.Context, id string) error {
+	if err := s.repo.DeleteTag(ctx, id); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Tag deleted", "tag_id", id)
+	return nil
+}
+
+// makeSlug lowercases the given slug, or derives one from name, e.g. "Love Songs" becomes "love-songs"
+func makeSlug(slug, name string) (string, error) {
+	if slug == "" {
+		slug = slugSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	}
+	slug = strings.Trim(strings.ToLower(slug), "-_")
+	if slug == "" {
+		return "", domain.ErrInvalidSlug
+	}
+	return slug, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+	"tokentide/pkg/money"
+)
+
+type CurrencyServiceImpl struct {
+	// rates is nil when no rates service is configured, which leaves only
+	// amounts already in the right currency convertible
+	rates           domain.ExchangeRateProvider
+	tokenCurrency   string
+	tokenValueMinor int64
+}
+
+// NewCurrencyService converts at the provider's rates; one token is worth
+// tokenValueMinor minor units of tokenCurrency
+func NewCurrencyService(rates domain.ExchangeRateProvider, tokenCurrency string, tokenValueMinor int64) domain.CurrencyService {
+	return &CurrencyServiceImpl{rates: rates, tokenCurrency: strings.ToUpper(tokenCurrency), tokenValueMinor: tokenValueMinor}
+}
+
+func (s *CurrencyServiceImpl) Convert(ctx context.Context, amountMinor int64, from, to string) (int64, error) {
+	return s.convert(ctx, amountMinor, from, to, money.RoundHalfUp)
+}
+
+// TokenCost rounds up at every step so a gift never costs less than its price
+func (s *CurrencyServiceImpl) TokenCost(ctx context.Context, priceMinor int64, currency string) (int64, error) {
+	value, err := s.convert(ctx, priceMinor, currency, s.tokenCurrency, money.RoundUp)
+	if err != nil {
+		return 0, err
+	}
+	return (value + s.tokenValueMinor - 1) / s.tokenValueMinor, nil
+}
+
+func (s *CurrencyServiceImpl) convert(ctx context.Context, amountMinor int64, from, to string, mode money.RoundingMode) (int64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	for _, code := range []string{from, to} {
+		if _, err := money.MinorUnits(code); err != nil {
+			return 0, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, code)
+		}
+	}
+	if from == to {
+		return amountMinor, nil
+	}
+	if s.rates == nil {
+		return 0, domain.ErrExchangeRatesUnavailable
+	}
+
+	rates, err := s.rates.LatestRates(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("Could not fetch exchange rates", "error", err)
+		return 0, domain.ErrExchangeRatesUnavailable
+	}
+	rate, err := crossRate(rates, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return money.Convert(amountMinor, from, to, rate, mode)
+}
+
+// crossRate derives the rate from one currency to another from their rates
+// against the base, as an exact fraction such as "27/25"
+func crossRate(rates *domain.ExchangeRates, from, to string) (string, error) {
+	quote := func(code string) (*big.Rat, error) {
+		if code == rates.Base {
+			return big.NewRat(1, 1), nil
+		}
+		rate, ok := new(big.Rat).SetString(rates.Rates[code])
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: no exchange rate for %q", domain.ErrUnsupportedCurrency, code)
+		}
+		return rate, nil
+	}
+
+	fromRate, err := quote(from)
+	if err != nil {
+		return "", err
+	}
+	toRate, err := quote(to)
+	if err != nil {
+		return "", err
+	}
+	return toRate.Quo(toRate, fromRate).RatString(), nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// scheduleCatchUp is how late a run may still send its gift, e.g. after
+	// the workers were down; runs missed by longer are skipped
+	scheduleCatchUp = 24 * time.Hour
+	// maxScheduleLead bounds how far ahead a gift can be scheduled
+	maxScheduleLead = 365 * 24 * time.Hour
+	// scheduleMissed is recorded on runs skipped for being too late
+	scheduleMissed = "skipped: the gift was due more than a day before it could be sent"
+)
+
+type GiftScheduleServiceImpl struct {
+	repo    domain.GiftScheduleRepository
+	gifts   domain.GiftRepository
+	wallets domain.WalletService
+}
+
+func NewGiftScheduleService(repo domain.GiftScheduleRepository, gifts domain.GiftRepository, wallets domain.WalletService) domain.GiftScheduleService {
+	return &GiftScheduleServiceImpl{repo: repo, gifts: gifts, wallets: wallets}
+}
+
+func (s *GiftScheduleServiceImpl) CreateSchedule(ctx context.Context, schedule domain.GiftSchedule) (*domain.GiftSchedule, error) {
+	now := time.Now()
+	if !schedule.NextRunAt.After(now) {
+		return nil, fmt.Errorf("%w: send_at must be in the future", domain.ErrInvalidGiftSchedule)
+	}
+	if schedule.NextRunAt.After(now.Add(maxScheduleLead)) {
+		return nil, fmt.Errorf("%w: send_at must be within a year", domain.ErrInvalidGiftSchedule)
+	}
+	switch schedule.Recurrence {
+	case domain.RecurrenceOnce, domain.RecurrenceDaily, domain.RecurrenceWeekly, domain.RecurrenceMonthly:
+	default:
+		return nil, fmt.Errorf("%w: unknown recurrence %q", domain.ErrInvalidGiftSchedule, schedule.Recurrence)
+	}
+
+	gift, err := s.gifts.GetGiftByID(ctx, schedule.GiftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.ArtistID == schedule.SenderID {
+		return nil, fmt.Errorf("%w: cannot send your own gift", domain.ErrInvalidTransfer)
+	}
+
+	schedule.ID = uuid.NewString()
+	schedule.Status = domain.ScheduleActive
+	schedule.NextRunAt = schedule.NextRunAt.UTC()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	if err := s.repo.CreateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift scheduled", "schedule_id", schedule.ID, "gift_id", schedule.GiftID, "recurrence", schedule.Recurrence)
+	return &schedule, nil
+}
+
+func (s *GiftScheduleServiceImpl) GetSchedule(ctx context.Context, id string) (*domain.GiftSchedule, error) {
+	return s.repo.GetSchedule(ctx, id)
+}
+
+func (s *GiftScheduleServiceImpl) ListSchedules(ctx context.Context, senderID string, limit, offset int) ([]domain.GiftSchedule, int64, error) {
+	return s.repo.ListSchedules(ctx, senderID, limit, offset)
+}
+
+func (s *GiftScheduleServiceImpl) CancelSchedule(ctx context.Context, id string) (*domain.GiftSchedule, error) {
+	if err := s.repo.CancelSchedule(ctx, id); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift schedule cancelled", "schedule_id", id)
+	return s.repo.GetSchedule(ctx, id)
+}
+
+func (s *GiftScheduleServiceImpl) RunDueSchedules(ctx context.Context, now time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "GiftScheduleService.RunDueSchedules")
+	defer tracing.End(span, &err)
+
+	schedules, err := s.repo.ListDueSchedules(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, schedule := range schedules {
+		claimed, err := s.run(ctx, schedule, now)
+		if err != nil {
+			return ran, err
+		}
+		if claimed {
+			ran++
+		}
+	}
+	return ran, nil
+}
+
+// run claims the due run of a schedule and sends its gift, reporting false
+// when another worker claimed it first. Runs missed by more than scheduleCatchUp are skipped rather
+// than sent all at once: a recurring schedule moves on to its next run after
+// now and sends the latest missed one if it is recent enough.
+//
+// A run is claimed before its gift is sent, so a crash in between skips the
+// gift rather than sending it twice.
+func (s *GiftScheduleServiceImpl) run(ctx context.Context, schedule domain.GiftSchedule, now time.Time) (bool, error) {
+	logger := logging.FromContext(ctx).With("schedule_id", schedule.ID)
+
+	due := schedule.NextRunAt
+	missed := 0
+	next, recurring := nextRun(schedule.Recurrence, due)
+	for recurring && !next.After(now) {
+		due = next
+		next, _ = nextRun(schedule.Recurrence, due)
+		missed++
+	}
+
+	status := domain.ScheduleActive
+	if !recurring {
+		next = due
+		status = domain.ScheduleCompleted
+	}
+	claimed, err := s.repo.ClaimRun(ctx, schedule.ID, schedule.NextRunAt, next, status)
+	if err != nil || !claimed {
+		return false, err
+	}
+	if missed > 0 {
+		logger.Warn("Skipped missed gift schedule runs", "count", missed)
+	}
+
+	if now.Sub(due) > scheduleCatchUp {
+		logger.Warn("Skipped overdue gift schedule run", "due", due)
+		return true, s.repo.RecordRun(ctx, schedule.ID, now, scheduleMissed, false)
+	}
+
+	if _, err := s.wallets.SendGift(ctx, schedule.SenderID, schedule.GiftID); err != nil {
+		var domainErr *domain.Error
+		if !errors.As(err, &domainErr) {
+			logger.Error("Scheduled gift failed", "error", err)
+			return true, s.repo.RecordRun(ctx, schedule.ID, now, "internal error", false)
+		}
+		// A gift or wallet that is gone, a gift now owned by the sender, or
+		// one the sender sent as often as it allows will fail every later run
+		// too; insufficient funds or a sold out gift, which may be restocked, may not
+		stop := errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidTransfer) || errors.Is(err, domain.ErrGiftLimitReached)
+		logger.Info("Scheduled gift not sent", "error", err, "stopped", stop)
+		return true, s.repo.RecordRun(ctx, schedule.ID, now, err.Error(), stop)
+	}
+	logger.Info("Scheduled gift sent", "gift_id", schedule.GiftID, "sender_id", schedule.SenderID)
+	return true, s.repo.RecordRun(ctx, schedule.ID, now, "", false)
+}
+
+// nextRun returns the run following due, reporting false for schedules that
+// do not recur
+func nextRun(recurrence string, due time.Time) (time.Time, bool) {
+	switch recurrence {
+	case domain.RecurrenceDaily:
+		return due.AddDate(0, 0, 1), true
+	case domain.RecurrenceWeekly:
+		return due.AddDate(0, 0, 7), true
+	case domain.RecurrenceMonthly:
+		return due.AddDate(0, 1, 0), true
+	default:
+		return time.Time{}, false
+	}
+}
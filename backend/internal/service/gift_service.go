@@ -1,21 +1,170 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/internal/storage"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+	"tokentide/pkg/money"
+
+	"github.com/google/uuid"
 )
 
 type GiftServiceImpl struct {
-	repo domain.GiftRepository
+	repo  domain.GiftRepository
+	files domain.FileStorage
+}
+
+func NewGiftService(repo domain.GiftRepository, files domain.FileStorage) domain.GiftService {
+	return &GiftServiceImpl{repo: repo, files: files}
+}
+
+func (s *GiftServiceImpl) CreateGift(ctx context.Context, gift domain.Gift) (_ *domain.Gift, err error) {
+	ctx, span := tracing.Start(ctx, "GiftService.CreateGift")
+	defer tracing.End(span, &err)
+
+	gift, err = validateGift(gift)
+	if err != nil {
+		return nil, err
+	}
+
+	if gift.ID == "" {
+		gift.ID = uuid.NewString()
+	}
+	gift.CreatedAt = time.Now()
+	gift.Sold = 0
+	gift.Version = 1
+	event, err := domain.NewEvent(domain.EventGiftCreated, gift.ID, gift)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateGift(ctx, gift, event); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift created", "gift_id", gift.ID, "artist_id", gift.ArtistID)
+	metrics.GiftsCreated.Inc()
+
+	// Return the stored gift, with its categories and tags loaded
+	return s.repo.GetGiftByID(ctx, gift.ID)
+}
+
+func (s *GiftServiceImpl) GetGiftByID(ctx context.Context, id string) (*domain.Gift, error) {
+	return s.repo.GetGiftByID(ctx, id)
+}
+
+func (s *GiftServiceImpl) ListGifts(ctx context.Context, filter domain.GiftFilter) ([]domain.Gift, int64, error) {
+	filter.Currency = strings.ToUpper(filter.Currency)
+	if (filter.MinPrice != nil || filter.MaxPrice != nil) && filter.Currency == "" {
+		return nil, 0, fmt.Errorf("%w: min_price and max_price require currency", domain.ErrInvalidGift)
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, 0, fmt.Errorf("%w: min_price must not exceed max_price", domain.ErrInvalidGift)
+	}
+	return s.repo.ListGifts(ctx, filter)
+}
+
+func (s *GiftServiceImpl) UpdateGift(ctx context.Context, id string, gift domain.Gift) (_ *domain.Gift, err error) {
+	ctx, span := tracing.Start(ctx, "GiftService.UpdateGift")
+	defer tracing.End(span, &err)
+
+	gift, err = validateGift(gift)
+	if err != nil {
+		return nil, err
+	}
+
+	gift.ID = id
+	if err := s.repo.UpdateGift(ctx, gift); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift updated", "gift_id", id)
+
+	// The owning artist never changes, so return the stored gift
+	return s.repo.GetGiftByID(ctx, id)
+}
+
+func (s *GiftServiceImpl) SetGiftImage(ctx context.Context, id string, upload []byte) (_ *domain.Gift, err error) {
+	ctx, span := tracing.Start(ctx, "GiftService.SetGiftImage")
+	defer tracing.End(span, &err)
+
+	gift, err := s.repo.GetGiftByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	image, err := storage.ProcessImage(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upload gets a new key, so stored files can be cached indefinitely
+	key := "gifts/" + id + "/" + uuid.NewString() + image.Ext
+	if err := s.files.Put(ctx, key, image.ContentType, image.Data); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetGiftImage(ctx, id, key, s.files.URL(key)); err != nil {
+		s.removeFile(ctx, key)
+		return nil, err
+	}
+	if gift.ImageKey != "" {
+		s.removeFile(ctx, gift.ImageKey)
+	}
+	logging.FromContext(ctx).Info("Gift image uploaded", "gift_id", id, "key", key)
+	return s.repo.GetGiftByID(ctx, id)
+}
+
+// DeleteGift soft deletes a gift; its image is kept along with the rest of it
+func (s *GiftServiceImpl) DeleteGift(ctx context.Context, id string) error {
+	if err := s.repo.DeleteGift(ctx, id); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Gift deleted", "gift_id", id)
+	return nil
 }
 
-func NewGiftService(repo domain.GiftRepository) domain.GiftService {
-	return &GiftServiceImpl{repo: repo}
+// ListPriceHistory returns a gift's prices, oldest first. Every gift has at
+// least its first price, so an empty history means there is no such gift or
+// it was deleted.
+func (s *GiftServiceImpl) ListPriceHistory(ctx context.Context, giftID string) ([]domain.GiftPrice, error) {
+	prices, err := s.repo.ListPriceHistory(ctx, giftID)
+	if err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		return nil, domain.ErrGiftNotFound
+	}
+	return prices, nil
 }
 
-func (s *GiftServiceImpl) CreateGift(gift domain.Gift) error {
-	return s.repo.CreateGift(gift)
+// removeFile deletes a file no gift refers to any more. A failure only leaves
+// an orphaned file behind, so it is logged rather than returned.
+func (s *GiftServiceImpl) removeFile(ctx context.Context, key string) {
+	if err := s.files.Delete(ctx, key); err != nil {
+		logging.FromContext(ctx).Warn("Could not delete stored file", "key", key, "error", err)
+	}
 }
 
-func (s *GiftServiceImpl) GetGiftByID(id string) (*domain.Gift, error) {
-	return s.repo.GetGiftByID(id)
+// validateGift checks a gift and returns it with its currency code in upper case
+func validateGift(gift domain.Gift) (domain.Gift, error) {
+	if strings.TrimSpace(gift.Name) == "" {
+		return gift, fmt.Errorf("%w: name is required", domain.ErrInvalidGift)
+	}
+	if gift.PriceMinor <= 0 {
+		return gift, fmt.Errorf("%w: price must be greater than zero", domain.ErrInvalidGift)
+	}
+	if gift.Quantity != nil && *gift.Quantity <= 0 {
+		return gift, fmt.Errorf("%w: quantity must be greater than zero", domain.ErrInvalidGift)
+	}
+	if gift.PerFanLimit != nil && *gift.PerFanLimit <= 0 {
+		return gift, fmt.Errorf("%w: per_fan_limit must be greater than zero", domain.ErrInvalidGift)
+	}
+	gift.Currency = strings.ToUpper(gift.Currency)
+	if _, err := money.MinorUnits(gift.Currency); err != nil {
+		return gift, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, gift.Currency)
+	}
+	return gift, nil
 }
@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+
+	"github.com/google/uuid"
+)
+
+func TestDeletedGiftHasNoPriceHistory(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	gifts := service.NewGiftService(memory.NewGiftRepository(store), nil)
+	gift := createGift(t, store, uuid.NewString(), 500, 0)
+
+	prices, err := gifts.ListPriceHistory(ctx, gift.ID)
+	if err != nil {
+		t.Fatalf("ListPriceHistory: %v", err)
+	}
+	if len(prices) != 1 || prices[0].PriceMinor != 500 {
+		t.Errorf("prices = %+v, want the first price of 500", prices)
+	}
+
+	if err := gifts.DeleteGift(ctx, gift.ID); err != nil {
+		t.Fatalf("DeleteGift: %v", err)
+	}
+	if _, err := gifts.ListPriceHistory(ctx, gift.ID); !errors.Is(err, domain.ErrGiftNotFound) {
+		t.Errorf("ListPriceHistory error = %v, want %v", err, domain.ErrGiftNotFound)
+	}
+}
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+
+	"github.com/google/uuid"
+)
+
+// tokenValueMinor values a token at one cent, so a gift costs its price in cents
+const tokenValueMinor = 1
+
+// nopPublisher drops the gift sent events of the wallet service
+type nopPublisher struct{}
+
+func (nopPublisher) PublishGiftSent(context.Context, domain.GiftSentEvent) {}
+
+func newWalletService(store *memory.Store) domain.WalletService {
+	return service.NewWalletService(
+		memory.NewWalletRepository(store),
+		memory.NewTransactionRepository(store),
+		memory.NewGiftRepository(store),
+		service.NewCurrencyService(nil, "USD", tokenValueMinor),
+		nopPublisher{},
+		memory.NewTxManager(store),
+	)
+}
+
+// fundedWallet returns the owner's wallet, credited with tokens
+func fundedWallet(t *testing.T, store *memory.Store, ownerID string, tokens int64) *domain.Wallet {
+	t.Helper()
+	ctx := context.Background()
+	wallet, err := newWalletService(store).GetWalletForOwner(ctx, ownerID)
+	if err != nil {
+		t.Fatalf("GetWalletForOwner: %v", err)
+	}
+	if tokens > 0 {
+		if wallet, err = memory.NewWalletRepository(store).AdjustBalance(ctx, wallet.ID, tokens); err != nil {
+			t.Fatalf("AdjustBalance: %v", err)
+		}
+	}
+	return wallet
+}
+
+// createGift stores a gift of the artist priced in US cents, with quantity
+// capping its sends unless it is zero
+func createGift(t *testing.T, store *memory.Store, artistID string, priceMinor, quantity int64) domain.Gift {
+	t.Helper()
+	gift := domain.Gift{
+		ID:         uuid.NewString(),
+		Name:       "Red Rose",
+		PriceMinor: priceMinor,
+		Currency:   "USD",
+		ArtistID:   artistID,
+		CreatedAt:  time.Now(),
+		Version:    1,
+	}
+	if quantity > 0 {
+		gift.Quantity = &quantity
+	}
+	if err := memory.NewGiftRepository(store).CreateGift(context.Background(), gift); err != nil {
+		t.Fatalf("CreateGift: %v", err)
+	}
+	return gift
+}
+
+// balance returns the current balance of a wallet
+func balance(t *testing.T, store *memory.Store, walletID string) int64 {
+	t.Helper()
+	wallet, err := memory.NewWalletRepository(store).GetWalletByID(context.Background(), walletID)
+	if err != nil {
+		t.Fatalf("GetWalletByID: %v", err)
+	}
+	return wallet.Balance
+}
+
+// entries returns a wallet's ledger, newest first
+func entries(t *testing.T, store *memory.Store, walletID string) []domain.Transaction {
+	t.Helper()
+	transactions, _, err := memory.NewTransactionRepository(store).ListTransactions(context.Background(), walletID, -1, 0)
+	if err != nil {
+		t.Fatalf("ListTransactions: %v", err)
+	}
+	return transactions
+}
+
+// countEvents counts the outbox events of a type
+func countEvents(store *memory.Store, eventType string) int {
+	count := 0
+	for _, event := range store.Events() {
+		if event.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+// fakePayments is a payment provider whose webhooks are the JSON of a
+// domain.PaymentEvent, and whose refunds succeed unless refundErr is set
+type fakePayments struct {
+	refundErr error
+	refunds   int
+}
+
+func (p *fakePayments) Name() string {
+	return "fake"
+}
+
+func (p *fakePayments) CreateCheckout(_ context.Context, purchase domain.Purchase, _ domain.TokenPackage) (*domain.CheckoutSession, error) {
+	return &domain.CheckoutSession{ProviderRef: "cs_" + purchase.ID, URL: "https://pay.example.com/" + purchase.ID}, nil
+}
+
+func (p *fakePayments) ParseWebhook(payload []byte, _ string) (*domain.PaymentEvent, error) {
+	var event domain.PaymentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, domain.ErrInvalidWebhook
+	}
+	return &event, nil
+}
+
+func (p *fakePayments) GetPaymentStatus(context.Context, domain.Purchase) (*domain.PaymentEvent, error) {
+	return nil, nil
+}
+
+func (p *fakePayments) Refund(_ context.Context, _ domain.Purchase, refundID string) (string, error) {
+	p.refunds++
+	if p.refundErr != nil {
+		return "", p.refundErr
+	}
+	return "re_" + refundID, nil
+}
+
+// webhook returns the payload of a fakePayments webhook
+func webhook(t *testing.T, purchaseID, status string) []byte {
+	t.Helper()
+	payload, err := json.Marshal(domain.PaymentEvent{PurchaseID: purchaseID, Status: status})
+	if err != nil {
+		t.Fatalf("marshal webhook: %v", err)
+	}
+	return payload
+}
+
+// fakePayouts is a payout provider whose accounts are always ready and whose
+// transfers succeed unless transferErr is set
+type fakePayouts struct {
+	transferErr error
+}
+
+func (p *fakePayouts) Name() string {
+	return "fake"
+}
+
+func (p *fakePayouts) CreateAccount(_ context.Context, artist domain.Artist) (string, error) {
+	return "acct_" + artist.ID, nil
+}
+
+func (p *fakePayouts) OnboardingURL(_ context.Context, accountID string) (string, error) {
+	return "https://connect.example.com/" + accountID, nil
+}
+
+func (p *fakePayouts) AccountReady(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+func (p *fakePayouts) Transfer(_ context.Context, payout domain.Payout, _ string) (string, error) {
+	if p.transferErr != nil {
+		return "", p.transferErr
+	}
+	return "tr_" + payout.ID, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+)
+
+type NotificationServiceImpl struct {
+	repo domain.NotificationRepository
+}
+
+func NewNotificationService(repo domain.NotificationRepository) domain.NotificationService {
+	return &NotificationServiceImpl{repo: repo}
+}
+
+func (s *NotificationServiceImpl) GetPreferences(ctx context.Context, artistID string) (*domain.NotificationPreferences, error) {
+	return s.repo.GetPreferences(ctx, artistID)
+}
+
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, preferences domain.NotificationPreferences) (*domain.NotificationPreferences, error) {
+	preferences.UpdatedAt = time.Now()
+	if err := s.repo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Notification preferences updated", "artist_id", preferences.ArtistID)
+	return &preferences, nil
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type PaymentServiceImpl struct {
+	repo     domain.PurchaseRepository
+	wallets  domain.WalletService
+	provider domain.PaymentProvider
+}
+
+func NewPaymentService(repo domain.PurchaseRepository, wallets domain.WalletService, provider domain.PaymentProvider) domain.PaymentService {
+	return &PaymentServiceImpl{repo: repo, wallets: wallets, provider: provider}
+}
+
+func (s *PaymentServiceImpl) CreateTokenPackage(ctx context.Context, pkg domain.TokenPackage) (*domain.TokenPackage, error) {
+	pkg.Name = strings.TrimSpace(pkg.Name)
+	pkg.Currency = strings.ToUpper(pkg.Currency)
+
+	if pkg.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidTokenPackage)
+	}
+	if pkg.Tokens <= 0 || pkg.AmountMinor <= 0 {
+		return nil, fmt.Errorf("%w: tokens and amount_minor must be greater than zero", domain.ErrInvalidTokenPackage)
+	}
+	if len(pkg.Currency) != 3 {
+		return nil, fmt.Errorf("%w: currency must be an ISO 4217 code", domain.ErrInvalidTokenPackage)
+	}
+
+	pkg.ID = uuid.NewString()
+	pkg.Active = true
+	pkg.CreatedAt = time.Now()
+
+	if err := s.repo.CreateTokenPackage(ctx, pkg); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Token package created", "package_id", pkg.ID)
+	return &pkg, nil
+}
+
+func (s *PaymentServiceImpl) ListTokenPackages(ctx context.Context) ([]domain.TokenPackage, error) {
+	return s.repo.ListTokenPackages(ctx)
+}
+
+func (s *PaymentServiceImpl) CreatePurchase(ctx context.Context, buyerID, packageID string) (_ *domain.Purchase, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.CreatePurchase")
+	defer tracing.End(span, &err)
+
+	pkg, err := s.repo.GetTokenPackage(ctx, packageID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !pkg.Active {
+		return nil, "", domain.ErrTokenPackageNotFound
+	}
+
+	now := time.Now()
+	purchase := domain.Purchase{
+		ID:          uuid.NewString(),
+		BuyerID:     buyerID,
+		PackageID:   pkg.ID,
+		Tokens:      pkg.Tokens,
+		AmountMinor: pkg.AmountMinor,
+		Currency:    pkg.Currency,
+		Provider:    s.provider.Name(),
+		Status:      domain.PurchasePending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreatePurchase(ctx, purchase); err != nil {
+		return nil, "", err
+	}
+
+	session, err := s.createCheckout(ctx, purchase, *pkg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	purchase.ProviderRef = session.ProviderRef
+	if err := s.repo.SetProviderRef(ctx, purchase.ID, session.ProviderRef); err != nil {
+		return nil, "", err
+	}
+	logging.FromContext(ctx).Info("Checkout started", "purchase_id", purchase.ID, "package_id", pkg.ID, "buyer_id", buyerID)
+	return &purchase, session.URL, nil
+}
+
+// createCheckout calls the provider in its own span, as it is usually the slowest step of a purchase
+func (s *PaymentServiceImpl) createCheckout(ctx context.Context, purchase domain.Purchase, pkg domain.TokenPackage) (_ *domain.CheckoutSession, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentProvider.CreateCheckout", attribute.String("payment.provider", s.provider.Name()))
+	defer tracing.End(span, &err)
+
+	return s.provider.CreateCheckout(ctx, purchase, pkg)
+}
+
+func (s *PaymentServiceImpl) GetPurchase(ctx context.Context, id string) (*domain.Purchase, error) {
+	return s.repo.GetPurchase(ctx, id)
+}
+
+func (s *PaymentServiceImpl) HandleWebhook(ctx context.Context, payload []byte, signature string) (err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.HandleWebhook")
+	defer tracing.End(span, &err)
+
+	event, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return nil
+	}
+
+	purchase, err := s.repo.GetPurchase(ctx, event.PurchaseID)
+	if err != nil {
+		return err
+	}
+	return s.settle(ctx, *purchase, event.Status)
+}
+
+func (s *PaymentServiceImpl) ReconcilePurchases(ctx context.Context, before time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.ReconcilePurchases")
+	defer tracing.End(span, &err)
+
+	purchases, err := s.repo.ListPendingPurchases(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, purchase := range purchases {
+		event, err := s.provider.GetPaymentStatus(ctx, purchase)
+		if err != nil {
+			return settled, err
+		}
+		if event == nil {
+			continue
+		}
+		if err := s.settle(ctx, purchase, event.Status); err != nil {
+			return settled, err
+		}
+		settled++
+	}
+	return settled, nil
+}
+
+// settle applies the payment outcome reported for a purchase, crediting the
+// buyer's wallet when it succeeded
+func (s *PaymentServiceImpl) settle(ctx context.Context, purchase domain.Purchase, status string) error {
+	// Providers retry webhooks; a completed or refunded purchase needs no
+	// further work, and the repository checks again under a lock
+	if purchase.Status == domain.PurchaseSucceeded || purchase.Status == domain.PurchaseRefunded {
+		return nil
+	}
+
+	logger := logging.FromContext(ctx).With("purchase_id", purchase.ID)
+	switch status {
+	case domain.PurchaseSucceeded:
+		wallet, err := s.wallets.GetWalletForOwner(ctx, purchase.BuyerID)
+		if err != nil {
+			return err
+		}
+		event, err := domain.NewEvent(domain.EventPaymentSucceeded, purchase.ID, domain.PaymentSucceeded{
+			PurchaseID:  purchase.ID,
+			BuyerID:     purchase.BuyerID,
+			WalletID:    wallet.ID,
+			Tokens:      purchase.Tokens,
+			AmountMinor: purchase.AmountMinor,
+			Currency:    purchase.Currency,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.repo.CompletePurchase(ctx, purchase.ID, wallet.ID, event); err != nil {
+			return err
+		}
+		logger.Info("Purchase completed", "wallet_id", wallet.ID, "tokens", purchase.Tokens)
+		metrics.TokensPurchased.Add(float64(purchase.Tokens))
+	case domain.PurchaseFailed:
+		if err := s.repo.FailPurchase(ctx, purchase.ID); err != nil {
+			return err
+		}
+		logger.Info("Purchase failed")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+
+	"github.com/google/uuid"
+)
+
+// pendingPurchase stores a purchase of tokens awaiting its payment
+func pendingPurchase(t *testing.T, store *memory.Store, buyerID string, tokens int64) domain.Purchase {
+	t.Helper()
+	now := time.Now()
+	purchase := domain.Purchase{
+		ID:          uuid.NewString(),
+		BuyerID:     buyerID,
+		PackageID:   uuid.NewString(),
+		Tokens:      tokens,
+		AmountMinor: tokens * tokenValueMinor,
+		Currency:    "USD",
+		Provider:    "fake",
+		ProviderRef: "cs_test",
+		Status:      domain.PurchasePending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := memory.NewPurchaseRepository(store).CreatePurchase(context.Background(), purchase); err != nil {
+		t.Fatalf("CreatePurchase: %v", err)
+	}
+	return purchase
+}
+
+func TestHandleWebhookCreditsOnce(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	purchase := pendingPurchase(t, store, buyerID, 500)
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), &fakePayments{})
+
+	// Providers deliver a webhook at least once
+	for range 2 {
+		if err := payments.HandleWebhook(ctx, webhook(t, purchase.ID, domain.PurchaseSucceeded), ""); err != nil {
+			t.Fatalf("HandleWebhook: %v", err)
+		}
+	}
+
+	if got := balance(t, store, wallet.ID); got != 500 {
+		t.Errorf("balance = %d, want 500", got)
+	}
+	if got := len(entries(t, store, wallet.ID)); got != 1 {
+		t.Errorf("ledger entries = %d, want 1", got)
+	}
+	if got := countEvents(store, domain.EventPaymentSucceeded); got != 1 {
+		t.Errorf("%s events = %d, want 1", domain.EventPaymentSucceeded, got)
+	}
+}
+
+func TestHandleWebhookConcurrentDeliveriesCreditOnce(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	purchase := pendingPurchase(t, store, buyerID, 500)
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), &fakePayments{})
+
+	// Every delivery may read the purchase while it is still pending
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := payments.HandleWebhook(ctx, webhook(t, purchase.ID, domain.PurchaseSucceeded), ""); err != nil {
+				t.Errorf("HandleWebhook: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := balance(t, store, wallet.ID); got != 500 {
+		t.Errorf("balance = %d, want 500", got)
+	}
+}
+
+func TestHandleWebhookFailedPayment(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	purchase := pendingPurchase(t, store, buyerID, 500)
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), &fakePayments{})
+
+	if err := payments.HandleWebhook(ctx, webhook(t, purchase.ID, domain.PurchaseFailed), ""); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	stored, err := payments.GetPurchase(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("GetPurchase: %v", err)
+	}
+	if stored.Status != domain.PurchaseFailed {
+		t.Errorf("status = %s, want %s", stored.Status, domain.PurchaseFailed)
+	}
+	if got := balance(t, store, wallet.ID); got != 0 {
+		t.Errorf("balance = %d, want 0", got)
+	}
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+	"tokentide/pkg/money"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type PayoutServiceImpl struct {
+	repo    domain.PayoutRepository
+	wallets domain.WalletService
+	artists domain.ArtistRepository
+	// provider is nil when payouts are not configured; earnings can be read but not withdrawn then
+	provider domain.PayoutProvider
+	terms    domain.PayoutTerms
+}
+
+func NewPayoutService(repo domain.PayoutRepository, wallets domain.WalletService, artists domain.ArtistRepository, provider domain.PayoutProvider, terms domain.PayoutTerms) domain.PayoutService {
+	return &PayoutServiceImpl{repo: repo, wallets: wallets, artists: artists, provider: provider, terms: terms}
+}
+
+func (s *PayoutServiceImpl) SetUpAccount(ctx context.Context, artistID string) (_ *domain.PayoutAccount, err error) {
+	ctx, span := tracing.Start(ctx, "PayoutService.SetUpAccount")
+	defer tracing.End(span, &err)
+
+	if s.provider == nil {
+		return nil, domain.ErrPayoutsUnavailable
+	}
+	account, err := s.repo.GetAccount(ctx, artistID)
+	if errors.Is(err, domain.ErrPayoutAccountNotFound) {
+		account, err = s.openAccount(ctx, artistID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refresh(ctx, account); err != nil {
+		return nil, err
+	}
+	if !account.Ready {
+		account.OnboardingURL, err = s.provider.OnboardingURL(ctx, account.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return account, nil
+}
+
+func (s *PayoutServiceImpl) openAccount(ctx context.Context, artistID string) (*domain.PayoutAccount, error) {
+	artist, err := s.artists.GetArtistByID(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+	externalID, err := s.provider.CreateAccount(ctx, *artist)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := domain.PayoutAccount{
+		ArtistID:   artistID,
+		Provider:   s.provider.Name(),
+		ExternalID: externalID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.repo.SaveAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Payout account opened", "artist_id", artistID, "provider", account.Provider)
+	return &account, nil
+}
+
+func (s *PayoutServiceImpl) GetAccount(ctx context.Context, artistID string) (*domain.PayoutAccount, error) {
+	if s.provider == nil {
+		return nil, domain.ErrPayoutsUnavailable
+	}
+	account, err := s.repo.GetAccount(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refresh(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// refresh asks the provider whether an account that was not ready finished onboarding
+func (s *PayoutServiceImpl) refresh(ctx context.Context, account *domain.PayoutAccount) error {
+	if account.Ready {
+		return nil
+	}
+	ready, err := s.provider.AccountReady(ctx, account.ExternalID)
+	if err != nil || !ready {
+		return err
+	}
+
+	account.Ready = true
+	account.UpdatedAt = time.Now()
+	return s.repo.SaveAccount(ctx, *account)
+}
+
+func (s *PayoutServiceImpl) DeleteAccount(ctx context.Context, artistID string) error {
+	if err := s.repo.DeleteAccount(ctx, artistID); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Payout account deleted", "artist_id", artistID)
+	return nil
+}
+
+func (s *PayoutServiceImpl) GetBalance(ctx context.Context, artistID string) (*domain.PayoutBalance, error) {
+	wallet, err := s.wallets.GetWalletForOwner(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.repo.GetBalance(ctx, artistID, wallet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, balance.WithdrawableMinor, err = s.value(balance.Withdrawable)
+	if err != nil {
+		return nil, err
+	}
+	balance.Currency = s.terms.TokenCurrency
+	return balance, nil
+}
+
+func (s *PayoutServiceImpl) RequestPayout(ctx context.Context, artistID string, tokens int64) (_ *domain.Payout, err error) {
+	ctx, span := tracing.Start(ctx, "PayoutService.RequestPayout")
+	defer tracing.End(span, &err)
+
+	if tokens <= 0 {
+		return nil, fmt.Errorf("%w: tokens must be greater than zero", domain.ErrInvalidPayout)
+	}
+	account, err := s.GetAccount(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.Ready {
+		return nil, domain.ErrPayoutAccountNotReady
+	}
+	wallet, err := s.wallets.GetWalletForOwner(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	gross, fee, net, err := s.value(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if net <= 0 {
+		return nil, fmt.Errorf("%w: too few tokens to pay anything after the fee", domain.ErrInvalidPayout)
+	}
+
+	now := time.Now()
+	payout := domain.Payout{
+		ID:          uuid.NewString(),
+		ArtistID:    artistID,
+		WalletID:    wallet.ID,
+		Tokens:      tokens,
+		GrossMinor:  gross,
+		FeeMinor:    fee,
+		AmountMinor: net,
+		Currency:    s.terms.TokenCurrency,
+		Status:      domain.PayoutRequested,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.RequestPayout(ctx, payout); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Payout requested", "payout_id", payout.ID, "artist_id", artistID, "tokens", tokens, "amount_minor", net)
+	metrics.Payouts.WithLabelValues(domain.PayoutRequested).Inc()
+	return &payout, nil
+}
+
+// value returns what tokens are worth, the platform's fee on it, rounded in
+// the platform's favour, and what is left for the artist
+func (s *PayoutServiceImpl) value(tokens int64) (gross, fee, net int64, err error) {
+	if tokens > math.MaxInt64/s.terms.TokenValueMinor {
+		return 0, 0, 0, fmt.Errorf("%w: too many tokens", domain.ErrInvalidPayout)
+	}
+	gross = tokens * s.terms.TokenValueMinor
+	fee, err = money.ApplyRate(gross, s.terms.FeeRate, money.RoundUp)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return gross, fee, gross - fee, nil
+}
+
+func (s *PayoutServiceImpl) ApprovePayout(ctx context.Context, id string) (_ *domain.Payout, err error) {
+	ctx, span := tracing.Start(ctx, "PayoutService.ApprovePayout")
+	defer tracing.End(span, &err)
+
+	if s.provider == nil {
+		return nil, domain.ErrPayoutsUnavailable
+	}
+	payout, err := s.repo.StartPayout(ctx, id, reviewerID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	providerRef, err := s.transfer(ctx, *payout)
+	if err := s.settlePayout(ctx, *payout, providerRef, err); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPayout(ctx, id)
+}
+
+func (s *PayoutServiceImpl) ReconcilePayouts(ctx context.Context, before time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "PayoutService.ReconcilePayouts")
+	defer tracing.End(span, &err)
+
+	if s.provider == nil {
+		return 0, nil
+	}
+	payouts, err := s.repo.ListProcessingPayouts(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, payout := range payouts {
+		// The provider returns the first transfer of a payout rather than paying it twice
+		providerRef, err := s.transfer(ctx, payout)
+		if err := s.settlePayout(ctx, payout, providerRef, err); err != nil {
+			return settled, err
+		}
+		if err == nil || errors.Is(err, domain.ErrPaymentRejected) {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// settlePayout applies the provider's answer to a processing payout. It only
+// fails the payout, returning the tokens, when the provider rejected the
+// transfer; otherwise the money may have been sent, so the payout stays
+// processing until ReconcilePayouts learns the outcome.
+func (s *PayoutServiceImpl) settlePayout(ctx context.Context, payout domain.Payout, providerRef string, transferErr error) error {
+	// The money has moved or not by now, so the outcome is recorded even if the caller gave up
+	ctx = context.WithoutCancel(ctx)
+	logger := logging.FromContext(ctx).With("payout_id", payout.ID, "artist_id", payout.ArtistID)
+
+	switch {
+	case errors.Is(transferErr, domain.ErrPaymentRejected):
+		logger.Error("Payout transfer rejected", "error", transferErr)
+		if err := s.repo.FailPayout(ctx, payout.ID, transferErr.Error()); err != nil {
+			return err
+		}
+		metrics.Payouts.WithLabelValues(domain.PayoutFailed).Inc()
+		return nil
+	case transferErr != nil:
+		logger.Warn("Payout transfer outcome unknown, left processing", "error", transferErr)
+		return nil
+	}
+
+	event, err := domain.NewEvent(domain.EventPayoutCompleted, payout.ID, domain.PayoutCompleted{
+		PayoutID:    payout.ID,
+		ArtistID:    payout.ArtistID,
+		Tokens:      payout.Tokens,
+		AmountMinor: payout.AmountMinor,
+		Currency:    payout.Currency,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CompletePayout(ctx, payout.ID, providerRef, event); err != nil {
+		return err
+	}
+	logger.Info("Payout paid", "tokens", payout.Tokens, "amount_minor", payout.AmountMinor)
+	metrics.Payouts.WithLabelValues(domain.PayoutPaid).Inc()
+	return nil
+}
+
+// transfer pays a payout to its artist's account in its own span, like refundPayment
+func (s *PayoutServiceImpl) transfer(ctx context.Context, payout domain.Payout) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "PayoutProvider.Transfer", attribute.String("payout.provider", s.provider.Name()))
+	defer tracing.End(span, &err)
+
+	account, err := s.repo.GetAccount(ctx, payout.ArtistID)
+	if err != nil {
+		return "", err
+	}
+	return s.provider.Transfer(ctx, payout, account.ExternalID)
+}
+
+func (s *PayoutServiceImpl) RejectPayout(ctx context.Context, id, reason string) (*domain.Payout, error) {
+	if err := s.repo.RejectPayout(ctx, id, reviewerID(ctx), reason); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Payout rejected", "payout_id", id)
+	metrics.Payouts.WithLabelValues(domain.PayoutRejected).Inc()
+	return s.repo.GetPayout(ctx, id)
+}
+
+func (s *PayoutServiceImpl) GetPayout(ctx context.Context, id string) (*domain.Payout, error) {
+	return s.repo.GetPayout(ctx, id)
+}
+
+func (s *PayoutServiceImpl) ListPayouts(ctx context.Context, filter domain.PayoutFilter) ([]domain.Payout, int64, error) {
+	return s.repo.ListPayouts(ctx, filter)
+}
+
+// reviewerID returns the account in ctx reviewing a payout
+func reviewerID(ctx context.Context) string {
+	if identity, ok := domain.IdentityFromContext(ctx); ok {
+		return identity.ArtistID
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+
+	"github.com/google/uuid"
+)
+
+// payoutTerms value a token at ten cents and keep a fifth of every payout
+var payoutTerms = domain.PayoutTerms{TokenCurrency: "USD", TokenValueMinor: 10, FeeRate: "0.2"}
+
+func newPayoutService(store *memory.Store, provider domain.PayoutProvider) domain.PayoutService {
+	return service.NewPayoutService(memory.NewPayoutRepository(store), newWalletService(store), memory.NewArtistRepository(store), provider, payoutTerms)
+}
+
+// readyAccount gives the artist a payout account that finished onboarding
+func readyAccount(t *testing.T, store *memory.Store, artistID string) {
+	t.Helper()
+	now := time.Now()
+	account := domain.PayoutAccount{ArtistID: artistID, Provider: "fake", ExternalID: "acct_" + artistID, Ready: true, CreatedAt: now, UpdatedAt: now}
+	if err := memory.NewPayoutRepository(store).SaveAccount(context.Background(), account); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+}
+
+func TestPayoutBalance(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	wallets := newWalletService(store)
+	fanID, artistID, otherArtistID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	fundedWallet(t, store, fanID, 200)
+	gift := createGift(t, store, artistID, 50, 0)
+	otherGift := createGift(t, store, otherArtistID, 30, 0)
+
+	// The artist earns two gifts, one of which is refunded, and spends some
+	// of the earnings on another artist's gift
+	for range 2 {
+		if _, err := wallets.SendGift(ctx, fanID, gift.ID); err != nil {
+			t.Fatalf("SendGift: %v", err)
+		}
+	}
+	fan, err := wallets.GetWalletForOwner(ctx, fanID)
+	if err != nil {
+		t.Fatalf("fan wallet: %v", err)
+	}
+	refunds := service.NewRefundService(memory.NewRefundRepository(store), memory.NewPurchaseRepository(store), memory.NewTransactionRepository(store), wallets, nil)
+	if _, err := refunds.RefundGiftSend(ctx, entries(t, store, fan.ID)[0].ID, "sent twice"); err != nil {
+		t.Fatalf("RefundGiftSend: %v", err)
+	}
+	if _, err := wallets.SendGift(ctx, artistID, otherGift.ID); err != nil {
+		t.Fatalf("SendGift: %v", err)
+	}
+
+	payouts := newPayoutService(store, &fakePayouts{})
+	balance, err := payouts.GetBalance(ctx, artistID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Earned != 50 {
+		t.Errorf("earned = %d, want 50", balance.Earned)
+	}
+	// Only what is left in the wallet can be withdrawn
+	if balance.Withdrawable != 20 {
+		t.Errorf("withdrawable = %d, want 20", balance.Withdrawable)
+	}
+	if balance.WithdrawableMinor != 160 {
+		t.Errorf("withdrawable minor = %d, want 160", balance.WithdrawableMinor)
+	}
+
+	// Tokens bought are spendable but not earnings
+	artistWallet := fundedWallet(t, store, artistID, 500)
+	if balance, err = payouts.GetBalance(ctx, artistID); err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Withdrawable != 50 {
+		t.Errorf("withdrawable with bought tokens = %d, want 50", balance.Withdrawable)
+	}
+
+	readyAccount(t, store, artistID)
+	payout, err := payouts.RequestPayout(ctx, artistID, 50)
+	if err != nil {
+		t.Fatalf("RequestPayout: %v", err)
+	}
+	if payout.GrossMinor != 500 || payout.FeeMinor != 100 || payout.AmountMinor != 400 {
+		t.Errorf("payout value = %d gross, %d fee, %d net, want 500, 100, 400", payout.GrossMinor, payout.FeeMinor, payout.AmountMinor)
+	}
+	if _, err := payouts.RequestPayout(ctx, artistID, 1); !errors.Is(err, domain.ErrInsufficientEarnings) {
+		t.Fatalf("second RequestPayout error = %v, want %v", err, domain.ErrInsufficientEarnings)
+	}
+	if balance, err = payouts.GetBalance(ctx, artistID); err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Pending != 50 || balance.Withdrawable != 0 {
+		t.Errorf("balance while requested = %+v, want 50 pending and nothing withdrawable", balance)
+	}
+
+	if payout, err = payouts.ApprovePayout(ctx, payout.ID); err != nil {
+		t.Fatalf("ApprovePayout: %v", err)
+	}
+	if payout.Status != domain.PayoutPaid {
+		t.Errorf("status = %s, want %s", payout.Status, domain.PayoutPaid)
+	}
+	if balance, err = payouts.GetBalance(ctx, artistID); err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.PaidOut != 50 || balance.Pending != 0 || balance.Withdrawable != 0 {
+		t.Errorf("balance once paid = %+v, want 50 paid out", balance)
+	}
+	wallet, err := wallets.GetWallet(ctx, artistWallet.ID)
+	if err != nil {
+		t.Fatalf("GetWallet: %v", err)
+	}
+	if wallet.Balance != 470 {
+		t.Errorf("wallet balance = %d, want 470", wallet.Balance)
+	}
+}
+
+func TestApprovePayoutTransferFailureReturnsTokens(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	fanID, artistID := uuid.NewString(), uuid.NewString()
+	fundedWallet(t, store, fanID, 100)
+	gift := createGift(t, store, artistID, 100, 0)
+	if _, err := newWalletService(store).SendGift(ctx, fanID, gift.ID); err != nil {
+		t.Fatalf("SendGift: %v", err)
+	}
+	readyAccount(t, store, artistID)
+
+	payouts := newPayoutService(store, &fakePayouts{transferErr: fmt.Errorf("%w: account closed", domain.ErrPaymentRejected)})
+	payout, err := payouts.RequestPayout(ctx, artistID, 100)
+	if err != nil {
+		t.Fatalf("RequestPayout: %v", err)
+	}
+	if payout, err = payouts.ApprovePayout(ctx, payout.ID); err != nil {
+		t.Fatalf("ApprovePayout: %v", err)
+	}
+	if payout.Status != domain.PayoutFailed {
+		t.Errorf("status = %s, want %s", payout.Status, domain.PayoutFailed)
+	}
+	balance, err := payouts.GetBalance(ctx, artistID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Withdrawable != 100 || balance.Pending != 0 {
+		t.Errorf("balance = %+v, want the 100 tokens withdrawable again", balance)
+	}
+}
+
+func TestUnknownTransferOutcomeIsReconciled(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	fanID, artistID := uuid.NewString(), uuid.NewString()
+	fundedWallet(t, store, fanID, 100)
+	gift := createGift(t, store, artistID, 100, 0)
+	if _, err := newWalletService(store).SendGift(ctx, fanID, gift.ID); err != nil {
+		t.Fatalf("SendGift: %v", err)
+	}
+	readyAccount(t, store, artistID)
+
+	provider := &fakePayouts{transferErr: errors.New("connection reset by peer")}
+	payouts := newPayoutService(store, provider)
+	payout, err := payouts.RequestPayout(ctx, artistID, 100)
+	if err != nil {
+		t.Fatalf("RequestPayout: %v", err)
+	}
+
+	// The provider may have sent the money, so the tokens are not returned
+	if payout, err = payouts.ApprovePayout(ctx, payout.ID); err != nil {
+		t.Fatalf("ApprovePayout: %v", err)
+	}
+	if payout.Status != domain.PayoutProcessing {
+		t.Errorf("status = %s, want %s", payout.Status, domain.PayoutProcessing)
+	}
+	balance, err := payouts.GetBalance(ctx, artistID)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Pending != 100 || balance.Withdrawable != 0 {
+		t.Errorf("balance = %+v, want the 100 tokens pending", balance)
+	}
+
+	provider.transferErr = nil
+	settled, err := payouts.ReconcilePayouts(ctx, time.Now().Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ReconcilePayouts: %v", err)
+	}
+	if settled != 1 {
+		t.Errorf("settled = %d, want 1", settled)
+	}
+	if payout, err = payouts.GetPayout(ctx, payout.ID); err != nil {
+		t.Fatalf("GetPayout: %v", err)
+	}
+	if payout.Status != domain.PayoutPaid {
+		t.Errorf("status = %s, want %s", payout.Status, domain.PayoutPaid)
+	}
+	if got := countEvents(store, domain.EventPayoutCompleted); got != 1 {
+		t.Errorf("%s events = %d, want 1", domain.EventPayoutCompleted, got)
+	}
+}
+
+func TestRequestPayoutRejectsOverflowingValue(t *testing.T) {
+	store := memory.NewStore()
+	artistID := uuid.NewString()
+	readyAccount(t, store, artistID)
+
+	_, err := newPayoutService(store, &fakePayouts{}).RequestPayout(context.Background(), artistID, math.MaxInt64/payoutTerms.TokenValueMinor+1)
+	if !errors.Is(err, domain.ErrInvalidPayout) {
+		t.Fatalf("RequestPayout error = %v, want %v", err, domain.ErrInvalidPayout)
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RefundServiceImpl struct {
+	repo         domain.RefundRepository
+	purchases    domain.PurchaseRepository
+	transactions domain.TransactionRepository
+	wallets      domain.WalletService
+	// provider is nil when payments are not configured; purchases cannot be refunded then
+	provider domain.PaymentProvider
+}
+
+func NewRefundService(repo domain.RefundRepository, purchases domain.PurchaseRepository, transactions domain.TransactionRepository, wallets domain.WalletService, provider domain.PaymentProvider) domain.RefundService {
+	return &RefundServiceImpl{repo: repo, purchases: purchases, transactions: transactions, wallets: wallets, provider: provider}
+}
+
+func (s *RefundServiceImpl) RefundPurchase(ctx context.Context, purchaseID, reason string) (_ *domain.Refund, err error) {
+	ctx, span := tracing.Start(ctx, "RefundService.RefundPurchase")
+	defer tracing.End(span, &err)
+
+	if s.provider == nil {
+		return nil, fmt.Errorf("%w: payments are not configured", domain.ErrNotRefundable)
+	}
+	purchase, err := s.purchases.GetPurchase(ctx, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallets.GetWalletForOwner(ctx, purchase.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := s.newRefund(ctx, domain.RefundPurchase, reason)
+	refund.PurchaseID = purchase.ID
+	refund.WalletID = wallet.ID
+	refund.Tokens = purchase.Tokens
+	refund.AmountMinor = purchase.AmountMinor
+	refund.Currency = purchase.Currency
+	refund.Status = domain.RefundPending
+
+	// The tokens are taken back before the money is returned, so a buyer who
+	// already spent them is not refunded
+	if err := s.repo.StartPurchaseRefund(ctx, refund); err != nil {
+		return nil, err
+	}
+
+	providerRef, err := s.refundPayment(ctx, *purchase, refund.ID)
+	if err := s.settleRefund(ctx, refund, providerRef, err); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRefund(ctx, refund.ID)
+}
+
+func (s *RefundServiceImpl) ReconcileRefunds(ctx context.Context, before time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "RefundService.ReconcileRefunds")
+	defer tracing.End(span, &err)
+
+	if s.provider == nil {
+		return 0, nil
+	}
+	refunds, err := s.repo.ListPendingRefunds(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, refund := range refunds {
+		purchase, err := s.purchases.GetPurchase(ctx, refund.PurchaseID)
+		if err != nil {
+			return settled, err
+		}
+		// The provider returns the outcome of the first attempt for the same refund ID
+		providerRef, err := s.refundPayment(ctx, *purchase, refund.ID)
+		if err := s.settleRefund(ctx, refund, providerRef, err); err != nil {
+			return settled, err
+		}
+		if err == nil || errors.Is(err, domain.ErrPaymentRejected) {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// settleRefund applies the provider's answer to a pending purchase refund. It
+// only fails the refund, returning the tokens, when the provider rejected it;
+// otherwise the money may have been returned, so the refund stays pending
+// until ReconcileRefunds learns the outcome.
+func (s *RefundServiceImpl) settleRefund(ctx context.Context, refund domain.Refund, providerRef string, refundErr error) error {
+	// The money has moved or not by now, so the outcome is recorded even if the caller gave up
+	ctx = context.WithoutCancel(ctx)
+	logger := logging.FromContext(ctx).With("refund_id", refund.ID, "purchase_id", refund.PurchaseID)
+
+	switch {
+	case errors.Is(refundErr, domain.ErrPaymentRejected):
+		logger.Error("Payment refund rejected", "error", refundErr)
+		if err := s.repo.FailPurchaseRefund(ctx, refund.ID, refundErr.Error()); err != nil {
+			return err
+		}
+		metrics.Refunds.WithLabelValues(refund.Kind, domain.RefundFailed).Inc()
+		return nil
+	case refundErr != nil:
+		logger.Warn("Payment refund outcome unknown, left pending", "error", refundErr)
+		return nil
+	}
+
+	refund.Status = domain.RefundSucceeded
+	event, err := domain.NewEvent(domain.EventRefundSucceeded, refund.ID, refund)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CompleteRefund(ctx, refund.ID, providerRef, event); err != nil {
+		return err
+	}
+	logger.Info("Purchase refunded", "tokens", refund.Tokens, "amount_minor", refund.AmountMinor)
+	metrics.Refunds.WithLabelValues(refund.Kind, domain.RefundSucceeded).Inc()
+	return nil
+}
+
+// refundPayment calls the provider in its own span, like createCheckout
+func (s *RefundServiceImpl) refundPayment(ctx context.Context, purchase domain.Purchase, refundID string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentProvider.Refund", attribute.String("payment.provider", s.provider.Name()))
+	defer tracing.End(span, &err)
+
+	return s.provider.Refund(ctx, purchase, refundID)
+}
+
+func (s *RefundServiceImpl) RefundGiftSend(ctx context.Context, transactionID, reason string) (_ *domain.Refund, err error) {
+	ctx, span := tracing.Start(ctx, "RefundService.RefundGiftSend")
+	defer tracing.End(span, &err)
+
+	entry, err := s.transactions.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != domain.TransactionDebit || entry.GiftID == "" || entry.CounterpartyWalletID == "" || entry.RefundID != "" {
+		return nil, fmt.Errorf("%w: only the sender's debit of a gift send can be refunded", domain.ErrNotRefundable)
+	}
+
+	refund := s.newRefund(ctx, domain.RefundGiftSend, reason)
+	refund.TransactionID = entry.ID
+	refund.GiftID = entry.GiftID
+	refund.WalletID = entry.WalletID
+	refund.CounterpartyWalletID = entry.CounterpartyWalletID
+	refund.Tokens = entry.Amount
+	refund.Status = domain.RefundSucceeded
+
+	event, err := domain.NewEvent(domain.EventRefundSucceeded, refund.ID, refund)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RefundGiftSend(ctx, refund, event); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift send refunded", "refund_id", refund.ID, "transaction_id", entry.ID, "tokens", refund.Tokens)
+	metrics.Refunds.WithLabelValues(refund.Kind, domain.RefundSucceeded).Inc()
+	return &refund, nil
+}
+
+func (s *RefundServiceImpl) GetRefund(ctx context.Context, id string) (*domain.Refund, error) {
+	return s.repo.GetRefund(ctx, id)
+}
+
+func (s *RefundServiceImpl) ListRefunds(ctx context.Context, filter domain.RefundFilter) ([]domain.Refund, int64, error) {
+	return s.repo.ListRefunds(ctx, filter)
+}
+
+// newRefund starts a refund requested by the account in ctx; automated rules
+// run without one and leave RequestedBy empty
+func (s *RefundServiceImpl) newRefund(ctx context.Context, kind, reason string) domain.Refund {
+	now := time.Now()
+	refund := domain.Refund{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Reason:    reason,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if identity, ok := domain.IdentityFromContext(ctx); ok {
+		refund.RequestedBy = identity.ArtistID
+	}
+	return refund
+}
@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+
+	"github.com/google/uuid"
+)
+
+func newRefundService(store *memory.Store, provider domain.PaymentProvider) domain.RefundService {
+	return service.NewRefundService(memory.NewRefundRepository(store), memory.NewPurchaseRepository(store), memory.NewTransactionRepository(store), newWalletService(store), provider)
+}
+
+// paidPurchase stores a purchase of tokens and credits it through its webhook
+func paidPurchase(t *testing.T, store *memory.Store, payments domain.PaymentService, buyerID string, tokens int64) domain.Purchase {
+	t.Helper()
+	purchase := pendingPurchase(t, store, buyerID, tokens)
+	if err := payments.HandleWebhook(context.Background(), webhook(t, purchase.ID, domain.PurchaseSucceeded), ""); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+	return purchase
+}
+
+func TestRefundedPurchaseIsNotCreditedAgain(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	provider := &fakePayments{}
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), provider)
+	purchase := paidPurchase(t, store, payments, buyerID, 500)
+
+	refund, err := newRefundService(store, provider).RefundPurchase(ctx, purchase.ID, "chargeback")
+	if err != nil {
+		t.Fatalf("RefundPurchase: %v", err)
+	}
+	if refund.Status != domain.RefundSucceeded {
+		t.Fatalf("refund status = %s, want %s", refund.Status, domain.RefundSucceeded)
+	}
+
+	// A late redelivery of the payment webhook, or a failure report before it
+	for _, status := range []string{domain.PurchaseSucceeded, domain.PurchaseFailed, domain.PurchaseSucceeded} {
+		if err := payments.HandleWebhook(ctx, webhook(t, purchase.ID, status), ""); err != nil {
+			t.Fatalf("HandleWebhook %s: %v", status, err)
+		}
+	}
+	// A delivery that read the purchase before it was refunded
+	if err := memory.NewPurchaseRepository(store).CompletePurchase(ctx, purchase.ID, wallet.ID); err != nil {
+		t.Fatalf("CompletePurchase: %v", err)
+	}
+
+	if got := balance(t, store, wallet.ID); got != 0 {
+		t.Errorf("balance = %d, want 0", got)
+	}
+	if got := len(entries(t, store, wallet.ID)); got != 2 {
+		t.Errorf("ledger entries = %d, want the credit and its refund", got)
+	}
+	stored, err := payments.GetPurchase(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("GetPurchase: %v", err)
+	}
+	if stored.Status != domain.PurchaseRefunded {
+		t.Errorf("purchase status = %s, want %s", stored.Status, domain.PurchaseRefunded)
+	}
+}
+
+func TestRejectedRefundRestoresPurchase(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	provider := &fakePayments{refundErr: fmt.Errorf("%w: charge disputed", domain.ErrPaymentRejected)}
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), provider)
+	purchase := paidPurchase(t, store, payments, buyerID, 500)
+
+	refund, err := newRefundService(store, provider).RefundPurchase(ctx, purchase.ID, "chargeback")
+	if err != nil {
+		t.Fatalf("RefundPurchase: %v", err)
+	}
+	if refund.Status != domain.RefundFailed || refund.FailureReason == "" {
+		t.Errorf("refund = %s with reason %q, want %s with a reason", refund.Status, refund.FailureReason, domain.RefundFailed)
+	}
+	if got := balance(t, store, wallet.ID); got != 500 {
+		t.Errorf("balance = %d, want 500", got)
+	}
+	stored, err := payments.GetPurchase(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("GetPurchase: %v", err)
+	}
+	if stored.Status != domain.PurchaseSucceeded {
+		t.Errorf("purchase status = %s, want %s", stored.Status, domain.PurchaseSucceeded)
+	}
+}
+
+func TestUnknownRefundOutcomeIsReconciled(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	buyerID := uuid.NewString()
+	wallet := fundedWallet(t, store, buyerID, 0)
+	provider := &fakePayments{refundErr: errors.New("connection reset by peer")}
+	payments := service.NewPaymentService(memory.NewPurchaseRepository(store), newWalletService(store), provider)
+	purchase := paidPurchase(t, store, payments, buyerID, 500)
+	refunds := newRefundService(store, provider)
+
+	// The provider may have refunded the money, so the tokens stay taken back
+	refund, err := refunds.RefundPurchase(ctx, purchase.ID, "chargeback")
+	if err != nil {
+		t.Fatalf("RefundPurchase: %v", err)
+	}
+	if refund.Status != domain.RefundPending {
+		t.Errorf("refund status = %s, want %s", refund.Status, domain.RefundPending)
+	}
+	if got := balance(t, store, wallet.ID); got != 0 {
+		t.Errorf("balance = %d, want 0", got)
+	}
+
+	// Still unknown on the next run
+	settled, err := refunds.ReconcileRefunds(ctx, time.Now().Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ReconcileRefunds: %v", err)
+	}
+	if settled != 0 {
+		t.Errorf("settled = %d, want 0", settled)
+	}
+
+	provider.refundErr = nil
+	if settled, err = refunds.ReconcileRefunds(ctx, time.Now().Add(time.Minute), 10); err != nil {
+		t.Fatalf("ReconcileRefunds: %v", err)
+	}
+	if settled != 1 {
+		t.Errorf("settled = %d, want 1", settled)
+	}
+	if refund, err = refunds.GetRefund(ctx, refund.ID); err != nil {
+		t.Fatalf("GetRefund: %v", err)
+	}
+	if refund.Status != domain.RefundSucceeded {
+		t.Errorf("refund status = %s, want %s", refund.Status, domain.RefundSucceeded)
+	}
+	if got := countEvents(store, domain.EventRefundSucceeded); got != 1 {
+		t.Errorf("%s events = %d, want 1", domain.EventRefundSucceeded, got)
+	}
+	if provider.refunds != 3 {
+		t.Errorf("provider refund calls = %d, want 3", provider.refunds)
+	}
+}
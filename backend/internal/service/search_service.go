@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/tracing"
+)
+
+type SearchServiceImpl struct {
+	repo domain.SearchRepository
+}
+
+func NewSearchService(repo domain.SearchRepository) domain.SearchService {
+	return &SearchServiceImpl{repo: repo}
+}
+
+func (s *SearchServiceImpl) Search(ctx context.Context, query domain.SearchQuery) (_ []domain.SearchResult, _ int64, err error) {
+	ctx, span := tracing.Start(ctx, "SearchService.Search")
+	defer tracing.End(span, &err)
+
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return []domain.SearchResult{}, 0, nil
+	}
+	return s.repo.Search(ctx, query)
+}
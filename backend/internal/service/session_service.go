@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+// maxUserAgentLen bounds the user agent kept to describe a session
+const maxUserAgentLen = 255
+
+type SessionServiceImpl struct {
+	repo       domain.SessionRepository
+	artists    domain.ArtistRepository
+	tokens     domain.TokenIssuer
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewSessionService returns a session service issuing access tokens valid for
+// accessTTL. Sessions expire when they go unrefreshed for refreshTTL.
+func NewSessionService(repo domain.SessionRepository, artists domain.ArtistRepository, tokens domain.TokenIssuer, accessTTL, refreshTTL time.Duration) domain.SessionService {
+	return &SessionServiceImpl{repo: repo, artists: artists, tokens: tokens, accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+func (s *SessionServiceImpl) StartSession(ctx context.Context, artist domain.Artist, device domain.Device) (*domain.TokenPair, error) {
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := domain.Session{
+		ID:         uuid.NewString(),
+		ArtistID:   artist.ID,
+		TokenHash:  hashToken(refreshToken),
+		UserAgent:  truncate(device.UserAgent, maxUserAgentLen),
+		IP:         device.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+	}
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Session started", "session_id", session.ID, "artist_id", artist.ID)
+
+	return s.issue(artist, session.ID, refreshToken)
+}
+
+func (s *SessionServiceImpl) Refresh(ctx context.Context, refreshToken string, device domain.Device) (*domain.TokenPair, error) {
+	hash := hashToken(refreshToken)
+	session, err := s.repo.GetSessionByTokenHash(ctx, hash)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.TokenHash != hash {
+		// The token was already exchanged, so it has been copied: end the
+		// session rather than guess which of its holders is legitimate
+		if session.RevokedAt == nil {
+			if err := s.repo.RevokeSession(ctx, session.ID, now); err != nil {
+				return nil, err
+			}
+			logging.FromContext(ctx).Warn("Refresh token reused, session revoked", "session_id", session.ID, "artist_id", session.ArtistID)
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if !session.Active(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// Access tokens carry the account's current role, and the session dies with the account
+	artist, err := s.artists.GetArtistByID(ctx, session.ArtistID)
+	if errors.Is(err, domain.ErrArtistNotFound) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	session.TokenHash = hashToken(next)
+	session.UserAgent = truncate(device.UserAgent, maxUserAgentLen)
+	session.IP = device.IP
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(s.refreshTTL)
+	if err := s.repo.RotateSession(ctx, *session, hash); err != nil {
+		return nil, err
+	}
+
+	return s.issue(*artist, session.ID, next)
+}
+
+func (s *SessionServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	session, err := s.repo.GetSessionByTokenHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	return s.RevokeSession(ctx, session.ID)
+}
+
+func (s *SessionServiceImpl) GetSession(ctx context.Context, id string) (*domain.Session, error) {
+	return s.repo.GetSession(ctx, id)
+}
+
+func (s *SessionServiceImpl) ListSessions(ctx context.Context, artistID string) ([]domain.Session, error) {
+	return s.repo.ListSessions(ctx, artistID, time.Now())
+}
+
+// RevokeSession ends a session. Access tokens already issued to it stay
+// valid until they expire.
+func (s *SessionServiceImpl) RevokeSession(ctx context.Context, id string) error {
+	if err := s.repo.RevokeSession(ctx, id, time.Now()); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Session revoked", "session_id", id)
+	return nil
+}
+
+func (s *SessionServiceImpl) issue(artist domain.Artist, sessionID, refreshToken string) (*domain.TokenPair, error) {
+	accessToken, err := s.tokens.Issue(artist.ID, artist.Role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+func newRefreshToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// truncate cuts s to at most n bytes without splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
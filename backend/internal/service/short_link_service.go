@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+const (
+	shortLinkAlphabet   = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	shortLinkCodeLength = 7
+	shortLinkAttempts   = 5
+)
+
+type ShortLinkServiceImpl struct {
+	repo domain.ShortLinkRepository
+	// hosts are the lowercased hosts links may redirect to
+	hosts []string
+}
+
+// NewShortLinkService creates a service whose links only redirect to hosts
+func NewShortLinkService(repo domain.ShortLinkRepository, hosts []string) domain.ShortLinkService {
+	allowed := make([]string, len(hosts))
+	for i, host := range hosts {
+		allowed[i] = strings.ToLower(host)
+	}
+	return &ShortLinkServiceImpl{repo: repo, hosts: allowed}
+}
+
+func (s *ShortLinkServiceImpl) CreateShortLink(ctx context.Context, link domain.ShortLink) (*domain.ShortLink, error) {
+	target, err := url.Parse(link.TargetURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, domain.ErrInvalidTargetURL
+	}
+	// Links redirect from the platform's domain, so they may not lead off it
+	if !slices.Contains(s.hosts, strings.ToLower(target.Hostname())) {
+		return nil, domain.ErrInvalidTargetURL
+	}
+
+	link.ID = uuid.NewString()
+	link.CreatedAt = time.Now()
+
+	// A caller-chosen code is used as is; generated codes are retried on collision
+	if link.Code != "" {
+		if err := s.repo.CreateShortLink(ctx, link); err != nil {
+			return nil, err
+		}
+		logging.FromContext(ctx).Info("Short link created", "code", link.Code)
+		return &link, nil
+	}
+
+	for attempt := 0; attempt < shortLinkAttempts; attempt++ {
+		link.Code, err = generateCode()
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.repo.CreateShortLink(ctx, link)
+		if err == nil {
+			logging.FromContext(ctx).Info("Short link created", "code", link.Code)
+			return &link, nil
+		}
+		if !errors.Is(err, domain.ErrShortLinkExists) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (s *ShortLinkServiceImpl) GetShortLink(ctx context.Context, code string) (*domain.ShortLink, error) {
+	return s.repo.GetShortLinkByCode(ctx, code)
+}
+
+func (s *ShortLinkServiceImpl) Resolve(ctx context.Context, code string, click domain.ShortLinkClick, utm map[string]string) (string, error) {
+	link, err := s.repo.GetShortLinkByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if link.ExpiresAt != nil && time.Now().After(*link.ExpiresAt) {
+		return "", domain.ErrShortLinkExpired
+	}
+
+	click.ShortLinkID = link.ID
+	click.CreatedAt = time.Now()
+	if err := s.repo.RecordClick(ctx, click); err != nil {
+		return "", err
+	}
+
+	target, err := url.Parse(link.TargetURL)
+	if err != nil {
+		return "", err
+	}
+
+	// The link's own UTM parameters are defaults; ones passed on the short URL win
+	query := target.Query()
+	for key, value := range map[string]string{
+		"utm_source":   link.UTMSource,
+		"utm_medium":   link.UTMMedium,
+		"utm_campaign": link.UTMCampaign,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	for key, value := range utm {
+		query.Set(key, value)
+	}
+	target.RawQuery = query.Encode()
+
+	return target.String(), nil
+}
+
+func (s *ShortLinkServiceImpl) GetStats(ctx context.Context, code string) (*domain.ShortLinkStats, error) {
+	link, err := s.repo.GetShortLinkByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetClickStats(ctx, link.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Code = link.Code
+	return stats, nil
+}
+
+func generateCode() (string, error) {
+	code := make([]byte, shortLinkCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(shortLinkAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = shortLinkAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+	"tokentide/internal/service"
+)
+
+func newShortLinkService(store *memory.Store) domain.ShortLinkService {
+	return service.NewShortLinkService(memory.NewShortLinkRepository(store), []string{"tokentide.app", "www.tokentide.app"})
+}
+
+func TestCreateShortLinkOnPlatformHost(t *testing.T) {
+	links := newShortLinkService(memory.NewStore())
+
+	link, err := links.CreateShortLink(context.Background(), domain.ShortLink{TargetURL: "https://WWW.tokentide.app/artists/rose?tab=gifts"})
+	if err != nil {
+		t.Fatalf("CreateShortLink: %v", err)
+	}
+	if link.Code == "" {
+		t.Error("code was not generated")
+	}
+}
+
+func TestCreateShortLinkRejectsOffPlatformTargets(t *testing.T) {
+	links := newShortLinkService(memory.NewStore())
+
+	for _, target := range []string{
+		"https://evil.example/login",
+		"//evil.example/login",
+		"https://tokentide.app@evil.example/login",
+		"https://tokentide.app.evil.example/login",
+		"javascript:alert(1)",
+	} {
+		_, err := links.CreateShortLink(context.Background(), domain.ShortLink{TargetURL: target})
+		if !errors.Is(err, domain.ErrInvalidTargetURL) {
+			t.Errorf("CreateShortLink(%q) error = %v, want %v", target, err, domain.ErrInvalidTargetURL)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+// recentIncidentWindow is how long resolved incidents stay on the status page
+const recentIncidentWindow = 7 * 24 * time.Hour
+
+var componentSeverity = map[string]int{
+	domain.ComponentOperational:   0,
+	domain.ComponentDegraded:      1,
+	domain.ComponentPartialOutage: 2,
+	domain.ComponentMajorOutage:   3,
+}
+
+var incidentStatuses = map[string]bool{
+	domain.IncidentInvestigating: true,
+	domain.IncidentIdentified:    true,
+	domain.IncidentMonitoring:    true,
+}
+
+type StatusServiceImpl struct {
+	repo    domain.IncidentRepository
+	tracker domain.UptimeTracker
+}
+
+func NewStatusService(repo domain.IncidentRepository, tracker domain.UptimeTracker) domain.StatusService {
+	return &StatusServiceImpl{repo: repo, tracker: tracker}
+}
+
+func (s *StatusServiceImpl) GetStatus(ctx context.Context) (*domain.Status, error) {
+	incidents, err := s.repo.ListIncidents(ctx, time.Now().Add(-recentIncidentWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.Status{
+		Status:          domain.ComponentOperational,
+		ActiveIncidents: []domain.Incident{},
+		RecentIncidents: []domain.Incident{},
+	}
+
+	components := map[string]*domain.ComponentStatus{}
+	for _, uptime := range s.tracker.Uptime() {
+		component := &domain.ComponentStatus{
+			Name:          uptime.Name,
+			Status:        domain.ComponentOperational,
+			UptimePercent: uptime.UptimePercent,
+			AvgLatencyMS:  uptime.AvgLatencyMS,
+		}
+		if !uptime.Healthy {
+			component.Status = domain.ComponentDegraded
+		}
+		components[uptime.Name] = component
+	}
+
+	for _, incident := range incidents {
+		if incident.ResolvedAt != nil {
+			status.RecentIncidents = append(status.RecentIncidents, incident)
+			continue
+		}
+		status.ActiveIncidents = append(status.ActiveIncidents, incident)
+
+		// Incidents may name components the prober doesn't know, e.g. "payments"
+		component, ok := components[incident.Component]
+		if !ok {
+			component = &domain.ComponentStatus{Name: incident.Component, Status: domain.ComponentOperational, UptimePercent: 100}
+			components[incident.Component] = component
+		}
+		component.Status = worst(component.Status, incident.Impact)
+	}
+
+	for _, component := range components {
+		status.Components = append(status.Components, *component)
+		status.Status = worst(status.Status, component.Status)
+	}
+	sort.Slice(status.Components, func(i, j int) bool {
+		return status.Components[i].Name < status.Components[j].Name
+	})
+
+	return status, nil
+}
+
+func (s *StatusServiceImpl) OpenIncident(ctx context.Context, incident domain.Incident, message string) (*domain.Incident, error) {
+	if incident.Component == "" || incident.Title == "" {
+		return nil, fmt.Errorf("%w: component and title are required", domain.ErrInvalidIncident)
+	}
+	if err := validateImpact(incident.Impact); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	incident.ID = uuid.NewString()
+	incident.Status = domain.IncidentInvestigating
+	incident.StartedAt = now
+	incident.ResolvedAt = nil
+	incident.Updates = []domain.IncidentUpdate{{
+		Status:    domain.IncidentInvestigating,
+		Message:   message,
+		CreatedAt: now,
+	}}
+
+	if err := s.repo.CreateIncident(ctx, incident); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Incident opened", "incident_id", incident.ID, "component", incident.Component, "impact", incident.Impact)
+	return &incident, nil
+}
+
+func (s *StatusServiceImpl) UpdateIncident(ctx context.Context, id, status, impact, message string) (*domain.Incident, error) {
+	if !incidentStatuses[status] {
+		return nil, fmt.Errorf("%w: status must be investigating, identified or monitoring", domain.ErrInvalidIncident)
+	}
+
+	incident, err := s.repo.GetIncidentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.ResolvedAt != nil {
+		return nil, fmt.Errorf("%w: incident is already resolved", domain.ErrInvalidIncident)
+	}
+
+	if impact != "" {
+		if err := validateImpact(impact); err != nil {
+			return nil, err
+		}
+		incident.Impact = impact
+	}
+	incident.Status = status
+
+	return s.appendUpdate(ctx, incident, message)
+}
+
+func (s *StatusServiceImpl) ResolveIncident(ctx context.Context, id, message string) (*domain.Incident, error) {
+	incident, err := s.repo.GetIncidentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.ResolvedAt != nil {
+		return nil, fmt.Errorf("%w: incident is already resolved", domain.ErrInvalidIncident)
+	}
+
+	now := time.Now()
+	incident.Status = domain.IncidentResolved
+	incident.ResolvedAt = &now
+
+	return s.appendUpdate(ctx, incident, message)
+}
+
+func (s *StatusServiceImpl) appendUpdate(ctx context.Context, incident *domain.Incident, message string) (*domain.Incident, error) {
+	update := domain.IncidentUpdate{
+		Status:    incident.Status,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.UpdateIncident(ctx, *incident, update); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Incident updated", "incident_id", incident.ID, "status", incident.Status)
+
+	incident.Updates = append(incident.Updates, update)
+	return incident, nil
+}
+
+func validateImpact(impact string) error {
+	if componentSeverity[impact] == 0 {
+		return fmt.Errorf("%w: impact must be degraded, partial_outage or major_outage", domain.ErrInvalidIncident)
+	}
+	return nil
+}
+
+func worst(a, b string) string {
+	if componentSeverity[b] > componentSeverity[a] {
+		return b
+	}
+	return a
+}
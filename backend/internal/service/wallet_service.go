@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/metrics"
+	"tokentide/internal/tracing"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+type WalletServiceImpl struct {
+	repo         domain.WalletRepository
+	transactions domain.TransactionRepository
+	gifts        domain.GiftRepository
+	currencies   domain.CurrencyService
+	events       domain.EventPublisher
+	tx           domain.TxManager
+}
+
+func NewWalletService(repo domain.WalletRepository, transactions domain.TransactionRepository, gifts domain.GiftRepository, currencies domain.CurrencyService, events domain.EventPublisher, tx domain.TxManager) domain.WalletService {
+	return &WalletServiceImpl{repo: repo, transactions: transactions, gifts: gifts, currencies: currencies, events: events, tx: tx}
+}
+
+func (s *WalletServiceImpl) GetWallet(ctx context.Context, id string) (*domain.Wallet, error) {
+	return s.repo.GetWalletByID(ctx, id)
+}
+
+func (s *WalletServiceImpl) GetWalletForOwner(ctx context.Context, ownerID string) (_ *domain.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetWalletForOwner")
+	defer tracing.End(span, &err)
+
+	wallet, err := s.repo.GetWalletByOwner(ctx, ownerID)
+	if !errors.Is(err, domain.ErrWalletNotFound) {
+		return wallet, err
+	}
+
+	now := time.Now()
+	err = s.repo.CreateWallet(ctx, domain.Wallet{
+		ID:        uuid.NewString(),
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-read in case a concurrent request created the wallet first
+	return s.repo.GetWalletByOwner(ctx, ownerID)
+}
+
+func (s *WalletServiceImpl) Credit(ctx context.Context, walletID string, amount int64) (_ *domain.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Credit")
+	defer tracing.End(span, &err)
+
+	if amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	wallet, err := s.repo.AdjustBalance(ctx, walletID, amount)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Wallet credited", "wallet_id", walletID, "amount", amount)
+	return wallet, nil
+}
+
+func (s *WalletServiceImpl) Debit(ctx context.Context, walletID string, amount int64) (_ *domain.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Debit")
+	defer tracing.End(span, &err)
+
+	if amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	wallet, err := s.repo.AdjustBalance(ctx, walletID, -amount)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Wallet debited", "wallet_id", walletID, "amount", amount)
+	return wallet, nil
+}
+
+func (s *WalletServiceImpl) SendGift(ctx context.Context, senderID, giftID string) (_ *domain.Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.SendGift")
+	defer tracing.End(span, &err)
+
+	gift, err := s.gifts.GetGiftByID(ctx, giftID)
+	if err != nil {
+		return nil, err
+	}
+	if gift.ArtistID == senderID {
+		return nil, fmt.Errorf("%w: cannot send your own gift", domain.ErrInvalidTransfer)
+	}
+
+	// Gifts are priced in money but paid in whole tokens, at the current rates
+	amount, err := s.currencies.TokenCost(ctx, gift.PriceMinor, gift.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	sent := domain.GiftSentEvent{
+		GiftID:   gift.ID,
+		GiftName: gift.Name,
+		SenderID: senderID,
+		ArtistID: gift.ArtistID,
+		Amount:   amount,
+		SentAt:   time.Now(),
+	}
+
+	// Wallets created for the send are rolled back with a transfer that fails
+	var from, to, wallet *domain.Wallet
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if from, err = s.GetWalletForOwner(ctx, senderID); err != nil {
+			return err
+		}
+		if to, err = s.GetWalletForOwner(ctx, gift.ArtistID); err != nil {
+			return err
+		}
+
+		transferred, err := domain.NewEvent(domain.EventTokensTransferred, from.ID, domain.TokensTransferred{
+			FromWalletID: from.ID,
+			ToWalletID:   to.ID,
+			Amount:       amount,
+			GiftID:       gift.ID,
+		})
+		if err != nil {
+			return err
+		}
+		giftSent, err := domain.NewEvent(domain.EventGiftSent, gift.ID, sent)
+		if err != nil {
+			return err
+		}
+
+		wallet, err = s.repo.Transfer(ctx, from.ID, to.ID, amount, gift.ID, transferred, giftSent)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Gift sent", "gift_id", gift.ID, "from_wallet_id", from.ID, "to_wallet_id", to.ID, "amount", amount)
+	metrics.GiftsSent.Inc()
+	metrics.TokensTransferred.Add(float64(amount))
+	s.events.PublishGiftSent(ctx, sent)
+	return wallet, nil
+}
+
+func (s *WalletServiceImpl) ListTransactions(ctx context.Context, walletID string, limit, offset int) ([]domain.Transaction, int64, error) {
+	return s.transactions.ListTransactions(ctx, walletID, limit, offset)
+}
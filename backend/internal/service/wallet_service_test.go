@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tokentide/internal/domain"
+	"tokentide/internal/repository/memory"
+
+	"github.com/google/uuid"
+)
+
+func TestSendGiftTransfersTokens(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	fanID, artistID := uuid.NewString(), uuid.NewString()
+	fan := fundedWallet(t, store, fanID, 100)
+	gift := createGift(t, store, artistID, 30, 0)
+
+	wallet, err := newWalletService(store).SendGift(ctx, fanID, gift.ID)
+	if err != nil {
+		t.Fatalf("SendGift: %v", err)
+	}
+	if wallet.Balance != 70 {
+		t.Errorf("sender balance = %d, want 70", wallet.Balance)
+	}
+	artist, err := memory.NewWalletRepository(store).GetWalletByOwner(ctx, artistID)
+	if err != nil {
+		t.Fatalf("artist wallet: %v", err)
+	}
+	if artist.Balance != 30 {
+		t.Errorf("artist balance = %d, want 30", artist.Balance)
+	}
+
+	// Each side records the other as counterparty
+	debit := entries(t, store, fan.ID)[0]
+	if debit.Type != domain.TransactionDebit || debit.Amount != 30 || debit.CounterpartyWalletID != artist.ID || debit.GiftID != gift.ID {
+		t.Errorf("sender entry = %+v", debit)
+	}
+	credit := entries(t, store, artist.ID)[0]
+	if credit.Type != domain.TransactionCredit || credit.Amount != 30 || credit.CounterpartyWalletID != fan.ID || credit.BalanceAfter != 30 {
+		t.Errorf("artist entry = %+v", credit)
+	}
+	if got := countEvents(store, domain.EventTokensTransferred); got != 1 {
+		t.Errorf("%s events = %d, want 1", domain.EventTokensTransferred, got)
+	}
+	if got := countEvents(store, domain.EventGiftSent); got != 1 {
+		t.Errorf("%s events = %d, want 1", domain.EventGiftSent, got)
+	}
+}
+
+func TestSendGiftWithoutFundsRollsBack(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	fanID, artistID := uuid.NewString(), uuid.NewString()
+	fan := fundedWallet(t, store, fanID, 10)
+	gift := createGift(t, store, artistID, 30, 5)
+
+	_, err := newWalletService(store).SendGift(ctx, fanID, gift.ID)
+	if !errors.Is(err, domain.ErrInsufficientFunds) {
+		t.Fatalf("SendGift error = %v, want %v", err, domain.ErrInsufficientFunds)
+	}
+	if got := balance(t, store, fan.ID); got != 10 {
+		t.Errorf("sender balance = %d, want 10", got)
+	}
+	// The artist's wallet was created within the failed send
+	if _, err := memory.NewWalletRepository(store).GetWalletByOwner(ctx, artistID); !errors.Is(err, domain.ErrWalletNotFound) {
+		t.Errorf("artist wallet error = %v, want %v", err, domain.ErrWalletNotFound)
+	}
+	stored, err := memory.NewGiftRepository(store).GetGiftByID(ctx, gift.ID)
+	if err != nil {
+		t.Fatalf("GetGiftByID: %v", err)
+	}
+	if stored.Sold != 0 {
+		t.Errorf("sold = %d, want 0", stored.Sold)
+	}
+	if events := store.Events(); len(events) != 0 {
+		t.Errorf("events = %d, want none", len(events))
+	}
+}
+
+func TestSendGiftSoldOut(t *testing.T) {
+	store := memory.NewStore()
+	ctx := context.Background()
+	fanID := uuid.NewString()
+	fan := fundedWallet(t, store, fanID, 100)
+	gift := createGift(t, store, uuid.NewString(), 30, 1)
+	wallets := newWalletService(store)
+
+	if _, err := wallets.SendGift(ctx, fanID, gift.ID); err != nil {
+		t.Fatalf("first SendGift: %v", err)
+	}
+	if _, err := wallets.SendGift(ctx, fanID, gift.ID); !errors.Is(err, domain.ErrGiftSoldOut) {
+		t.Fatalf("second SendGift error = %v, want %v", err, domain.ErrGiftSoldOut)
+	}
+	if got := balance(t, store, fan.ID); got != 70 {
+		t.Errorf("sender balance = %d, want 70", got)
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"tokentide/internal/domain"
+	"tokentide/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+type WebhookServiceImpl struct {
+	repo domain.WebhookRepository
+}
+
+func NewWebhookService(repo domain.WebhookRepository) domain.WebhookService {
+	return &WebhookServiceImpl{repo: repo}
+}
+
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, artistID, rawURL string) (*domain.Webhook, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	webhook := domain.Webhook{
+		ID:        uuid.NewString(),
+		ArtistID:  artistID,
+		URL:       target.String(),
+		Secret:    "whsec_" + hex.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("Webhook created", "webhook_id", webhook.ID, "artist_id", artistID)
+	return &webhook, nil
+}
+
+func (s *WebhookServiceImpl) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	return s.repo.GetWebhook(ctx, id)
+}
+
+func (s *WebhookServiceImpl) ListWebhooks(ctx context.Context, artistID string) ([]domain.Webhook, error) {
+	return s.repo.ListWebhooks(ctx, artistID)
+}
+
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, id string) error {
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Webhook deleted", "webhook_id", id)
+	return nil
+}
+
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]domain.WebhookDelivery, int64, error) {
+	return s.repo.ListDeliveries(ctx, webhookID, limit, offset)
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"tokentide/internal/domain"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxImageBytes bounds uploaded images
+	MaxImageBytes = 4 << 20
+	// maxImagePixels rejects images that would take too much memory to decode
+	maxImagePixels = 40_000_000
+	// maxImageSide is the longest side stored images are scaled down to
+	maxImageSide = 1024
+	jpegQuality  = 85
+)
+
+// Image is an upload ready to store
+type Image struct {
+	Data        []byte
+	ContentType string
+	// Ext is the file extension matching ContentType, such as ".jpg"
+	Ext string
+}
+
+// ProcessImage validates a JPEG, PNG, GIF or WebP upload, scales it down to
+// fit maxImageSide and re-encodes it, as JPEG when it is opaque and PNG
+// otherwise. Re-encoding also drops any metadata or trailing data.
+func ProcessImage(data []byte) (*Image, error) {
+	if len(data) > MaxImageBytes {
+		return nil, fmt.Errorf("%w: must be at most %d MB", domain.ErrInvalidImage, MaxImageBytes>>20)
+	}
+	switch http.DetectContentType(data) {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+	default:
+		return nil, fmt.Errorf("%w: must be a JPEG, PNG, GIF or WebP image", domain.ErrInvalidImage)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: dimensions %dx%d are not supported", domain.ErrInvalidImage, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImage, err)
+	}
+	img = fit(img, maxImageSide)
+
+	var buf bytes.Buffer
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		return &Image{Data: buf.Bytes(), ContentType: "image/jpeg", Ext: ".jpg"}, nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &Image{Data: buf.Bytes(), ContentType: "image/png", Ext: ".png"}, nil
+}
+
+// fit scales img down, keeping its aspect ratio, so neither side exceeds side
+func fit(img image.Image, side int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= side && height <= side {
+		return img
+	}
+	if width >= height {
+		width, height = side, max(1, height*side/width)
+	} else {
+		width, height = max(1, width*side/height), side
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3 keeps files in an S3 bucket, or any S3-compatible store such as MinIO.
+// Credentials come from the usual AWS sources: the environment, shared
+// config files or the instance role.
+type S3 struct {
+	client    *s3.Client
+	bucket    string
+	publicURL string
+}
+
+func NewS3(ctx context.Context, bucket, region, endpoint, publicURL string) (*S3, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			// S3-compatible stores rarely support virtual-hosted bucket names
+			o.UsePathStyle = true
+		}
+	})
+	return &S3{client: client, bucket: bucket, publicURL: strings.TrimSuffix(publicURL, "/")}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+		// Keys are never reused, so clients and CDNs may cache files for good
+		CacheControl: aws.String("public, max-age=31536000, immutable"),
+	})
+	return err
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3) URL(key string) string {
+	return s.publicURL + "/" + key
+}
@@ -0,0 +1,19 @@
+// Package storage keeps uploaded files, such as gift artwork, on the local
+// disk or in an S3 bucket
+package storage
+
+import (
+	"context"
+	"tokentide/internal/domain"
+	"tokentide/pkg/config"
+)
+
+// New returns the file storage selected in cfg
+func New(ctx context.Context, cfg config.StorageConfig) (domain.FileStorage, error) {
+	switch cfg.Backend {
+	case config.StorageS3:
+		return NewS3(ctx, cfg.S3Bucket, cfg.S3Region, cfg.S3Endpoint, cfg.PublicURL)
+	default:
+		return NewLocal(cfg.LocalDir, cfg.PublicURL), nil
+	}
+}
@@ -0,0 +1,83 @@
+// Package webhook turns domain events into signed HTTP deliveries to the
+// endpoints artists register
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/internal/jobs"
+
+	"github.com/riverqueue/river"
+)
+
+// Payload is the body of every delivery
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Notifier is an EventBroker that queues a delivery to the webhooks of the
+// artist each relevant event concerns. It runs as part of the outbox relay,
+// so every committed event is queued exactly once per webhook.
+type Notifier struct {
+	repo  domain.WebhookRepository
+	queue *jobs.Client
+}
+
+func NewNotifier(repo domain.WebhookRepository, queue *jobs.Client) *Notifier {
+	return &Notifier{repo: repo, queue: queue}
+}
+
+func (n *Notifier) Publish(ctx context.Context, events []domain.Event) error {
+	var args []river.JobArgs
+	for _, event := range events {
+		artistID, webhookType, ok, err := route(event)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		payload, err := json.Marshal(Payload{ID: event.ID, Type: webhookType, CreatedAt: event.OccurredAt, Data: event.Data})
+		if err != nil {
+			return err
+		}
+		deliveries, err := n.repo.EnqueueDeliveries(ctx, artistID, event.ID, webhookType, payload)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			args = append(args, jobs.WebhookDeliveryArgs{WebhookID: delivery.WebhookID, EventID: delivery.EventID})
+		}
+	}
+	return n.queue.Enqueue(ctx, args...)
+}
+
+func (n *Notifier) Close() error {
+	return nil
+}
+
+// route maps a domain event to the artist notified and the webhook event type
+func route(event domain.Event) (artistID, webhookType string, ok bool, err error) {
+	switch event.Type {
+	case domain.EventGiftSent:
+		var sent domain.GiftSentEvent
+		if err := json.Unmarshal(event.Data, &sent); err != nil {
+			return "", "", false, err
+		}
+		return sent.ArtistID, domain.WebhookGiftReceived, true, nil
+	case domain.EventPayoutCompleted:
+		var paid domain.PayoutCompleted
+		if err := json.Unmarshal(event.Data, &paid); err != nil {
+			return "", "", false, err
+		}
+		return paid.ArtistID, domain.WebhookPayoutCompleted, true, nil
+	default:
+		return "", "", false, nil
+	}
+}
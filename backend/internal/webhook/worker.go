@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+	"tokentide/internal/domain"
+	"tokentide/internal/jobs"
+	"tokentide/internal/metrics"
+	"tokentide/pkg/logging"
+
+	"github.com/riverqueue/river"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	baseBackoff    = 30 * time.Second
+	maxBackoff     = 6 * time.Hour
+
+	SignatureHeader = "X-Tokentide-Signature"
+	EventHeader     = "X-Tokentide-Event"
+	DeliveryHeader  = "X-Tokentide-Delivery"
+)
+
+var errPrivateAddress = errors.New("webhook address is on a private network")
+
+// DeliveryWorker sends queued deliveries. Failed attempts are retried by the
+// job queue with exponential backoff until the job runs out of attempts.
+type DeliveryWorker struct {
+	river.WorkerDefaults[jobs.WebhookDeliveryArgs]
+	repo   domain.WebhookRepository
+	client *http.Client
+}
+
+// NewDeliveryWorker builds a delivery worker. Unless allowPrivate is set,
+// deliveries to loopback, private and link-local addresses are refused so
+// webhooks cannot be used to reach internal services.
+func NewDeliveryWorker(repo domain.WebhookRepository, allowPrivate bool) *DeliveryWorker {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &DeliveryWorker{
+		repo: repo,
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			// A redirect could lead anywhere; receivers must answer directly
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (w *DeliveryWorker) Work(ctx context.Context, job *river.Job[jobs.WebhookDeliveryArgs]) error {
+	logger := logging.FromContext(ctx).With("webhook_id", job.Args.WebhookID, "event_id", job.Args.EventID)
+
+	// A deleted webhook takes its deliveries along, so there is nothing left to send
+	delivery, err := w.repo.GetDelivery(ctx, job.Args.WebhookID, job.Args.EventID)
+	if errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.Status == domain.DeliverySucceeded {
+		return nil
+	}
+	webhook, err := w.repo.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	status, sendErr := w.send(ctx, *webhook, *delivery)
+	delivery.Attempts = job.Attempt
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = time.Now()
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+	case job.Attempt >= job.MaxAttempts:
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = nil
+		logger.Warn("Webhook delivery abandoned", "delivery_id", delivery.ID, "attempts", delivery.Attempts, "error", sendErr)
+	default:
+		next := w.NextRetry(job)
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = &next
+	}
+	metrics.WebhookDeliveries.WithLabelValues(delivery.Status).Inc()
+
+	// Record the outcome even if shutdown started meanwhile
+	if err := w.repo.UpdateDelivery(context.WithoutCancel(ctx), *delivery); err != nil {
+		logger.Error("Could not record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+	return sendErr
+}
+
+// NextRetry waits exponentially longer after each failed attempt
+func (w *DeliveryWorker) NextRetry(job *river.Job[jobs.WebhookDeliveryArgs]) time.Time {
+	return time.Now().Add(backoff(job.Attempt))
+}
+
+// send POSTs the payload, signed with the webhook secret, and returns the response status
+func (w *DeliveryWorker) send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tokentide-webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for payload: the timestamp and the
+// hex HMAC-SHA256 of "<timestamp>.<payload>", e.g. "t=1700000000,v1=5257a8…".
+// Receivers recompute it with their secret and reject stale timestamps.
+func Sign(secret string, at time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff is the wait before the next attempt after the given number of failures
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff << (attempts - 1)
+	if wait <= 0 || wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
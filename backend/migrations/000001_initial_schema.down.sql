@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS incident_updates;
+DROP TABLE IF EXISTS incidents;
+DROP TABLE IF EXISTS short_link_clicks;
+DROP TABLE IF EXISTS short_links;
+DROP TABLE IF EXISTS idempotency_records;
+DROP TABLE IF EXISTS purchases;
+DROP TABLE IF EXISTS token_packages;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS gifts;
+DROP TABLE IF EXISTS artists;
@@ -0,0 +1,125 @@
+-- Baseline of the schema previously managed by GORM AutoMigrate; IF NOT EXISTS
+-- lets databases created that way adopt versioned migrations.
+
+CREATE TABLE IF NOT EXISTS artists (
+    id            text PRIMARY KEY,
+    name          text NOT NULL,
+    email         text NOT NULL,
+    password_hash text NOT NULL,
+    created_at    timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_email ON artists (email);
+
+CREATE TABLE IF NOT EXISTS gifts (
+    id        text PRIMARY KEY,
+    name      text NOT NULL,
+    price     decimal NOT NULL,
+    artist_id text
+);
+CREATE INDEX IF NOT EXISTS idx_gifts_artist_id ON gifts (artist_id);
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id         text PRIMARY KEY,
+    owner_id   text NOT NULL,
+    balance    bigint NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT chk_wallets_balance CHECK (balance >= 0)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_owner_id ON wallets (owner_id);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id                     text PRIMARY KEY,
+    wallet_id              text NOT NULL,
+    type                   text NOT NULL,
+    amount                 bigint NOT NULL,
+    balance_after          bigint NOT NULL,
+    counterparty_wallet_id text,
+    gift_id                text,
+    purchase_id            text,
+    created_at             timestamptz,
+    CONSTRAINT chk_transactions_amount CHECK (amount > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_created ON transactions (wallet_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_gift_id ON transactions (gift_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_purchase_id ON transactions (purchase_id);
+
+CREATE TABLE IF NOT EXISTS token_packages (
+    id           text PRIMARY KEY,
+    name         text NOT NULL,
+    tokens       bigint NOT NULL,
+    amount_minor bigint NOT NULL,
+    currency     text NOT NULL,
+    active       boolean NOT NULL DEFAULT true,
+    created_at   timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS purchases (
+    id           text PRIMARY KEY,
+    buyer_id     text NOT NULL,
+    package_id   text NOT NULL,
+    tokens       bigint NOT NULL,
+    amount_minor bigint NOT NULL,
+    currency     text NOT NULL,
+    provider     text NOT NULL,
+    provider_ref text,
+    status       text NOT NULL,
+    created_at   timestamptz,
+    updated_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_purchases_buyer_id ON purchases (buyer_id);
+CREATE INDEX IF NOT EXISTS idx_purchases_provider_ref ON purchases (provider_ref);
+
+CREATE TABLE IF NOT EXISTS idempotency_records (
+    key          text PRIMARY KEY,
+    request_hash text NOT NULL,
+    completed    boolean NOT NULL DEFAULT false,
+    status_code  bigint NOT NULL DEFAULT 0,
+    content_type text NOT NULL DEFAULT '',
+    body         bytea,
+    created_at   timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_records_created_at ON idempotency_records (created_at);
+
+CREATE TABLE IF NOT EXISTS short_links (
+    id           text PRIMARY KEY,
+    code         text NOT NULL,
+    target_url   text NOT NULL,
+    utm_source   text,
+    utm_medium   text,
+    utm_campaign text,
+    expires_at   timestamptz,
+    created_at   timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_short_links_code ON short_links (code);
+
+CREATE TABLE IF NOT EXISTS short_link_clicks (
+    id            bigserial PRIMARY KEY,
+    short_link_id text NOT NULL,
+    referrer      text,
+    country       text,
+    created_at    timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_short_link_clicks_short_link_id ON short_link_clicks (short_link_id);
+
+CREATE TABLE IF NOT EXISTS incidents (
+    id          text PRIMARY KEY,
+    component   text NOT NULL,
+    title       text NOT NULL,
+    impact      text NOT NULL,
+    status      text NOT NULL,
+    started_at  timestamptz,
+    resolved_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_incidents_component ON incidents (component);
+CREATE INDEX IF NOT EXISTS idx_incidents_status ON incidents (status);
+
+CREATE TABLE IF NOT EXISTS incident_updates (
+    id          bigserial PRIMARY KEY,
+    incident_id text NOT NULL,
+    status      text,
+    message     text,
+    created_at  timestamptz,
+    CONSTRAINT fk_incidents_updates FOREIGN KEY (incident_id) REFERENCES incidents (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_incident_updates_incident_id ON incident_updates (incident_id);
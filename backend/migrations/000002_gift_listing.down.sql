@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_gifts_price;
+DROP INDEX IF EXISTS idx_gifts_created_at;
+ALTER TABLE gifts DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now();
+CREATE INDEX IF NOT EXISTS idx_gifts_created_at ON gifts (created_at);
+CREATE INDEX IF NOT EXISTS idx_gifts_price ON gifts (price);
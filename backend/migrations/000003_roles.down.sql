@@ -0,0 +1 @@
+ALTER TABLE artists DROP COLUMN IF EXISTS role;
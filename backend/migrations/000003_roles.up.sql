@@ -0,0 +1 @@
+ALTER TABLE artists ADD COLUMN IF NOT EXISTS role text NOT NULL DEFAULT 'artist';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id           text PRIMARY KEY,
+    type         text NOT NULL,
+    aggregate_id text NOT NULL,
+    data         jsonb NOT NULL,
+    occurred_at  timestamptz NOT NULL,
+    published_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (occurred_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at);
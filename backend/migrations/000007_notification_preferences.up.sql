@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    artist_id        text PRIMARY KEY,
+    gift_received    boolean NOT NULL DEFAULT true,
+    purchase_receipt boolean NOT NULL DEFAULT true,
+    payout_sent      boolean NOT NULL DEFAULT true,
+    updated_at       timestamptz
+);
@@ -0,0 +1,2 @@
+ALTER TABLE gifts DROP COLUMN IF EXISTS image_key;
+ALTER TABLE gifts DROP COLUMN IF EXISTS image_url;
@@ -0,0 +1,2 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS image_url text NOT NULL DEFAULT '';
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS image_key text NOT NULL DEFAULT '';
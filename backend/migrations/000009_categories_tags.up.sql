@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id         text PRIMARY KEY,
+    name       text NOT NULL,
+    slug       text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id         text PRIMARY KEY,
+    name       text NOT NULL,
+    slug       text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug);
+
+CREATE TABLE IF NOT EXISTS gift_categories (
+    gift_id     text NOT NULL REFERENCES gifts (id) ON DELETE CASCADE,
+    category_id text NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (gift_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_gift_categories_category_id ON gift_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS gift_tags (
+    gift_id text NOT NULL REFERENCES gifts (id) ON DELETE CASCADE,
+    tag_id  text NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (gift_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS idx_gift_tags_tag_id ON gift_tags (tag_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_artists_search_vector;
+ALTER TABLE artists DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_gifts_search_vector;
+ALTER TABLE gifts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE gifts DROP COLUMN IF EXISTS description;
@@ -0,0 +1,13 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS description text NOT NULL DEFAULT '';
+
+-- Postgres keeps the generated search vectors in sync with every write; names
+-- outweigh descriptions in the ranking
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_gifts_search_vector ON gifts USING gin (search_vector);
+
+ALTER TABLE artists ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', name), 'A')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_artists_search_vector ON artists USING gin (search_vector);
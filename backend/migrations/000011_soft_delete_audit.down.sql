@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS audit_log;
+
+DROP INDEX IF EXISTS idx_artists_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_email ON artists (email);
+
+DROP INDEX IF EXISTS idx_artists_deleted_at;
+ALTER TABLE artists DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_gifts_deleted_at;
+ALTER TABLE gifts DROP COLUMN IF EXISTS deleted_at;
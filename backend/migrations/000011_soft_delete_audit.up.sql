@@ -0,0 +1,22 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_gifts_deleted_at ON gifts (deleted_at);
+
+ALTER TABLE artists ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_artists_deleted_at ON artists (deleted_at);
+
+-- A deleted account releases its email for a new signup
+DROP INDEX IF EXISTS idx_artists_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artists_email ON artists (email) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          text PRIMARY KEY,
+    actor_id    text NOT NULL DEFAULT '',
+    action      text NOT NULL,
+    entity_type text NOT NULL,
+    entity_id   text NOT NULL,
+    changes     jsonb NOT NULL,
+    created_at  timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
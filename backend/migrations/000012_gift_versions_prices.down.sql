@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS gift_price_history;
+
+ALTER TABLE gifts DROP COLUMN IF EXISTS version;
@@ -0,0 +1,15 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS gift_price_history (
+    id             text PRIMARY KEY,
+    gift_id        text NOT NULL REFERENCES gifts (id) ON DELETE CASCADE,
+    price          decimal NOT NULL,
+    effective_from timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_gift_price_history_gift ON gift_price_history (gift_id, effective_from);
+
+-- Existing gifts start their history at their current price
+INSERT INTO gift_price_history (id, gift_id, price, effective_from)
+SELECT gen_random_uuid()::text, id, price, created_at
+FROM gifts
+WHERE NOT EXISTS (SELECT 1 FROM gift_price_history h WHERE h.gift_id = gifts.id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_refund_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS refund_id;
+
+DROP TABLE IF EXISTS refunds;
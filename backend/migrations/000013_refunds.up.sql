@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id                     text PRIMARY KEY,
+    kind                   text NOT NULL,
+    purchase_id            text,
+    transaction_id         text,
+    gift_id                text,
+    wallet_id              text NOT NULL,
+    counterparty_wallet_id text,
+    tokens                 bigint NOT NULL,
+    amount_minor           bigint NOT NULL DEFAULT 0,
+    currency               text NOT NULL DEFAULT '',
+    reason                 text NOT NULL DEFAULT '',
+    requested_by           text NOT NULL DEFAULT '',
+    status                 text NOT NULL,
+    provider_ref           text NOT NULL DEFAULT '',
+    failure_reason         text NOT NULL DEFAULT '',
+    created_at             timestamptz NOT NULL,
+    updated_at             timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_refunds_purchase_id ON refunds (purchase_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_status ON refunds (status);
+CREATE INDEX IF NOT EXISTS idx_refunds_created_at ON refunds (created_at);
+-- A gift send is refunded at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refunds_transaction_id ON refunds (transaction_id) WHERE transaction_id <> '';
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS refund_id text;
+CREATE INDEX IF NOT EXISTS idx_transactions_refund_id ON transactions (refund_id);
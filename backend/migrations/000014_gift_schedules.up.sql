@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS gift_schedules (
+    id          text PRIMARY KEY,
+    sender_id   text NOT NULL,
+    gift_id     text NOT NULL,
+    recurrence  text NOT NULL,
+    status      text NOT NULL,
+    next_run_at timestamptz NOT NULL,
+    last_run_at timestamptz,
+    sent_count  integer NOT NULL DEFAULT 0,
+    last_error  text NOT NULL DEFAULT '',
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_gift_schedules_sender_id ON gift_schedules (sender_id);
+-- The workers look up active schedules by due time
+CREATE INDEX IF NOT EXISTS idx_gift_schedules_next_run_at ON gift_schedules (next_run_at) WHERE status = 'active';
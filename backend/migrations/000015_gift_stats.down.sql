@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_created_at;
+
+DROP TABLE IF EXISTS artist_gift_stats;
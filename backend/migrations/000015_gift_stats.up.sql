@@ -0,0 +1,15 @@
+-- Daily aggregates of the gifts each fan sent each artist, recomputed from
+-- the transactions ledger by a background job
+CREATE TABLE IF NOT EXISTS artist_gift_stats (
+    artist_id text NOT NULL,
+    sender_id text NOT NULL,
+    day       date NOT NULL,
+    gifts     bigint NOT NULL,
+    tokens    bigint NOT NULL,
+    PRIMARY KEY (artist_id, sender_id, day)
+);
+CREATE INDEX IF NOT EXISTS idx_artist_gift_stats_artist_day ON artist_gift_stats (artist_id, day);
+CREATE INDEX IF NOT EXISTS idx_artist_gift_stats_day ON artist_gift_stats (day);
+
+-- The refresh scans the ledger by time
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at);
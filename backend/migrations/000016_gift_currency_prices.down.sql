@@ -0,0 +1,15 @@
+-- Prices in other currencies cannot be turned back into tokens here, so they
+-- are restored as if they were USD
+ALTER TABLE gift_price_history ADD COLUMN IF NOT EXISTS price decimal;
+UPDATE gift_price_history SET price = price_minor / 100.0;
+ALTER TABLE gift_price_history ALTER COLUMN price SET NOT NULL;
+ALTER TABLE gift_price_history DROP COLUMN IF EXISTS price_minor;
+ALTER TABLE gift_price_history DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS price decimal;
+UPDATE gifts SET price = price_minor / 100.0;
+ALTER TABLE gifts ALTER COLUMN price SET NOT NULL;
+DROP INDEX IF EXISTS idx_gifts_currency_price;
+ALTER TABLE gifts DROP COLUMN IF EXISTS price_minor;
+ALTER TABLE gifts DROP COLUMN IF EXISTS currency;
+CREATE INDEX IF NOT EXISTS idx_gifts_price ON gifts (price);
@@ -0,0 +1,19 @@
+-- Prices move from decimal token amounts to integer minor units of an explicit
+-- currency. Existing prices were in tokens, which the default TOKEN_VALUE_MINOR
+-- of 100 values at one USD each, so they carry over as USD cents and cost the
+-- same number of tokens as before.
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS price_minor bigint;
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS currency text;
+UPDATE gifts SET price_minor = CEIL(price * 100), currency = 'USD' WHERE price_minor IS NULL;
+ALTER TABLE gifts ALTER COLUMN price_minor SET NOT NULL;
+ALTER TABLE gifts ALTER COLUMN currency SET NOT NULL;
+DROP INDEX IF EXISTS idx_gifts_price;
+ALTER TABLE gifts DROP COLUMN IF EXISTS price;
+CREATE INDEX IF NOT EXISTS idx_gifts_currency_price ON gifts (currency, price_minor);
+
+ALTER TABLE gift_price_history ADD COLUMN IF NOT EXISTS price_minor bigint;
+ALTER TABLE gift_price_history ADD COLUMN IF NOT EXISTS currency text;
+UPDATE gift_price_history SET price_minor = CEIL(price * 100), currency = 'USD' WHERE price_minor IS NULL;
+ALTER TABLE gift_price_history ALTER COLUMN price_minor SET NOT NULL;
+ALTER TABLE gift_price_history ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE gift_price_history DROP COLUMN IF EXISTS price;
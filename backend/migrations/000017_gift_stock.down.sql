@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_wallet_gift;
+ALTER TABLE gifts DROP COLUMN IF EXISTS per_fan_limit;
+ALTER TABLE gifts DROP COLUMN IF EXISTS sold;
+ALTER TABLE gifts DROP COLUMN IF EXISTS quantity;
@@ -0,0 +1,18 @@
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS quantity bigint CHECK (quantity > 0);
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS sold bigint NOT NULL DEFAULT 0;
+ALTER TABLE gifts ADD COLUMN IF NOT EXISTS per_fan_limit bigint CHECK (per_fan_limit > 0);
+
+-- Existing gifts start with the sends already in the ledger: a send debits
+-- the sender, and its refund credits them back
+UPDATE gifts SET sold = counts.sold
+FROM (
+    SELECT gift_id, SUM(CASE WHEN type = 'debit' THEN 1 ELSE -1 END) AS sold
+    FROM transactions
+    WHERE COALESCE(gift_id, '') <> ''
+        AND ((type = 'debit' AND COALESCE(refund_id, '') = '') OR (type = 'credit' AND COALESCE(refund_id, '') <> ''))
+    GROUP BY gift_id
+) counts
+WHERE counts.gift_id = gifts.id;
+
+-- Per-fan limits count a fan's sends of a gift
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_gift ON transactions (wallet_id, gift_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_payout_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS payout_id;
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS payout_accounts;
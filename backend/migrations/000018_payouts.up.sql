@@ -0,0 +1,32 @@
+-- The accounts at the payout provider artists are paid to
+CREATE TABLE IF NOT EXISTS payout_accounts (
+    artist_id   text PRIMARY KEY,
+    provider    text NOT NULL,
+    external_id text NOT NULL,
+    ready       boolean NOT NULL DEFAULT false,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id             text PRIMARY KEY,
+    artist_id      text NOT NULL,
+    wallet_id      text NOT NULL,
+    tokens         bigint NOT NULL CHECK (tokens > 0),
+    gross_minor    bigint NOT NULL,
+    fee_minor      bigint NOT NULL,
+    amount_minor   bigint NOT NULL,
+    currency       text NOT NULL,
+    status         text NOT NULL,
+    reviewed_by    text NOT NULL DEFAULT '',
+    provider_ref   text NOT NULL DEFAULT '',
+    failure_reason text NOT NULL DEFAULT '',
+    created_at     timestamptz NOT NULL,
+    updated_at     timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_payouts_artist_id ON payouts (artist_id);
+CREATE INDEX IF NOT EXISTS idx_payouts_status ON payouts (status);
+CREATE INDEX IF NOT EXISTS idx_payouts_created_at ON payouts (created_at);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payout_id text;
+CREATE INDEX IF NOT EXISTS idx_transactions_payout_id ON transactions (payout_id);
@@ -0,0 +1,15 @@
+-- API keys of server integrations; only a SHA-256 hash of each key is kept
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           text PRIMARY KEY,
+    artist_id    text NOT NULL,
+    name         text NOT NULL,
+    prefix       text NOT NULL,
+    key_hash     text NOT NULL,
+    scopes       jsonb NOT NULL,
+    expires_at   timestamptz,
+    last_used_at timestamptz,
+    revoked_at   timestamptz,
+    created_at   timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS idx_api_keys_artist_id ON api_keys (artist_id);
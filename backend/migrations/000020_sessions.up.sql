@@ -0,0 +1,16 @@
+-- Signed-in sessions; only SHA-256 hashes of their refresh tokens are kept
+CREATE TABLE IF NOT EXISTS sessions (
+    id                  text PRIMARY KEY,
+    artist_id           text NOT NULL,
+    token_hash          text NOT NULL,
+    previous_token_hash text NOT NULL DEFAULT '',
+    user_agent          text NOT NULL DEFAULT '',
+    ip                  text NOT NULL DEFAULT '',
+    created_at          timestamptz NOT NULL,
+    last_used_at        timestamptz NOT NULL,
+    expires_at          timestamptz NOT NULL,
+    revoked_at          timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_token_hash ON sessions (token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token_hash ON sessions (previous_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_artist_id ON sessions (artist_id);
@@ -0,0 +1,4 @@
+-- The account that created each short link; links created before owners were
+-- recorded have none and stay manageable by admins only
+ALTER TABLE short_links ADD COLUMN IF NOT EXISTS artist_id text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_short_links_artist_id ON short_links (artist_id);
@@ -0,0 +1,106 @@
+// Package migrations holds the versioned SQL schema, embedded into the binary.
+// Files are named NNNNNN_description.up.sql / .down.sql and are applied in order.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Migration is a single schema version and whether it has been applied
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Status is the schema version of a database and the migrations it knows about
+type Status struct {
+	Version uint
+	// Dirty is set when a migration failed halfway and needs manual repair
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Up applies every pending migration
+func Up(db *sql.DB) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the most recently applied migration
+func Down(db *sql.DB) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	return m.Steps(-1)
+}
+
+// GetStatus reports the current schema version and which migrations are applied
+func GetStatus(db *sql.DB) (*Status, error) {
+	m, err := newMigrate(db)
+	if err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+
+	status := &Status{Version: version, Dirty: dirty}
+	entries, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(entries)
+	for _, entry := range entries {
+		number, name, _ := strings.Cut(strings.TrimSuffix(entry, ".up.sql"), "_")
+		v, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version", entry)
+		}
+		status.Migrations = append(status.Migrations, Migration{
+			Version: uint(v),
+			Name:    name,
+			Applied: uint(v) < version || (uint(v) == version && !dirty),
+		})
+	}
+	return status, nil
+}
+
+func newMigrate(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewWithInstance("iofs", source, "pgx5", driver)
+}
@@ -1,43 +1,675 @@
 package config
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"tokentide/pkg/dbresolver"
+	"tokentide/pkg/logging"
+	"tokentide/pkg/money"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// LoadConfig loads environment variables from the .env file
-func LoadConfig() {
-	err := godotenv.Load()
+// Config is the validated application configuration, loaded once at startup
+type Config struct {
+	Port      string
+	ReusePort bool
+	// GRPCPort is the port the gRPC API listens on; it is not served when empty
+	GRPCPort string
+	LogLevel string
+	Database DatabaseConfig
+	Cache    CacheConfig
+	JWT      JWTConfig
+	Stripe   StripeConfig
+	// AdminAllowedCIDRs are the networks allowed to reach the /admin and /debug routes
+	AdminAllowedCIDRs []string
+	CORS              CORSConfig
+	// BodyLimit bounds request bodies in bytes, and UploadBodyLimit gift image
+	// uploads, which may be larger
+	BodyLimit       int
+	UploadBodyLimit int
+	// ChaosEnabled switches on the fault injection layer; it is off unless opted in
+	ChaosEnabled bool
+	Shadow       ShadowConfig
+	Tracing      TracingConfig
+	RateLimit    RateLimitConfig
+	Outbox       OutboxConfig
+	Jobs         JobsConfig
+	Email        EmailConfig
+	Storage      StorageConfig
+	Currency     CurrencyConfig
+	Payout       PayoutConfig
+	// ShortLinkHosts are the hosts short links may redirect to, so that
+	// artists cannot turn the platform's domain into an open redirect
+	ShortLinkHosts []string
+	// WebhookAllowPrivateNetworks lets webhooks target internal addresses, for local development
+	WebhookAllowPrivateNetworks bool
+}
+
+// DatabaseConfig holds the PostgreSQL connection settings
+type DatabaseConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	// The pool settings apply to the primary and to each replica
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// StatementTimeout cancels queries running longer; zero for no limit
+	StatementTimeout time.Duration
+	// ReplicaDSNs are the read replicas listing and search queries are spread over
+	ReplicaDSNs []string
+}
+
+// DSN is the connection string of the database
+func (d DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		d.Host, d.Port, d.User, d.Password, d.Name)
+}
+
+// CacheConfig holds the Redis cache settings; caching is disabled when RedisURL is empty
+type CacheConfig struct {
+	RedisURL  string
+	GiftTTL   time.Duration
+	ArtistTTL time.Duration
+}
+
+// Enabled reports whether hot reads are cached in Redis
+func (c CacheConfig) Enabled() bool {
+	return c.RedisURL != ""
+}
+
+// JWTConfig holds the access and refresh token settings
+type JWTConfig struct {
+	Secret string
+	TTL    time.Duration
+	// RefreshTTL is how long a session stays signed in without being refreshed
+	RefreshTTL time.Duration
+}
+
+// CORSConfig holds the settings of the browser clients served from other
+// origins; cross-origin calls are refused when AllowedOrigins is empty
+type CORSConfig struct {
+	// AllowedOrigins are origins such as https://app.example.com, or "*" for any
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowCredentials lets browsers send cookies with cross-origin calls
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache the answer to a preflight
+	MaxAge time.Duration
+}
+
+// Enabled reports whether any cross-origin calls are allowed
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+// StripeConfig holds the Stripe settings; token purchases are disabled when SecretKey is empty
+type StripeConfig struct {
+	SecretKey     string
+	WebhookSecret string
+	SuccessURL    string
+	CancelURL     string
+}
+
+// Enabled reports whether token purchases through Stripe are configured
+func (s StripeConfig) Enabled() bool {
+	return s.SecretKey != ""
+}
+
+// ShadowConfig holds the read traffic mirroring settings
+type ShadowConfig struct {
+	URL string
+	// Percent is the percentage (0-100) of read traffic mirrored to URL
+	Percent float64
+}
+
+// Enabled reports whether read traffic is mirrored
+func (s ShadowConfig) Enabled() bool {
+	return s.URL != "" && s.Percent > 0
+}
+
+// TracingConfig holds the OpenTelemetry settings; tracing is disabled when Endpoint is empty
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+	// SampleRatio is the fraction (0-1) of new traces that are sampled
+	SampleRatio float64
+}
+
+// Enabled reports whether spans are exported
+func (t TracingConfig) Enabled() bool {
+	return t.Endpoint != ""
+}
+
+// RateLimitConfig holds the per-route rate limits; limits are shared through
+// Redis when caching is configured and kept per instance otherwise
+type RateLimitConfig struct {
+	Enabled bool
+	// Auth limits login and signup attempts per client IP
+	Auth Rate
+	// Gifts limits gift sends and token purchases per account
+	Gifts Rate
+}
+
+// Rate allows a burst of Requests that refills evenly over Per
+type Rate struct {
+	Requests int
+	Per      time.Duration
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%s", r.Requests, r.Per)
+}
+
+// Brokers domain events can be relayed to
+const (
+	BrokerNATS  = "nats"
+	BrokerKafka = "kafka"
+)
+
+// OutboxConfig holds the domain event relay settings
+type OutboxConfig struct {
+	// Broker is BrokerNATS, BrokerKafka or empty to only log events
+	Broker        string
+	BrokerURL     string
+	SubjectPrefix string
+}
+
+// JobsConfig holds the background job queue settings
+type JobsConfig struct {
+	// Concurrency is how many jobs each worker runs at once
+	Concurrency int
+	// InProcess makes the API work jobs as well as enqueue them
+	InProcess bool
+}
+
+// Providers notification emails can be sent through
+const (
+	EmailSMTP     = "smtp"
+	EmailSendGrid = "sendgrid"
+)
+
+// EmailConfig holds the notification email settings
+type EmailConfig struct {
+	// Provider is EmailSMTP, EmailSendGrid or empty to only log emails
+	Provider       string
+	From           string
+	SMTPHost       string
+	SMTPPort       string
+	SMTPUsername   string
+	SMTPPassword   string
+	SendGridAPIKey string
+}
+
+// Backends uploaded files can be kept in
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// LocalMediaPath is where the API serves locally stored files
+const LocalMediaPath = "/media"
+
+// StorageConfig holds the uploaded file storage settings
+type StorageConfig struct {
+	// Backend is StorageLocal or StorageS3
+	Backend  string
+	LocalDir string
+	// PublicURL prefixes the URL of every stored file
+	PublicURL  string
+	S3Bucket   string
+	S3Region   string
+	S3Endpoint string
+}
+
+// Services exchange rates can be fetched from
+const (
+	ExchangeFixer             = "fixer"
+	ExchangeOpenExchangeRates = "openexchangerates"
+)
+
+// CurrencyConfig holds the token value and exchange rate settings
+type CurrencyConfig struct {
+	// TokenCurrency and TokenValueMinor value one token, e.g. 100 USD for $1.00
+	TokenCurrency   string
+	TokenValueMinor int64
+	// RatesProvider is ExchangeFixer, ExchangeOpenExchangeRates or empty to
+	// only accept amounts that need no conversion
+	RatesProvider string
+	RatesAPIKey   string
+	RatesTTL      time.Duration
+}
+
+// PayoutConfig holds the artist payout settings; payouts go through Stripe
+// Connect and are disabled unless Stripe is configured
+type PayoutConfig struct {
+	// FeeRate is the decimal fraction (0-1) of every payout the platform keeps
+	FeeRate string
+	// ReturnURL and RefreshURL are where artists land after onboarding, and
+	// when their onboarding link expired
+	ReturnURL  string
+	RefreshURL string
+}
+
+// LoadConfig loads command-line flags, the .env file and the optional YAML
+// config file, validates the result and returns it along with the positional
+// arguments left after the flags
+func LoadConfig(args []string) (*Config, []string, error) {
+	configFile, rest, err := parseFlags(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if configFile != "" {
+		if err := loadFile(configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = godotenv.Load()
 	if err != nil {
-		log.Printf("Error loading .env file")
+		slog.Info("No .env file loaded", "error", err)
 	}
+
+	cfg, err := build()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, rest, nil
 }
 
-// GetEnv returns the value of an environment variable
-func GetEnv(key string) string {
-	return os.Getenv(key)
+// build resolves every setting into a Config, collecting all validation errors
+func build() (*Config, error) {
+	var errs []error
+	required := func(key string) string {
+		value := getEnv(key)
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+		return value
+	}
+	optionalPort := func(key string) string {
+		value := getEnv(key)
+		if n, err := strconv.Atoi(value); value != "" && (err != nil || n < 1 || n > 65535) {
+			errs = append(errs, fmt.Errorf("%s must be a port number, got %q", key, value))
+		}
+		return value
+	}
+	port := func(key string) string {
+		required(key)
+		return optionalPort(key)
+	}
+	duration := func(key string) time.Duration {
+		value, err := time.ParseDuration(getEnv(key))
+		if err != nil || value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration such as 5m, got %q", key, getEnv(key)))
+		}
+		return value
+	}
+	rate := func(key string) Rate {
+		requests, per, _ := strings.Cut(getEnv(key), "/")
+		n, err := strconv.Atoi(requests)
+		d, perErr := time.ParseDuration(per)
+		if err != nil || perErr != nil || n <= 0 || d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a rate such as 10/1m, got %q", key, getEnv(key)))
+		}
+		return Rate{Requests: n, Per: d}
+	}
+	size := func(key string) int {
+		value, unit := strings.ToUpper(strings.TrimSpace(getEnv(key))), 1
+		if n, ok := strings.CutSuffix(value, "KB"); ok {
+			value, unit = n, 1<<10
+		} else if n, ok := strings.CutSuffix(value, "MB"); ok {
+			value, unit = n, 1<<20
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive size such as 512KB or 1MB, got %q", key, getEnv(key)))
+		}
+		return n * unit
+	}
+	boolean := func(key string) bool {
+		value, err := strconv.ParseBool(getEnv(key))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s must be true or false, got %q", key, getEnv(key)))
+		}
+		return value
+	}
+
+	cfg := &Config{
+		Port:      port("PORT"),
+		ReusePort: boolean("REUSE_PORT"),
+		GRPCPort:  optionalPort("GRPC_PORT"),
+		LogLevel:  strings.ToLower(getEnv("LOG_LEVEL")),
+		Database: DatabaseConfig{
+			Host:            required("DB_HOST"),
+			Port:            port("DB_PORT"),
+			User:            required("DB_USER"),
+			Password:        getEnv("DB_PASSWORD"),
+			Name:            required("DB_NAME"),
+			ConnMaxLifetime: duration("DB_CONN_MAX_LIFETIME"),
+			ConnMaxIdleTime: duration("DB_CONN_MAX_IDLE_TIME"),
+			ReplicaDSNs:     getEnvList("DB_REPLICA_DSNS"),
+		},
+		Cache: CacheConfig{
+			RedisURL:  getEnv("REDIS_URL"),
+			GiftTTL:   duration("CACHE_GIFT_TTL"),
+			ArtistTTL: duration("CACHE_ARTIST_TTL"),
+		},
+		JWT: JWTConfig{
+			Secret:     required("JWT_SECRET"),
+			TTL:        duration("JWT_TTL"),
+			RefreshTTL: duration("REFRESH_TOKEN_TTL"),
+		},
+		Stripe: StripeConfig{
+			SecretKey:     getEnv("STRIPE_SECRET_KEY"),
+			WebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET"),
+			SuccessURL:    getEnv("CHECKOUT_SUCCESS_URL"),
+			CancelURL:     getEnv("CHECKOUT_CANCEL_URL"),
+		},
+		AdminAllowedCIDRs: getEnvList("ADMIN_ALLOWED_CIDRS"),
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS"),
+			AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS"),
+			AllowCredentials: boolean("CORS_ALLOW_CREDENTIALS"),
+			MaxAge:           duration("CORS_MAX_AGE"),
+		},
+		BodyLimit:       size("BODY_LIMIT"),
+		UploadBodyLimit: size("UPLOAD_BODY_LIMIT"),
+		ChaosEnabled:    boolean("CHAOS_ENABLED"),
+		Shadow: ShadowConfig{
+			URL: getEnv("SHADOW_URL"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: boolean("RATE_LIMIT_ENABLED"),
+			Auth:    rate("RATE_LIMIT_AUTH"),
+			Gifts:   rate("RATE_LIMIT_GIFTS"),
+		},
+		ShortLinkHosts:              getEnvList("SHORT_LINK_HOSTS"),
+		WebhookAllowPrivateNetworks: boolean("WEBHOOK_ALLOW_PRIVATE_NETWORKS"),
+		Outbox: OutboxConfig{
+			Broker:        strings.ToLower(getEnv("OUTBOX_BROKER")),
+			BrokerURL:     getEnv("OUTBOX_BROKER_URL"),
+			SubjectPrefix: getEnv("OUTBOX_SUBJECT_PREFIX"),
+		},
+		Email: EmailConfig{
+			Provider:       strings.ToLower(getEnv("EMAIL_PROVIDER")),
+			From:           required("EMAIL_FROM"),
+			SMTPHost:       getEnv("SMTP_HOST"),
+			SMTPPort:       port("SMTP_PORT"),
+			SMTPUsername:   getEnv("SMTP_USERNAME"),
+			SMTPPassword:   getEnv("SMTP_PASSWORD"),
+			SendGridAPIKey: getEnv("SENDGRID_API_KEY"),
+		},
+		Storage: StorageConfig{
+			Backend:    strings.ToLower(getEnv("STORAGE_BACKEND")),
+			LocalDir:   getEnv("STORAGE_LOCAL_DIR"),
+			PublicURL:  getEnv("STORAGE_PUBLIC_URL"),
+			S3Bucket:   getEnv("S3_BUCKET"),
+			S3Region:   getEnv("S3_REGION"),
+			S3Endpoint: getEnv("S3_ENDPOINT"),
+		},
+		Jobs: JobsConfig{
+			InProcess: boolean("JOBS_IN_PROCESS"),
+		},
+		Currency: CurrencyConfig{
+			TokenCurrency: strings.ToUpper(required("TOKEN_CURRENCY")),
+			RatesProvider: strings.ToLower(getEnv("EXCHANGE_RATES_PROVIDER")),
+			RatesAPIKey:   getEnv("EXCHANGE_RATES_API_KEY"),
+			RatesTTL:      duration("EXCHANGE_RATES_TTL"),
+		},
+		Payout: PayoutConfig{
+			FeeRate:    getEnv("PAYOUT_FEE_RATE"),
+			ReturnURL:  getEnv("PAYOUT_ONBOARDING_RETURN_URL"),
+			RefreshURL: getEnv("PAYOUT_ONBOARDING_REFRESH_URL"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			ServiceName: getEnv("OTEL_SERVICE_NAME"),
+		},
+	}
+
+	switch cfg.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be one of debug, info, warn or error, got %q", cfg.LogLevel))
+	}
+
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		if origin == "*" {
+			if cfg.CORS.AllowCredentials || len(cfg.CORS.AllowedOrigins) > 1 {
+				errs = append(errs, errors.New("CORS_ALLOWED_ORIGINS can only be * on its own and without CORS_ALLOW_CREDENTIALS"))
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+			errs = append(errs, fmt.Errorf("CORS_ALLOWED_ORIGINS must list origins such as https://app.example.com, got %q", origin))
+		}
+	}
+
+	for _, host := range cfg.ShortLinkHosts {
+		if strings.ContainsAny(host, "/:@") {
+			errs = append(errs, fmt.Errorf("SHORT_LINK_HOSTS must list host names such as tokentide.app, got %q", host))
+		}
+	}
+
+	if cfg.UploadBodyLimit < cfg.BodyLimit {
+		errs = append(errs, errors.New("UPLOAD_BODY_LIMIT must be at least BODY_LIMIT"))
+	}
+
+	switch cfg.Outbox.Broker {
+	case "":
+	case BrokerNATS, BrokerKafka:
+		if cfg.Outbox.BrokerURL == "" {
+			errs = append(errs, fmt.Errorf("OUTBOX_BROKER_URL is required when OUTBOX_BROKER is %s", cfg.Outbox.Broker))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("OUTBOX_BROKER must be nats, kafka or empty, got %q", cfg.Outbox.Broker))
+	}
+
+	switch cfg.Email.Provider {
+	case "":
+	case EmailSMTP:
+		if cfg.Email.SMTPHost == "" {
+			errs = append(errs, errors.New("SMTP_HOST is required when EMAIL_PROVIDER is smtp"))
+		}
+	case EmailSendGrid:
+		if cfg.Email.SendGridAPIKey == "" {
+			errs = append(errs, errors.New("SENDGRID_API_KEY is required when EMAIL_PROVIDER is sendgrid"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("EMAIL_PROVIDER must be smtp, sendgrid or empty, got %q", cfg.Email.Provider))
+	}
+
+	switch cfg.Storage.Backend {
+	case StorageLocal:
+		if cfg.Storage.LocalDir == "" {
+			errs = append(errs, errors.New("STORAGE_LOCAL_DIR is required when STORAGE_BACKEND is local"))
+		}
+		if cfg.Storage.PublicURL == "" {
+			cfg.Storage.PublicURL = LocalMediaPath
+		}
+	case StorageS3:
+		if cfg.Storage.S3Bucket == "" || cfg.Storage.PublicURL == "" {
+			errs = append(errs, errors.New("S3_BUCKET and STORAGE_PUBLIC_URL are required when STORAGE_BACKEND is s3"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE_BACKEND must be local or s3, got %q", cfg.Storage.Backend))
+	}
+
+	switch cfg.Currency.RatesProvider {
+	case "":
+	case ExchangeFixer, ExchangeOpenExchangeRates:
+		if cfg.Currency.RatesAPIKey == "" {
+			errs = append(errs, fmt.Errorf("EXCHANGE_RATES_API_KEY is required when EXCHANGE_RATES_PROVIDER is %s", cfg.Currency.RatesProvider))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("EXCHANGE_RATES_PROVIDER must be fixer, openexchangerates or empty, got %q", cfg.Currency.RatesProvider))
+	}
+
+	if _, err := money.MinorUnits(cfg.Currency.TokenCurrency); cfg.Currency.TokenCurrency != "" && err != nil {
+		errs = append(errs, fmt.Errorf("TOKEN_CURRENCY must be an ISO 4217 currency code, got %q", cfg.Currency.TokenCurrency))
+	}
+
+	tokenValue, err := strconv.ParseInt(getEnv("TOKEN_VALUE_MINOR"), 10, 64)
+	if err != nil || tokenValue < 1 {
+		errs = append(errs, fmt.Errorf("TOKEN_VALUE_MINOR must be a positive number, got %q", getEnv("TOKEN_VALUE_MINOR")))
+	}
+	cfg.Currency.TokenValueMinor = tokenValue
+
+	// Parsed exactly, the way payouts apply it
+	feeRate, ok := new(big.Rat).SetString(cfg.Payout.FeeRate)
+	if !ok || feeRate.Sign() < 0 || feeRate.Cmp(big.NewRat(1, 1)) > 0 {
+		errs = append(errs, fmt.Errorf("PAYOUT_FEE_RATE must be a number between 0 and 1, got %q", cfg.Payout.FeeRate))
+	}
+
+	if cfg.Stripe.Enabled() && cfg.Stripe.WebhookSecret == "" {
+		errs = append(errs, errors.New("STRIPE_WEBHOOK_SECRET is required when STRIPE_SECRET_KEY is set"))
+	}
+
+	percent, err := strconv.ParseFloat(getEnv("SHADOW_PERCENT"), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		errs = append(errs, fmt.Errorf("SHADOW_PERCENT must be a number between 0 and 100, got %q", getEnv("SHADOW_PERCENT")))
+	}
+	cfg.Shadow.Percent = percent
+
+	maxOpen, err := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS"))
+	if err != nil || maxOpen < 1 {
+		errs = append(errs, fmt.Errorf("DB_MAX_OPEN_CONNS must be a positive number, got %q", getEnv("DB_MAX_OPEN_CONNS")))
+	}
+	cfg.Database.MaxOpenConns = maxOpen
+
+	maxIdle, err := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS"))
+	if err != nil || maxIdle < 0 || maxIdle > maxOpen {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS must be a number between 0 and DB_MAX_OPEN_CONNS, got %q", getEnv("DB_MAX_IDLE_CONNS")))
+	}
+	cfg.Database.MaxIdleConns = maxIdle
+
+	timeout, err := time.ParseDuration(getEnv("DB_STATEMENT_TIMEOUT"))
+	if err != nil || timeout < 0 {
+		errs = append(errs, fmt.Errorf("DB_STATEMENT_TIMEOUT must be a duration such as 30s, or 0, got %q", getEnv("DB_STATEMENT_TIMEOUT")))
+	}
+	cfg.Database.StatementTimeout = timeout
+
+	concurrency, err := strconv.Atoi(getEnv("JOBS_CONCURRENCY"))
+	if err != nil || concurrency < 1 {
+		errs = append(errs, fmt.Errorf("JOBS_CONCURRENCY must be a positive number, got %q", getEnv("JOBS_CONCURRENCY")))
+	}
+	cfg.Jobs.Concurrency = concurrency
+
+	ratio, err := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO"), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO must be a number between 0 and 1, got %q", getEnv("TRACING_SAMPLE_RATIO")))
+	}
+	cfg.Tracing.SampleRatio = ratio
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// getEnv returns the effective value of a configuration key
+func getEnv(key string) string {
+	value, _ := lookup(key)
+	return value
 }
 
-// SetupDatabase connects to PostgreSQL
-func SetupDatabase() (*gorm.DB, error) {
-	dbHost := GetEnv("DB_HOST")
-	dbPort := GetEnv("DB_PORT")
-	dbUser := GetEnv("DB_USER")
-	dbPassword := GetEnv("DB_PASSWORD")
-	dbName := GetEnv("DB_NAME")
+// getEnvList returns a comma-separated configuration value as a list
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(getEnv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPassword, dbName)
+// SetupDatabase connects to PostgreSQL, routing the queries repositories
+// mark as reads to the replicas when any are configured. CloseDatabase
+// closes the connections once the database is no longer used.
+func SetupDatabase(cfg DatabaseConfig) (_ *gorm.DB, err error) {
+	primary, err := openPool(cfg, cfg.DSN())
+	if err != nil {
+		return nil, err
+	}
+	replicas := make([]gorm.ConnPool, 0, len(cfg.ReplicaDSNs))
+	defer func() {
+		if err != nil {
+			primary.Close()
+			dbresolver.New(replicas...).Close()
+		}
+	}()
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: primary}), &gorm.Config{
+		TranslateError: true,
+		Logger:         logging.NewGormLogger(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	for i, dsn := range cfg.ReplicaDSNs {
+		replica, err := openPool(cfg, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("replica %d: %w", i+1, err)
+		}
+		replicas = append(replicas, replica)
+		if err := replica.Ping(); err != nil {
+			return nil, fmt.Errorf("replica %d: %w", i+1, err)
+		}
+	}
+	if err := db.Use(dbresolver.New(replicas...)); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// CloseDatabase closes the connection pools of the primary and the replicas
+// of a database set up by SetupDatabase
+func CloseDatabase(db *gorm.DB) error {
+	primary, err := db.DB()
+	if err != nil {
+		return err
+	}
+	errs := []error{primary.Close()}
+	if resolver, ok := db.Config.Plugins[(&dbresolver.Resolver{}).Name()].(*dbresolver.Resolver); ok {
+		errs = append(errs, resolver.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func openPool(cfg DatabaseConfig, dsn string) (*sql.DB, error) {
+	conn, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.StatementTimeout > 0 {
+		conn.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	pool := stdlib.OpenDB(*conn)
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	pool.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return pool, nil
+}
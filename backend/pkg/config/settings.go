@@ -0,0 +1,208 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration values are resolved with the following precedence, highest first:
+//
+//	command-line flags > environment (including .env) > YAML config file > defaults
+type setting struct {
+	key          string
+	defaultValue string
+	usage        string
+	secret       bool
+}
+
+var settings = []setting{
+	{key: "PORT", defaultValue: "3000", usage: "port the API listens on"},
+	{key: "GRPC_PORT", usage: "port the gRPC API listens on, e.g. 9090; gRPC is not served when empty"},
+	{key: "LOG_LEVEL", defaultValue: "info", usage: "minimum log level: debug, info, warn or error"},
+	{key: "DB_HOST", defaultValue: "localhost", usage: "PostgreSQL host"},
+	{key: "DB_PORT", defaultValue: "5432", usage: "PostgreSQL port"},
+	{key: "DB_USER", defaultValue: "postgres", usage: "PostgreSQL user"},
+	{key: "DB_PASSWORD", usage: "PostgreSQL password", secret: true},
+	{key: "DB_NAME", defaultValue: "tokentide", usage: "PostgreSQL database name"},
+	{key: "DB_MAX_OPEN_CONNS", defaultValue: "25", usage: "most connections each database pool keeps open"},
+	{key: "DB_MAX_IDLE_CONNS", defaultValue: "10", usage: "most idle connections each database pool keeps for reuse"},
+	{key: "DB_CONN_MAX_LIFETIME", defaultValue: "30m", usage: "how long a database connection is reused before it is replaced"},
+	{key: "DB_CONN_MAX_IDLE_TIME", defaultValue: "5m", usage: "how long a database connection may sit idle before it is closed"},
+	{key: "DB_STATEMENT_TIMEOUT", defaultValue: "30s", usage: "how long a query may run before PostgreSQL cancels it; 0 for no limit"},
+	{key: "DB_REPLICA_DSNS", usage: "comma-separated connection strings of read replicas listing and search queries are spread over; every query goes to the primary when empty", secret: true},
+	{key: "REDIS_URL", usage: "Redis URL for caching hot reads, e.g. redis://localhost:6379/0; caching is disabled when empty", secret: true},
+	{key: "CACHE_GIFT_TTL", defaultValue: "5m", usage: "how long gifts stay cached"},
+	{key: "CACHE_ARTIST_TTL", defaultValue: "10m", usage: "how long artist profiles stay cached"},
+	{key: "JWT_SECRET", usage: "secret used to sign access tokens", secret: true},
+	{key: "JWT_TTL", defaultValue: "15m", usage: "lifetime of access tokens"},
+	{key: "REFRESH_TOKEN_TTL", defaultValue: "720h", usage: "how long a session stays signed in without being refreshed"},
+	{key: "STRIPE_SECRET_KEY", usage: "Stripe API secret key; token purchases are disabled when empty", secret: true},
+	{key: "STRIPE_WEBHOOK_SECRET", usage: "Stripe webhook signing secret", secret: true},
+	{key: "CHECKOUT_SUCCESS_URL", defaultValue: "http://localhost:3001/purchases/success", usage: "where buyers land after paying"},
+	{key: "CHECKOUT_CANCEL_URL", defaultValue: "http://localhost:3001/purchases/cancel", usage: "where buyers land after abandoning checkout"},
+	{key: "ADMIN_ALLOWED_CIDRS", defaultValue: "127.0.0.1/32,::1/128", usage: "comma-separated networks allowed to reach the admin routes and /debug"},
+	{key: "CORS_ALLOWED_ORIGINS", defaultValue: "http://localhost:3001", usage: "comma-separated origins browsers may call the API from, or * for any; cross-origin calls are refused when empty"},
+	{key: "CORS_ALLOWED_METHODS", defaultValue: "GET,POST,PUT,PATCH,DELETE", usage: "comma-separated methods allowed in cross-origin calls"},
+	{key: "CORS_ALLOW_CREDENTIALS", defaultValue: "false", usage: "let browsers send cookies with cross-origin calls; needs explicit CORS_ALLOWED_ORIGINS"},
+	{key: "CORS_MAX_AGE", defaultValue: "10m", usage: "how long browsers may cache the answer to a cross-origin preflight"},
+	{key: "BODY_LIMIT", defaultValue: "1MB", usage: "largest request body accepted, e.g. 512KB or 1MB; gift image uploads are bounded by UPLOAD_BODY_LIMIT instead"},
+	{key: "UPLOAD_BODY_LIMIT", defaultValue: "5MB", usage: "largest gift image upload accepted"},
+	{key: "CHAOS_ENABLED", defaultValue: "false", usage: "enable the fault injection layer"},
+	{key: "SHADOW_URL", usage: "secondary deployment read traffic is mirrored to"},
+	{key: "SHADOW_PERCENT", defaultValue: "0", usage: "percentage of read traffic mirrored to SHADOW_URL"},
+	{key: "OTEL_EXPORTER_OTLP_ENDPOINT", usage: "OTLP/HTTP collector URL traces are exported to, e.g. http://localhost:4318; tracing is disabled when empty"},
+	{key: "OTEL_SERVICE_NAME", defaultValue: "tokentide", usage: "service name reported on exported traces"},
+	{key: "TRACING_SAMPLE_RATIO", defaultValue: "1", usage: "fraction (0-1) of new traces that are sampled"},
+	{key: "RATE_LIMIT_ENABLED", defaultValue: "true", usage: "rate limit authentication and gifting routes"},
+	{key: "RATE_LIMIT_AUTH", defaultValue: "10/1m", usage: "login and signup attempts allowed per client IP, as requests/period"},
+	{key: "RATE_LIMIT_GIFTS", defaultValue: "30/1m", usage: "gift sends and token purchases allowed per account, as requests/period"},
+	{key: "OUTBOX_BROKER", usage: "broker domain events are relayed to, nats or kafka; events are only logged when empty"},
+	{key: "OUTBOX_BROKER_URL", usage: "NATS server URL, or comma-separated Kafka brokers"},
+	{key: "OUTBOX_SUBJECT_PREFIX", defaultValue: "tokentide", usage: "prefix of the NATS subjects or Kafka topics events are published to"},
+	{key: "SHORT_LINK_HOSTS", defaultValue: "localhost", usage: "comma-separated hosts short links may redirect to, e.g. tokentide.app,www.tokentide.app; other targets are refused"},
+	{key: "WEBHOOK_ALLOW_PRIVATE_NETWORKS", defaultValue: "false", usage: "allow webhook deliveries to loopback and private addresses, for local development"},
+	{key: "EMAIL_PROVIDER", usage: "provider notification emails are sent through, smtp or sendgrid; emails are only logged when empty"},
+	{key: "EMAIL_FROM", defaultValue: "Tokentide <no-reply@tokentide.local>", usage: "sender of notification emails"},
+	{key: "SMTP_HOST", usage: "SMTP server host, e.g. email-smtp.eu-west-1.amazonaws.com for SES"},
+	{key: "SMTP_PORT", defaultValue: "587", usage: "SMTP server port; STARTTLS is used when the server offers it"},
+	{key: "SMTP_USERNAME", usage: "SMTP username"},
+	{key: "SMTP_PASSWORD", usage: "SMTP password", secret: true},
+	{key: "SENDGRID_API_KEY", usage: "SendGrid API key", secret: true},
+	{key: "STORAGE_BACKEND", defaultValue: "local", usage: "where uploaded images are kept, local or s3"},
+	{key: "STORAGE_LOCAL_DIR", defaultValue: "uploads", usage: "directory uploaded images are kept in with local storage"},
+	{key: "STORAGE_PUBLIC_URL", usage: "base URL uploaded images are served from; required with s3, the API serves local images under /media when empty"},
+	{key: "S3_BUCKET", usage: "S3 bucket uploaded images are kept in"},
+	{key: "S3_REGION", defaultValue: "us-east-1", usage: "region of the S3 bucket"},
+	{key: "S3_ENDPOINT", usage: "endpoint of an S3-compatible store such as MinIO; AWS S3 is used when empty"},
+	{key: "TOKEN_CURRENCY", defaultValue: "USD", usage: "currency tokens are valued in"},
+	{key: "TOKEN_VALUE_MINOR", defaultValue: "100", usage: "value of one token in minor units of TOKEN_CURRENCY; gift prices are charged in tokens at this value"},
+	{key: "EXCHANGE_RATES_PROVIDER", usage: "service exchange rates are fetched from, fixer or openexchangerates; only prices in TOKEN_CURRENCY can be sent or converted when empty"},
+	{key: "EXCHANGE_RATES_API_KEY", usage: "API key of the exchange rates service", secret: true},
+	{key: "EXCHANGE_RATES_TTL", defaultValue: "1h", usage: "how long fetched exchange rates are used before they are refreshed"},
+	{key: "PAYOUT_FEE_RATE", defaultValue: "0.2", usage: "fraction (0-1) of artist payouts the platform keeps"},
+	{key: "PAYOUT_ONBOARDING_RETURN_URL", defaultValue: "http://localhost:3001/payouts/account", usage: "where artists land after payout account onboarding"},
+	{key: "PAYOUT_ONBOARDING_REFRESH_URL", defaultValue: "http://localhost:3001/payouts/account/refresh", usage: "where artists land when their onboarding link expired"},
+	{key: "JOBS_CONCURRENCY", defaultValue: "10", usage: "background jobs worked at once by each worker"},
+	{key: "JOBS_IN_PROCESS", defaultValue: "true", usage: "work background jobs in the API process too; disable when running separate worker processes"},
+	{key: "REUSE_PORT", defaultValue: "false", usage: "bind the API listener with SO_REUSEPORT"},
+}
+
+var (
+	flagValues = map[string]string{}
+	fileValues = map[string]string{}
+)
+
+// flagName turns a setting key such as DB_HOST into its flag name, db-host
+func flagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+}
+
+// parseFlags records explicitly set flags and returns the config file path and remaining arguments
+func parseFlags(args []string) (string, []string, error) {
+	fs := flag.NewFlagSet("tokentide", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	for _, s := range settings {
+		fs.String(flagName(s.key), "", s.usage)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return "", nil, err
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if flagName(s.key) == f.Name {
+				flagValues[s.key] = f.Value.String()
+			}
+		}
+	})
+
+	return *configFile, fs.Args(), nil
+}
+
+// loadFile reads a flat YAML mapping of setting keys (upper or lower case) to values
+func loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parse config file: %w", err)
+	}
+
+	for key, value := range raw {
+		key = strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		switch v := value.(type) {
+		case nil:
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			fileValues[key] = strings.Join(items, ",")
+		case map[string]any:
+			return fmt.Errorf("config file: %s must be a scalar or a list", key)
+		default:
+			fileValues[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// lookup resolves a key and reports which layer its value came from
+func lookup(key string) (string, string) {
+	if value, ok := flagValues[key]; ok {
+		return value, "flag"
+	}
+	if value, ok := os.LookupEnv(key); ok {
+		return value, "env"
+	}
+	if value, ok := fileValues[key]; ok {
+		return value, "file"
+	}
+	for _, s := range settings {
+		if s.key == key {
+			return s.defaultValue, "default"
+		}
+	}
+	return "", "default"
+}
+
+// redacted resolves a key for display, hiding secret values
+func redacted(s setting) (string, string) {
+	value, source := lookup(s.key)
+	if s.secret && value != "" {
+		value = "********"
+	}
+	return value, source
+}
+
+// Print writes the effective configuration, with secrets redacted
+func Print(w io.Writer) error {
+	var errs []error
+	for _, s := range settings {
+		value, source := redacted(s)
+		_, err := fmt.Fprintf(w, "%s=%s (%s)\n", s.key, value, source)
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Log records the effective configuration, with secrets redacted
+func Log(logger *slog.Logger) {
+	attrs := make([]any, 0, len(settings))
+	for _, s := range settings {
+		value, source := redacted(s)
+		attrs = append(attrs, slog.Group(s.key, slog.String("value", value), slog.String("source", source)))
+	}
+	logger.Info("Effective configuration", attrs...)
+}
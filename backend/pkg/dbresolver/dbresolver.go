@@ -0,0 +1,69 @@
+// Package dbresolver spreads the GORM queries marked with Read over read
+// replicas, leaving every other query on the primary.
+package dbresolver
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// readKey marks a statement as safe to serve from a replica
+const readKey = "dbresolver:read"
+
+// Read marks the queries of db as reads that tolerate replication lag, such
+// as listings and search. They stay on the primary inside a transaction or
+// when they lock rows, and when no replicas are configured.
+func Read(db *gorm.DB) *gorm.DB {
+	return db.Set(readKey, true)
+}
+
+// Resolver is the GORM plugin routing marked reads to replicas, round robin
+type Resolver struct {
+	replicas []gorm.ConnPool
+	next     atomic.Uint64
+}
+
+// New returns a resolver over the connection pools of the replicas
+func New(replicas ...gorm.ConnPool) *Resolver {
+	return &Resolver{replicas: replicas}
+}
+
+// Close closes the connection pools of the replicas
+func (r *Resolver) Close() error {
+	var errs []error
+	for _, replica := range r.replicas {
+		if closer, ok := replica.(interface{ Close() error }); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Resolver) Name() string {
+	return "dbresolver"
+}
+
+func (r *Resolver) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("dbresolver:query", r.route); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("dbresolver:row", r.route)
+}
+
+func (r *Resolver) route(db *gorm.DB) {
+	if len(r.replicas) == 0 {
+		return
+	}
+	if read, _ := db.Get(readKey); read != true {
+		return
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+	if _, locking := db.Statement.Clauses["FOR"]; locking {
+		return
+	}
+	db.Statement.ConnPool = r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+}
@@ -0,0 +1,96 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// nbsp keeps the symbol and the amount on the same line
+const nbsp = "\u00a0"
+
+// Languages that write the currency symbol after the amount, e.g. "1.234,50 €"
+var symbolAfterAmount = map[string]bool{
+	"cs": true, "de": true, "es": true, "fi": true, "fr": true,
+	"it": true, "pl": true, "sk": true, "sv": true,
+}
+
+// Languages that attach the symbol to the amount without a space, e.g. "$1,234.50"
+var symbolWithoutSpace = map[string]bool{
+	"en": true, "ja": true, "ko": true, "zh": true,
+}
+
+// Amount is the representation of money used in API responses; Amount is
+// always in minor units so clients never do float math on it
+type Amount struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Display  string `json:"display"`
+}
+
+// NewAmount builds an API amount with a display string for the given locale
+func NewAmount(amountMinor int64, code, locale string) (Amount, error) {
+	display, err := Format(amountMinor, code, locale)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return Amount{Amount: amountMinor, Currency: strings.ToUpper(code), Display: display}, nil
+}
+
+// Format renders an amount in minor units for display in the given locale
+// (a BCP 47 tag such as "en-US" or "de-DE"), e.g. 123450 USD in de-DE as "1.234,50 $"
+func Format(amountMinor int64, code, locale string) (string, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return "", fmt.Errorf("unknown currency %q", code)
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.AmericanEnglish
+	}
+	// Always use Latin digits so whole and fractional parts are rendered consistently
+	if latn, err := tag.SetTypeForKey("nu", "latn"); err == nil {
+		tag = latn
+	}
+	printer := message.NewPrinter(tag)
+
+	units, _ := currency.Standard.Rounding(unit)
+
+	sign := ""
+	magnitude := uint64(amountMinor)
+	if amountMinor < 0 {
+		sign = "-"
+		magnitude = uint64(-amountMinor)
+	}
+
+	// Format whole and fractional parts separately so large amounts never pass through a float
+	divisor := uint64(1)
+	for i := 0; i < units; i++ {
+		divisor *= 10
+	}
+	digits := printer.Sprint(number.Decimal(magnitude / divisor))
+	if units > 0 {
+		digits += decimalSeparator(printer) + fmt.Sprintf("%0*d", units, magnitude%divisor)
+	}
+
+	symbol := printer.Sprint(currency.Symbol(unit))
+	base, _ := tag.Base()
+	switch {
+	case symbolAfterAmount[base.String()]:
+		return sign + digits + nbsp + symbol, nil
+	case symbolWithoutSpace[base.String()]:
+		return sign + symbol + digits, nil
+	default:
+		return sign + symbol + nbsp + digits, nil
+	}
+}
+
+func decimalSeparator(printer *message.Printer) string {
+	return strings.Trim(printer.Sprint(number.Decimal(0.5, number.Scale(1))), "05")
+}
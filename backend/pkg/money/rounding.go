@@ -0,0 +1,126 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/text/currency"
+)
+
+// RoundingMode decides what happens to fractions of a minor unit
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero; the default for display and conversions
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even unit (banker's rounding)
+	RoundHalfEven
+	// RoundDown truncates toward zero, e.g. for fees charged to the platform
+	RoundDown
+	// RoundUp rounds away from zero, e.g. for fees charged to the customer
+	RoundUp
+)
+
+var ErrAmountOutOfRange = errors.New("amount out of range")
+
+// MinorUnits returns the number of decimal digits of a currency's minor unit (2 for USD, 0 for JPY)
+func MinorUnits(code string) (int, error) {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return 0, fmt.Errorf("unknown currency %q", code)
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	return scale, nil
+}
+
+// ToMinor parses a decimal amount such as "12.345" into minor units of the currency
+func ToMinor(amount string, code string, mode RoundingMode) (int64, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	units, err := MinorUnits(code)
+	if err != nil {
+		return 0, err
+	}
+
+	return round(value.Mul(value, pow10(units)), mode)
+}
+
+// ApplyRate multiplies an amount in minor units by a decimal rate such as "0.05",
+// rounding the result to whole minor units
+func ApplyRate(amountMinor int64, rate string, mode RoundingMode) (int64, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return 0, fmt.Errorf("invalid rate %q", rate)
+	}
+
+	return round(r.Mul(r, new(big.Rat).SetInt64(amountMinor)), mode)
+}
+
+// Convert converts an amount in minor units of one currency into minor units of
+// another using a decimal exchange rate (units of `to` per unit of `from`)
+func Convert(amountMinor int64, from, to, rate string, mode RoundingMode) (int64, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return 0, fmt.Errorf("invalid rate %q", rate)
+	}
+
+	fromUnits, err := MinorUnits(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnits, err := MinorUnits(to)
+	if err != nil {
+		return 0, err
+	}
+
+	value := new(big.Rat).SetInt64(amountMinor)
+	value.Mul(value, r)
+	value.Mul(value, pow10(toUnits))
+	value.Quo(value, pow10(fromUnits))
+	return round(value, mode)
+}
+
+func pow10(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+// round rounds a rational number to an integer according to the mode
+func round(value *big.Rat, mode RoundingMode) (int64, error) {
+	num, den := value.Num(), value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Sign() != 0 {
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+		cmp := twice.Cmp(den)
+
+		var away bool
+		switch mode {
+		case RoundHalfUp:
+			away = cmp >= 0
+		case RoundHalfEven:
+			away = cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1)
+		case RoundDown:
+			away = false
+		case RoundUp:
+			away = true
+		default:
+			return 0, fmt.Errorf("unknown rounding mode %d", mode)
+		}
+
+		if away {
+			quotient.Add(quotient, big.NewInt(int64(num.Sign())))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrAmountOutOfRange
+	}
+	return quotient.Int64(), nil
+}
@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: tokentide/v1/artist.proto
+
+package tokentidev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Artist struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// fan, artist or admin
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artist) Reset() {
+	*x = Artist{}
+	mi := &file_tokentide_v1_artist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artist) ProtoMessage() {}
+
+func (x *Artist) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_artist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artist.ProtoReflect.Descriptor instead.
+func (*Artist) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_artist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Artist) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Artist) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artist) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Artist) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetArtistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArtistRequest) Reset() {
+	*x = GetArtistRequest{}
+	mi := &file_tokentide_v1_artist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArtistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArtistRequest) ProtoMessage() {}
+
+func (x *GetArtistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_artist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArtistRequest.ProtoReflect.Descriptor instead.
+func (*GetArtistRequest) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_artist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetArtistRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_tokentide_v1_artist_proto protoreflect.FileDescriptor
+
+const file_tokentide_v1_artist_proto_rawDesc = "" +
+	"\n" +
+	"\x19tokentide/v1/artist.proto\x12\ftokentide.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"{\n" +
+	"\x06Artist\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\"\n" +
+	"\x10GetArtistRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2R\n" +
+	"\rArtistService\x12A\n" +
+	"\tGetArtist\x12\x1e.tokentide.v1.GetArtistRequest\x1a\x14.tokentide.v1.ArtistB*Z(tokentide/proto/tokentide/v1;tokentidev1b\x06proto3"
+
+var (
+	file_tokentide_v1_artist_proto_rawDescOnce sync.Once
+	file_tokentide_v1_artist_proto_rawDescData []byte
+)
+
+func file_tokentide_v1_artist_proto_rawDescGZIP() []byte {
+	file_tokentide_v1_artist_proto_rawDescOnce.Do(func() {
+		file_tokentide_v1_artist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tokentide_v1_artist_proto_rawDesc), len(file_tokentide_v1_artist_proto_rawDesc)))
+	})
+	return file_tokentide_v1_artist_proto_rawDescData
+}
+
+var file_tokentide_v1_artist_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_tokentide_v1_artist_proto_goTypes = []any{
+	(*Artist)(nil),                // 0: tokentide.v1.Artist
+	(*GetArtistRequest)(nil),      // 1: tokentide.v1.GetArtistRequest
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_tokentide_v1_artist_proto_depIdxs = []int32{
+	2, // 0: tokentide.v1.Artist.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: tokentide.v1.ArtistService.GetArtist:input_type -> tokentide.v1.GetArtistRequest
+	0, // 2: tokentide.v1.ArtistService.GetArtist:output_type -> tokentide.v1.Artist
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_tokentide_v1_artist_proto_init() }
+func file_tokentide_v1_artist_proto_init() {
+	if File_tokentide_v1_artist_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tokentide_v1_artist_proto_rawDesc), len(file_tokentide_v1_artist_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tokentide_v1_artist_proto_goTypes,
+		DependencyIndexes: file_tokentide_v1_artist_proto_depIdxs,
+		MessageInfos:      file_tokentide_v1_artist_proto_msgTypes,
+	}.Build()
+	File_tokentide_v1_artist_proto = out.File
+	file_tokentide_v1_artist_proto_goTypes = nil
+	file_tokentide_v1_artist_proto_depIdxs = nil
+}
@@ -0,0 +1,591 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: tokentide/v1/gift.proto
+
+package tokentidev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Gift struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Price in minor units of currency; senders pay its worth in tokens
+	PriceMinor int64  `protobuf:"varint,4,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency   string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	ArtistId   string `protobuf:"bytes,6,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	ImageUrl   string `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// How many times the gift can be sent in all; unset for unlimited gifts
+	Quantity *int64 `protobuf:"varint,8,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	// Sends so far, net of refunds
+	Sold int64 `protobuf:"varint,9,opt,name=sold,proto3" json:"sold,omitempty"`
+	// How many times each fan can send the gift; unset for no limit
+	PerFanLimit   *int64                 `protobuf:"varint,10,opt,name=per_fan_limit,json=perFanLimit,proto3,oneof" json:"per_fan_limit,omitempty"`
+	Version       int32                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gift) Reset() {
+	*x = Gift{}
+	mi := &file_tokentide_v1_gift_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gift) ProtoMessage() {}
+
+func (x *Gift) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_gift_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gift.ProtoReflect.Descriptor instead.
+func (*Gift) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_gift_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Gift) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Gift) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Gift) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Gift) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *Gift) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Gift) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *Gift) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Gift) GetQuantity() int64 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *Gift) GetSold() int64 {
+	if x != nil {
+		return x.Sold
+	}
+	return 0
+}
+
+func (x *Gift) GetPerFanLimit() int64 {
+	if x != nil && x.PerFanLimit != nil {
+		return *x.PerFanLimit
+	}
+	return 0
+}
+
+func (x *Gift) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Gift) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetGiftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGiftRequest) Reset() {
+	*x = GetGiftRequest{}
+	mi := &file_tokentide_v1_gift_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGiftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGiftRequest) ProtoMessage() {}
+
+func (x *GetGiftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_gift_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGiftRequest.ProtoReflect.Descriptor instead.
+func (*GetGiftRequest) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_gift_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetGiftRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListGiftsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ArtistId   string                 `protobuf:"bytes,1,opt,name=artist_id,json=artistId,proto3" json:"artist_id,omitempty"`
+	CategoryId string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	TagId      string                 `protobuf:"bytes,3,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	Currency   string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Price bounds in minor units; they require currency
+	MinPrice *int64 `protobuf:"varint,5,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice *int64 `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	// One of price, -price, created_at or -created_at
+	Sort string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	// 20 when unset, at most 100
+	Limit         int32 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGiftsRequest) Reset() {
+	*x = ListGiftsRequest{}
+	mi := &file_tokentide_v1_gift_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGiftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGiftsRequest) ProtoMessage() {}
+
+func (x *ListGiftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_gift_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGiftsRequest.ProtoReflect.Descriptor instead.
+func (*ListGiftsRequest) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_gift_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListGiftsRequest) GetArtistId() string {
+	if x != nil {
+		return x.ArtistId
+	}
+	return ""
+}
+
+func (x *ListGiftsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListGiftsRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *ListGiftsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListGiftsRequest) GetMinPrice() int64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListGiftsRequest) GetMaxPrice() int64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListGiftsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListGiftsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListGiftsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListGiftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gifts         []*Gift                `protobuf:"bytes,1,rep,name=gifts,proto3" json:"gifts,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGiftsResponse) Reset() {
+	*x = ListGiftsResponse{}
+	mi := &file_tokentide_v1_gift_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGiftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGiftsResponse) ProtoMessage() {}
+
+func (x *ListGiftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_gift_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGiftsResponse.ProtoReflect.Descriptor instead.
+func (*ListGiftsResponse) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_gift_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListGiftsResponse) GetGifts() []*Gift {
+	if x != nil {
+		return x.Gifts
+	}
+	return nil
+}
+
+func (x *ListGiftsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateGiftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	PriceMinor    int64                  `protobuf:"varint,3,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Quantity      *int64                 `protobuf:"varint,5,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	PerFanLimit   *int64                 `protobuf:"varint,6,opt,name=per_fan_limit,json=perFanLimit,proto3,oneof" json:"per_fan_limit,omitempty"`
+	CategoryIds   []string               `protobuf:"bytes,7,rep,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	TagIds        []string               `protobuf:"bytes,8,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGiftRequest) Reset() {
+	*x = CreateGiftRequest{}
+	mi := &file_tokentide_v1_gift_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGiftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGiftRequest) ProtoMessage() {}
+
+func (x *CreateGiftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tokentide_v1_gift_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGiftRequest.ProtoReflect.Descriptor instead.
+func (*CreateGiftRequest) Descriptor() ([]byte, []int) {
+	return file_tokentide_v1_gift_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateGiftRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGiftRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateGiftRequest) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *CreateGiftRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateGiftRequest) GetQuantity() int64 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateGiftRequest) GetPerFanLimit() int64 {
+	if x != nil && x.PerFanLimit != nil {
+		return *x.PerFanLimit
+	}
+	return 0
+}
+
+func (x *CreateGiftRequest) GetCategoryIds() []string {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+func (x *CreateGiftRequest) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+var File_tokentide_v1_gift_proto protoreflect.FileDescriptor
+
+const file_tokentide_v1_gift_proto_rawDesc = "" +
+	"\n" +
+	"\x17tokentide/v1/gift.proto\x12\ftokentide.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x03\n" +
+	"\x04Gift\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vprice_minor\x18\x04 \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tartist_id\x18\x06 \x01(\tR\bartistId\x12\x1b\n" +
+	"\timage_url\x18\a \x01(\tR\bimageUrl\x12\x1f\n" +
+	"\bquantity\x18\b \x01(\x03H\x00R\bquantity\x88\x01\x01\x12\x12\n" +
+	"\x04sold\x18\t \x01(\x03R\x04sold\x12'\n" +
+	"\rper_fan_limit\x18\n" +
+	" \x01(\x03H\x01R\vperFanLimit\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\v\n" +
+	"\t_quantityB\x10\n" +
+	"\x0e_per_fan_limit\" \n" +
+	"\x0eGetGiftRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa5\x02\n" +
+	"\x10ListGiftsRequest\x12\x1b\n" +
+	"\tartist_id\x18\x01 \x01(\tR\bartistId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x15\n" +
+	"\x06tag_id\x18\x03 \x01(\tR\x05tagId\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12 \n" +
+	"\tmin_price\x18\x05 \x01(\x03H\x00R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\x06 \x01(\x03H\x01R\bmaxPrice\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\t \x01(\x05R\x06offsetB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_price\"S\n" +
+	"\x11ListGiftsResponse\x12(\n" +
+	"\x05gifts\x18\x01 \x03(\v2\x12.tokentide.v1.GiftR\x05gifts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xab\x02\n" +
+	"\x11CreateGiftRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vprice_minor\x18\x03 \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\bquantity\x18\x05 \x01(\x03H\x00R\bquantity\x88\x01\x01\x12'\n" +
+	"\rper_fan_limit\x18\x06 \x01(\x03H\x01R\vperFanLimit\x88\x01\x01\x12!\n" +
+	"\fcategory_ids\x18\a \x03(\tR\vcategoryIds\x12\x17\n" +
+	"\atag_ids\x18\b \x03(\tR\x06tagIdsB\v\n" +
+	"\t_quantityB\x10\n" +
+	"\x0e_per_fan_limit2\xdb\x01\n" +
+	"\vGiftService\x12;\n" +
+	"\aGetGift\x12\x1c.tokentide.v1.GetGiftRequest\x1a\x12.tokentide.v1.Gift\x12L\n" +
+	"\tListGifts\x12\x1e.tokentide.v1.ListGiftsRequest\x1a\x1f.tokentide.v1.ListGiftsResponse\x12A\n" +
+	"\n" +
+	"CreateGift\x12\x1f.tokentide.v1.CreateGiftRequest\x1a\x12.tokentide.v1.GiftB*Z(tokentide/proto/tokentide/v1;tokentidev1b\x06proto3"
+
+var (
+	file_tokentide_v1_gift_proto_rawDescOnce sync.Once
+	file_tokentide_v1_gift_proto_rawDescData []byte
+)
+
+func file_tokentide_v1_gift_proto_rawDescGZIP() []byte {
+	file_tokentide_v1_gift_proto_rawDescOnce.Do(func() {
+		file_tokentide_v1_gift_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tokentide_v1_gift_proto_rawDesc), len(file_tokentide_v1_gift_proto_rawDesc)))
+	})
+	return file_tokentide_v1_gift_proto_rawDescData
+}
+
+var file_tokentide_v1_gift_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_tokentide_v1_gift_proto_goTypes = []any{
+	(*Gift)(nil),                  // 0: tokentide.v1.Gift
+	(*GetGiftRequest)(nil),        // 1: tokentide.v1.GetGiftRequest
+	(*ListGiftsRequest)(nil),      // 2: tokentide.v1.ListGiftsRequest
+	(*ListGiftsResponse)(nil),     // 3: tokentide.v1.ListGiftsResponse
+	(*CreateGiftRequest)(nil),     // 4: tokentide.v1.CreateGiftRequest
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_tokentide_v1_gift_proto_depIdxs = []int32{
+	5, // 0: tokentide.v1.Gift.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: tokentide.v1.ListGiftsResponse.gifts:type_name -> tokentide.v1.Gift
+	1, // 2: tokentide.v1.GiftService.GetGift:input_type -> tokentide.v1.GetGiftRequest
+	2, // 3: tokentide.v1.GiftService.ListGifts:input_type -> tokentide.v1.ListGiftsRequest
+	4, // 4: tokentide.v1.GiftService.CreateGift:input_type -> tokentide.v1.CreateGiftRequest
+	0, // 5: tokentide.v1.GiftService.GetGift:output_type -> tokentide.v1.Gift
+	3, // 6: tokentide.v1.GiftService.ListGifts:output_type -> tokentide.v1.ListGiftsResponse
+	0, // 7: tokentide.v1.GiftService.CreateGift:output_type -> tokentide.v1.Gift
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_tokentide_v1_gift_proto_init() }
+func file_tokentide_v1_gift_proto_init() {
+	if File_tokentide_v1_gift_proto != nil {
+		return
+	}
+	file_tokentide_v1_gift_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tokentide_v1_gift_proto_msgTypes[2].OneofWrappers = []any{}
+	file_tokentide_v1_gift_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tokentide_v1_gift_proto_rawDesc), len(file_tokentide_v1_gift_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tokentide_v1_gift_proto_goTypes,
+		DependencyIndexes: file_tokentide_v1_gift_proto_depIdxs,
+		MessageInfos:      file_tokentide_v1_gift_proto_msgTypes,
+	}.Build()
+	File_tokentide_v1_gift_proto = out.File
+	file_tokentide_v1_gift_proto_goTypes = nil
+	file_tokentide_v1_gift_proto_depIdxs = nil
+}
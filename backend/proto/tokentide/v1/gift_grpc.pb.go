@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: tokentide/v1/gift.proto
+
+package tokentidev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GiftService_GetGift_FullMethodName    = "/tokentide.v1.GiftService/GetGift"
+	GiftService_ListGifts_FullMethodName  = "/tokentide.v1.GiftService/ListGifts"
+	GiftService_CreateGift_FullMethodName = "/tokentide.v1.GiftService/CreateGift"
+)
+
+// GiftServiceClient is the client API for GiftService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GiftService browses and publishes gifts
+type GiftServiceClient interface {
+	// GetGift returns a gift by ID
+	GetGift(ctx context.Context, in *GetGiftRequest, opts ...grpc.CallOption) (*Gift, error)
+	// ListGifts returns a page of gifts, newest first unless sorted otherwise
+	ListGifts(ctx context.Context, in *ListGiftsRequest, opts ...grpc.CallOption) (*ListGiftsResponse, error)
+	// CreateGift publishes a gift owned by the caller, who must be an artist or an admin
+	CreateGift(ctx context.Context, in *CreateGiftRequest, opts ...grpc.CallOption) (*Gift, error)
+}
+
+type giftServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGiftServiceClient(cc grpc.ClientConnInterface) GiftServiceClient {
+	return &giftServiceClient{cc}
+}
+
+func (c *giftServiceClient) GetGift(ctx context.Context, in *GetGiftRequest, opts ...grpc.CallOption) (*Gift, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Gift)
+	err := c.cc.Invoke(ctx, GiftService_GetGift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftServiceClient) ListGifts(ctx context.Context, in *ListGiftsRequest, opts ...grpc.CallOption) (*ListGiftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGiftsResponse)
+	err := c.cc.Invoke(ctx, GiftService_ListGifts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giftServiceClient) CreateGift(ctx context.Context, in *CreateGiftRequest, opts ...grpc.CallOption) (*Gift, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Gift)
+	err := c.cc.Invoke(ctx, GiftService_CreateGift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GiftServiceServer is the server API for GiftService service.
+// All implementations must embed UnimplementedGiftServiceServer
+// for forward compatibility.
+//
+// GiftService browses and publishes gifts
+type GiftServiceServer interface {
+	// GetGift returns a gift by ID
+	GetGift(context.Context, *GetGiftRequest) (*Gift, error)
+	// ListGifts returns a page of gifts, newest first unless sorted otherwise
+	ListGifts(context.Context, *ListGiftsRequest) (*ListGiftsResponse, error)
+	// CreateGift publishes a gift owned by the caller, who must be an artist or an admin
+	CreateGift(context.Context, *CreateGiftRequest) (*Gift, error)
+	mustEmbedUnimplementedGiftServiceServer()
+}
+
+// UnimplementedGiftServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGiftServiceServer struct{}
+
+func (UnimplementedGiftServiceServer) GetGift(context.Context, *GetGiftRequest) (*Gift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGift not implemented")
+}
+func (UnimplementedGiftServiceServer) ListGifts(context.Context, *ListGiftsRequest) (*ListGiftsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGifts not implemented")
+}
+func (UnimplementedGiftServiceServer) CreateGift(context.Context, *CreateGiftRequest) (*Gift, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGift not implemented")
+}
+func (UnimplementedGiftServiceServer) mustEmbedUnimplementedGiftServiceServer() {}
+func (UnimplementedGiftServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeGiftServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GiftServiceServer will
+// result in compilation errors.
+type UnsafeGiftServiceServer interface {
+	mustEmbedUnimplementedGiftServiceServer()
+}
+
+func RegisterGiftServiceServer(s grpc.ServiceRegistrar, srv GiftServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGiftServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GiftService_ServiceDesc, srv)
+}
+
+func _GiftService_GetGift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftServiceServer).GetGift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiftService_GetGift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftServiceServer).GetGift(ctx, req.(*GetGiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftService_ListGifts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGiftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftServiceServer).ListGifts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiftService_ListGifts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftServiceServer).ListGifts(ctx, req.(*ListGiftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiftService_CreateGift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftServiceServer).CreateGift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiftService_CreateGift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftServiceServer).CreateGift(ctx, req.(*CreateGiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GiftService_ServiceDesc is the grpc.ServiceDesc for GiftService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GiftService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tokentide.v1.GiftService",
+	HandlerType: (*GiftServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetGift",
+			Handler:    _GiftService_GetGift_Handler,
+		},
+		{
+			MethodName: "ListGifts",
+			Handler:    _GiftService_ListGifts_Handler,
+		},
+		{
+			MethodName: "CreateGift",
+			Handler:    _GiftService_CreateGift_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tokentide/v1/gift.proto",
+}